@@ -0,0 +1,67 @@
+package fts
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const compositeKeySep = "x"
+
+// CompositeKey is a two-part key, such as a tenant and an ID, that is stored in a single id column of an Index; so that
+// an Index can be keyed by multi-part keys, as Index[CompositeKey, V].
+//
+// The parts are hex-encoded and joined by an "x" separator, which makes up a single FTS5 token. This is so that
+// deleting entries by key matches the whole key and nothing else (and so that entries from different tenants are
+// isolated from each other), and so that searches for a value do not match the keys' contents. It also means that a
+// CompositeKey must not be used with tokenizer settings (WithTokenChars or WithSeparators) that split on alphanumeric
+// characters.
+type CompositeKey struct {
+	Tenant string
+	ID     string
+}
+
+// Value implements the driver.Valuer interface.
+func (k CompositeKey) Value() (driver.Value, error) {
+	return hex.EncodeToString([]byte(k.Tenant)) + compositeKeySep + hex.EncodeToString([]byte(k.ID)), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (k *CompositeKey) Scan(src any) error {
+	var value string
+
+	switch v := src.(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("unsupported composite key type: %T", src)
+	}
+
+	tenant, id, ok := strings.Cut(value, compositeKeySep)
+	if !ok {
+		return fmt.Errorf("invalid composite key: %q", value)
+	}
+
+	tenantBytes, err := hex.DecodeString(tenant)
+	if err != nil {
+		return fmt.Errorf("invalid composite key tenant: %w", err)
+	}
+
+	idBytes, err := hex.DecodeString(id)
+	if err != nil {
+		return fmt.Errorf("invalid composite key ID: %w", err)
+	}
+
+	k.Tenant = string(tenantBytes)
+	k.ID = string(idBytes)
+
+	return nil
+}
+
+// String implements the fmt.Stringer interface.
+func (k CompositeKey) String() string {
+	return k.Tenant + "/" + k.ID
+}
@@ -0,0 +1,133 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompositeKey(t *testing.T) {
+	attrs := []Attribute[CompositeKey, string]{
+		{Key: CompositeKey{Tenant: "acme", ID: "1"}, Value: "gold"},
+		{Key: CompositeKey{Tenant: "acme", ID: "2"}, Value: "struck gold"},
+		{Key: CompositeKey{Tenant: "initech", ID: "1"}, Value: "gold"},
+		{Key: CompositeKey{Tenant: "initech", ID: "acme 1"}, Value: "silver"},
+	}
+
+	for _, testcase := range []struct {
+		name   string
+		delete []CompositeKey
+		term   string
+		wants  []Attribute[CompositeKey, string]
+		err    error
+	}{
+		{
+			name: "Success/RoundTrip",
+			term: "gold",
+			wants: []Attribute[CompositeKey, string]{
+				{Key: CompositeKey{Tenant: "acme", ID: "1"}, Value: "gold"},
+				{Key: CompositeKey{Tenant: "acme", ID: "2"}, Value: "struck gold"},
+				{Key: CompositeKey{Tenant: "initech", ID: "1"}, Value: "gold"},
+			},
+		},
+		{
+			name:   "Success/DeleteIsolatedByTenant",
+			delete: []CompositeKey{{Tenant: "acme", ID: "1"}},
+			term:   "gold OR silver",
+			wants: []Attribute[CompositeKey, string]{
+				{Key: CompositeKey{Tenant: "acme", ID: "2"}, Value: "struck gold"},
+				{Key: CompositeKey{Tenant: "initech", ID: "1"}, Value: "gold"},
+				{Key: CompositeKey{Tenant: "initech", ID: "acme 1"}, Value: "silver"},
+			},
+		},
+		{
+			name:   "Success/DeleteUnknownKey",
+			delete: []CompositeKey{{Tenant: "globex", ID: "1"}},
+			term:   "silver",
+			wants: []Attribute[CompositeKey, string]{
+				{Key: CompositeKey{Tenant: "initech", ID: "acme 1"}, Value: "silver"},
+			},
+		},
+		{
+			name: "Fail/KeysNotSearchable",
+			term: "acme",
+			err:  ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			if len(testcase.delete) > 0 {
+				require.NoError(t, index.Delete(ctx, testcase.delete...))
+			}
+
+			res, err := index.Search(ctx, testcase.term)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.ElementsMatch(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestCompositeKey_Scan(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		src   any
+		wants CompositeKey
+		fails bool
+	}{
+		{
+			name:  "Success/String",
+			src:   "61636d65x31",
+			wants: CompositeKey{Tenant: "acme", ID: "1"},
+		},
+		{
+			name:  "Success/Bytes",
+			src:   []byte("61636d65x"),
+			wants: CompositeKey{Tenant: "acme"},
+		},
+		{
+			name:  "Fail/NoSeparator",
+			src:   "61636d65",
+			fails: true,
+		},
+		{
+			name:  "Fail/InvalidHex",
+			src:   "acmex1",
+			fails: true,
+		},
+		{
+			name:  "Fail/UnsupportedType",
+			src:   int64(1),
+			fails: true,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var key CompositeKey
+
+			err := key.Scan(testcase.src)
+			if testcase.fails {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, key)
+		})
+	}
+}
@@ -5,12 +5,25 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io/fs"
+	"net/url"
 	"os"
+	"regexp"
+	"slices"
+	"strings"
 )
 
 const (
-	uriFormat = "file:%s?cache=shared"
-	inMemory  = ":memory:"
+	uriFormat       = "file:%s?cache=%s"
+	memoryURIFormat = "file:%s?mode=memory&cache=%s"
+	inMemory        = ":memory:"
+
+	cacheShared  = "shared"
+	cachePrivate = "private"
+
+	tempStoreDefault = "DEFAULT"
+	tempStoreFile    = "FILE"
+	tempStoreMemory  = "MEMORY"
 
 	checkTableExists = `
 SELECT EXISTS(SELECT 1 FROM sqlite_master 
@@ -20,73 +33,301 @@ SELECT EXISTS(SELECT 1 FROM sqlite_master
 
 	createTableQuery = `
 CREATE VIRTUAL TABLE fulltext_search 
-	USING FTS5(id, val);
+	USING FTS5(id, val%s);
+`
+
+	defaultTokenizer = "unicode61"
+
+	createVocabTableQuery = `
+CREATE VIRTUAL TABLE IF NOT EXISTS fulltext_search_vocab 
+	USING fts5vocab(fulltext_search, col);
 `
 )
 
-func open(uri string) (*sql.DB, error) {
+// open opens a connection pool to the SQLite database at the input URI, with the input cache mode. If the URI is
+// in-memory and a memory name is set, the database is a named in-memory database, only shared by connections using the
+// same name. The input pragmas (e.g. "busy_timeout(5000)") are executed on every new connection. A maxOpenConns value
+// above zero caps the number of open connections in the pool.
+//
+// If a raw DSN is set (see WithRawDSN), it is used verbatim instead, ignoring the memory name, cache mode and pragmas;
+// where the URI is expected to be its file part (see dsnPath).
+func open(uri, rawDSN, memoryName, cacheMode string, pragmas []string, maxOpenConns int) (*sql.DB, error) {
 	switch uri {
-	case inMemory:
-	case "":
-		uri = inMemory
+	case inMemory, "":
 	default:
 		if err := validateURI(uri); err != nil {
 			return nil, err
 		}
 	}
 
-	db, err := sql.Open("sqlite", fmt.Sprintf(uriFormat, uri))
+	db, err := sql.Open("sqlite", dataSourceName(uri, rawDSN, memoryName, cacheMode, pragmas))
 	if err != nil {
 		return nil, err
 	}
 
+	setPoolLimits(db, uri, cacheMode, maxOpenConns)
+
 	return db, nil
 }
 
+// dataSourceName returns the DSN for the SQLite database at the input URI, as described in open.
+func dataSourceName(uri, rawDSN, memoryName, cacheMode string, pragmas []string) string {
+	if rawDSN != "" {
+		return rawDSN
+	}
+
+	if uri == "" {
+		uri = inMemory
+	}
+
+	if cacheMode == "" {
+		cacheMode = cacheShared
+	}
+
+	dsn := fmt.Sprintf(uriFormat, uri, cacheMode)
+	if uri == inMemory && memoryName != "" {
+		dsn = fmt.Sprintf(memoryURIFormat, url.PathEscape(memoryName), cacheMode)
+	}
+
+	for _, pragma := range pragmas {
+		dsn += "&_pragma=" + url.QueryEscape(pragma)
+	}
+
+	return dsn
+}
+
+// setPoolLimits caps the number of open connections in the input pool, as described in open.
+func setPoolLimits(db *sql.DB, uri, cacheMode string, maxOpenConns int) {
+	if maxOpenConns > 0 {
+		db.SetMaxOpenConns(maxOpenConns)
+	}
+
+	// each connection to a private in-memory database has its own (empty) database, so the pool is capped to a single
+	// connection.
+	if isInMemory(uri) && cacheMode == cachePrivate {
+		db.SetMaxOpenConns(1)
+	}
+}
+
+// dsnPath returns the file part of the input DSN, without its "file:" scheme and its query parameters; or an empty
+// string if the DSN refers to an in-memory database (either as ":memory:" or with a "mode=memory" parameter).
+func dsnPath(dsn string) string {
+	path, query, _ := strings.Cut(strings.TrimPrefix(dsn, "file:"), "?")
+
+	if params, err := url.ParseQuery(query); err == nil && params.Get("mode") == "memory" {
+		return ""
+	}
+
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+
+	if path == inMemory {
+		return ""
+	}
+
+	return path
+}
+
+func isInMemory(uri string) bool {
+	return uri == "" || uri == inMemory
+}
+
+// validateURI checks that the input URI is a file path that the Index can open, creating the file if it doesn't exist.
+//
+// Filesystem errors are wrapped in an ErrDatabaseAccess error, including the path and the underlying cause; describing
+// whether the file could not be created (e.g. when its parent directory doesn't exist), whether the path is a
+// directory, or whether permission is denied.
 func validateURI(uri string) error {
 	stat, err := os.Stat(uri)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		f, err := os.Create(uri)
+		if err != nil {
+			if errors.Is(err, fs.ErrPermission) {
+				return fmt.Errorf("%w: permission denied creating %s: %w", ErrDatabaseAccess, uri, err)
+			}
+
+			return fmt.Errorf("%w: %s does not exist and cannot be created: %w", ErrDatabaseAccess, uri, err)
+		}
+
+		return f.Close()
+	case errors.Is(err, fs.ErrPermission):
+		return fmt.Errorf("%w: permission denied accessing %s: %w", ErrDatabaseAccess, uri, err)
+	case err != nil:
+		return fmt.Errorf("%w: %s: %w", ErrDatabaseAccess, uri, err)
+	case stat.IsDir():
+		return fmt.Errorf("%w: %s is a directory", ErrDatabaseAccess, uri)
+	}
+
+	// the database file is opened for reading and writing, which would otherwise fail later on with a less clear
+	// "unable to open database file" error from SQLite.
+	f, err := os.OpenFile(uri, os.O_RDWR, 0)
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			f, err := os.Create(uri)
-			if err != nil {
-				return err
+		if errors.Is(err, fs.ErrPermission) {
+			return fmt.Errorf("%w: permission denied opening %s: %w", ErrDatabaseAccess, uri, err)
+		}
+
+		return fmt.Errorf("%w: %s: %w", ErrDatabaseAccess, uri, err)
+	}
+
+	return f.Close()
+}
+
+var (
+	// tokenizers lists the FTS5 built-in tokenizers that can be set with WithTokenizer.
+	tokenizers = []string{"unicode61", "ascii", "porter", "trigram"}
+
+	// bareTokenizerArg matches the tokenizer arguments that don't need to be quoted.
+	bareTokenizerArg = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+	// tokenizeOption matches the tokenize option in the statement that created the fulltext_search table, quoted either
+	// as a string or as an identifier.
+	tokenizeOption = regexp.MustCompile(`(?i)tokenize\s*=\s*(?:"((?:[^"]|"")*)"|'((?:[^']|'')*)')`)
+)
+
+// tokenizer describes the FTS5 tokenizer configuration of the fulltext_search table: either a tokenizer set by name
+// and arguments (see WithTokenizer), or the unicode61 tokenizer with its options.
+type tokenizer struct {
+	name       string
+	args       []string
+	porter     bool
+	tokenChars string
+	separators string
+}
+
+// spec returns the tokenize option for the table's creation statement, or an empty string if the default tokenizer
+// is used.
+//
+// Each argument is quoted as an FTS5 string, and the whole spec is wrapped in double quotes, escaping any quotes in
+// the arguments.
+func (t tokenizer) spec() string {
+	if t.name == "" && !t.porter && t.tokenChars == "" && t.separators == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(", tokenize = \"%s\"", strings.ReplaceAll(t.String(), `"`, `""`))
+}
+
+// String returns the tokenizer and its arguments, as configured when creating the fulltext_search table.
+func (t tokenizer) String() string {
+	if t.name != "" {
+		args := make([]string, 0, len(t.args)+1)
+		args = append(args, t.name)
+
+		for _, arg := range t.args {
+			if !bareTokenizerArg.MatchString(arg) {
+				arg = quoteTokenizerArg(arg)
 			}
 
-			return f.Close()
+			args = append(args, arg)
 		}
 
+		return strings.Join(args, " ")
+	}
+
+	args := make([]string, 0, 6)
+
+	if t.porter {
+		args = append(args, "porter")
+	}
+
+	args = append(args, "unicode61")
+
+	if t.tokenChars != "" {
+		args = append(args, "tokenchars", quoteTokenizerArg(t.tokenChars))
+	}
+
+	if t.separators != "" {
+		args = append(args, "separators", quoteTokenizerArg(t.separators))
+	}
+
+	return strings.Join(args, " ")
+}
+
+func quoteTokenizerArg(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", "''") + "'"
+}
+
+// checkTokenizer verifies that the fulltext_search table of an existing database was created with the input
+// tokenizer, returning an ErrInvalidTokenizer error describing both tokenizers if they differ. A table created
+// without a tokenize option uses the default (unicode61) tokenizer.
+func checkTokenizer(ctx context.Context, db *sql.DB, tok tokenizer) error {
+	var tableSQL string
+	if err := db.QueryRowContext(ctx, tableSQLQuery).Scan(&tableSQL); err != nil {
 		return err
 	}
 
-	if stat.IsDir() {
-		return fmt.Errorf("%s is a directory", uri)
+	existing := tableTokenizer(tableSQL)
+
+	if !slices.Equal(strings.Fields(existing), strings.Fields(tok.String())) {
+		return fmt.Errorf("%w: database was created with the %q tokenizer, configured with %q",
+			ErrInvalidTokenizer, existing, tok.String())
 	}
 
 	return nil
 }
 
-func initDatabase(db *sql.DB) error {
+// tableTokenizer returns the tokenizer that the input CREATE VIRTUAL TABLE statement sets in its tokenize option, or
+// the default (unicode61) tokenizer if it has none.
+func tableTokenizer(tableSQL string) string {
+	match := tokenizeOption.FindStringSubmatch(tableSQL)
+
+	switch {
+	case match == nil:
+		return defaultTokenizer
+	case match[1] != "":
+		return strings.ReplaceAll(match[1], `""`, `"`)
+	default:
+		return strings.ReplaceAll(match[2], `''`, `'`)
+	}
+}
+
+// initDatabase sets the input database-level pragmas, and creates the Index's tables if they don't exist yet (or checks
+// their storage, otherwise).
+func initDatabase(db *sql.DB, tok tokenizer, store storage, pragmas []string) error {
 	ctx := context.Background()
-	r, err := db.QueryContext(ctx, checkTableExists)
-	if err != nil {
+
+	for _, pragma := range pragmas {
+		if _, err := db.ExecContext(ctx, "PRAGMA "+pragma+";"); err != nil {
+			return fmt.Errorf("setting pragma %q: %w", pragma, err)
+		}
+	}
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, checkTableExists).Scan(&exists); err != nil {
 		return err
 	}
 
-	defer r.Close()
+	switch {
+	case exists:
+		if err := checkStorage(ctx, db, store); err != nil {
+			return err
+		}
 
-	for r.Next() {
-		var count int
-		if err = r.Scan(&count); err != nil {
+		if tok.name != "" {
+			if err := checkTokenizer(ctx, db, tok); err != nil {
+				return err
+			}
+		}
+	case store.external():
+		if err := createExternalTables(ctx, db, tok, store); err != nil {
 			return err
 		}
+	default:
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(createTableQuery, tok.spec())); err != nil {
+			return err
+		}
+	}
 
-		if count == 1 {
-			return nil
+	if store.external() {
+		if _, err := db.ExecContext(ctx, createValuesKeyIndexQuery); err != nil {
+			return err
 		}
 	}
 
-	_, err = db.ExecContext(ctx, createTableQuery)
-	if err != nil {
+	if _, err := db.ExecContext(ctx, createVocabTableQuery); err != nil {
 		return err
 	}
 
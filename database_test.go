@@ -0,0 +1,506 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestTokenizer(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "a gold-plate"},
+		{Key: 2, Value: "a gold plate"},
+		{Key: 3, Value: "fooxbar"},
+		{Key: 4, Value: "crème brûlée"},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		opts  []cfg.Option[Config]
+		query string
+		wants []int
+		err   error
+	}{
+		{
+			name:  "Default/HyphenSplitsTokens",
+			query: `"gold-plate"`,
+			wants: []int{1, 2},
+		},
+		{
+			name:  "TokenChars/HyphenatedPhrase",
+			opts:  []cfg.Option[Config]{WithTokenChars("-")},
+			query: `"gold-plate"`,
+			wants: []int{1},
+		},
+		{
+			name:  "TokenChars/SingleWord",
+			opts:  []cfg.Option[Config]{WithTokenChars("-")},
+			query: "gold",
+			wants: []int{2},
+		},
+		{
+			name:  "TokenChars/QuoteCharacters",
+			opts:  []cfg.Option[Config]{WithTokenChars(`-'"`)},
+			query: `"gold-plate"`,
+			wants: []int{1},
+		},
+		{
+			name:  "Separators/SplitsOnCharacter",
+			opts:  []cfg.Option[Config]{WithSeparators("x")},
+			query: "bar",
+			wants: []int{3},
+		},
+		{
+			name:  "Porter/MatchesInflections",
+			opts:  []cfg.Option[Config]{WithPorterStemmer()},
+			query: "plates",
+			wants: []int{1, 2},
+		},
+		{
+			name:  "Tokenizer/PorterWithDiacritics",
+			opts:  []cfg.Option[Config]{WithTokenizer("porter", "unicode61", "remove_diacritics", "2")},
+			query: "plates OR creme",
+			wants: []int{1, 2, 4},
+		},
+		{
+			name:  "Tokenizer/ASCIIKeepsDiacritics",
+			opts:  []cfg.Option[Config]{WithTokenizer("ascii")},
+			query: "creme",
+			err:   ErrNotFoundKeyword,
+		},
+		{
+			name:  "Tokenizer/Trigram",
+			opts:  []cfg.Option[Config]{WithTokenizer("trigram")},
+			query: "oxb",
+			wants: []int{3},
+		},
+		{
+			name:  "Tokenizer/UnknownIsNoOp",
+			opts:  []cfg.Option[Config]{WithTokenizer("icu")},
+			query: "bar",
+			err:   ErrNotFoundKeyword,
+		},
+		{
+			name:  "Default/NoSeparator",
+			query: "bar",
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](
+				cfg.New(append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...),
+				attrs...,
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.Search(ctx, testcase.query)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			keys := make([]int, 0, len(res))
+			for i := range res {
+				keys = append(keys, res[i].Key)
+			}
+
+			require.Equal(t, testcase.wants, keys)
+		})
+	}
+}
+
+func TestTokenizerMismatch(t *testing.T) {
+	ctx := context.Background()
+	uri := filepath.Join(t.TempDir(), "index.db")
+
+	index, err := newIndex[int, string](cfg.New(WithURI(uri), WithPorterStemmer()))
+	require.NoError(t, err)
+	require.NoError(t, index.Shutdown(ctx))
+
+	// the same tokenizer, set by name
+	index, err = newIndex[int, string](cfg.New(WithURI(uri), WithTokenizer("porter", "unicode61")))
+	require.NoError(t, err)
+	require.NoError(t, index.Shutdown(ctx))
+
+	_, err = newIndex[int, string](cfg.New(WithURI(uri), WithTokenizer("trigram")))
+	require.ErrorIs(t, err, ErrInvalidTokenizer)
+	require.ErrorContains(t, err, `created with the "porter unicode61" tokenizer`)
+
+	// without WithTokenizer, existing tables keep their tokenizer
+	index, err = NewIndex[int, string](uri)
+	require.NoError(t, err)
+	require.NoError(t, index.Shutdown(ctx))
+}
+
+func TestCacheMode(t *testing.T) {
+	for _, testcase := range []struct {
+		name     string
+		uri      func(t *testing.T) string
+		mode     string
+		isolated bool
+	}{
+		{
+			name: "Shared/InMemory",
+			uri:  func(*testing.T) string { return inMemory },
+			mode: cacheShared,
+		},
+		{
+			name:     "Private/InMemory",
+			uri:      func(*testing.T) string { return inMemory },
+			mode:     cachePrivate,
+			isolated: true,
+		},
+		{
+			name: "Private/File",
+			uri:  func(t *testing.T) string { return filepath.Join(t.TempDir(), "index.db") },
+			mode: cachePrivate,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			uri := testcase.uri(t)
+
+			index, err := newIndex[int, string](
+				cfg.New(WithURI(uri), WithCacheMode(testcase.mode)),
+				Attribute[int, string]{Key: 1, Value: "some data"},
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			require.NoError(t, index.Insert(ctx, Attribute[int, string]{Key: 2, Value: "struck gold"}))
+
+			res, err := index.Search(ctx, "data OR gold")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{
+				{Key: 1, Value: "some data"},
+				{Key: 2, Value: "struck gold"},
+			}, res)
+
+			if uri != inMemory {
+				return
+			}
+
+			// a second in-memory index only sees the first one's data in shared-cache mode
+			other, err := newIndex[int, string](cfg.New(WithURI(uri), WithCacheMode(testcase.mode)))
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, other.Shutdown(ctx))
+			}()
+
+			_, err = other.Search(ctx, "data")
+			if testcase.isolated {
+				require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMemoryName(t *testing.T) {
+	for _, testcase := range []struct {
+		name      string
+		first     string
+		second    string
+		wantsData bool
+	}{
+		{
+			name:   "Isolated/DistinctNames",
+			first:  "test-memory-name-first",
+			second: "test-memory-name-second",
+		},
+		{
+			name:      "Shared/SameName",
+			first:     "test-memory-name-shared",
+			second:    "test-memory-name-shared",
+			wantsData: true,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](
+				cfg.New(WithMemoryName(testcase.first)),
+				Attribute[int, string]{Key: 1, Value: "some data"},
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			other, err := newIndex[int, string](cfg.New(WithMemoryName(testcase.second)))
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, other.Shutdown(ctx))
+			}()
+
+			_, err = other.Search(ctx, "data")
+			if !testcase.wantsData {
+				require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInitialPragmas(t *testing.T) {
+	for _, testcase := range []struct {
+		name    string
+		pragmas map[string]string
+		wants   int
+	}{
+		{
+			name:  "Default",
+			wants: 0,
+		},
+		{
+			name:    "AutoVacuumFull",
+			pragmas: map[string]string{"auto_vacuum": "FULL"},
+			wants:   1,
+		},
+		{
+			name:    "Ignored/UnknownPragma",
+			pragmas: map[string]string{"writable_schema": "ON"},
+			wants:   0,
+		},
+		{
+			name:    "Ignored/InvalidValue",
+			pragmas: map[string]string{"auto_vacuum": "FULL; DROP TABLE fulltext_search"},
+			wants:   0,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New(
+				WithURI(filepath.Join(t.TempDir(), "index.db")),
+				WithInitialPragmas(testcase.pragmas),
+			))
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			var autoVacuum int
+			require.NoError(t, index.db.QueryRowContext(ctx, "PRAGMA auto_vacuum;").Scan(&autoVacuum))
+			require.Equal(t, testcase.wants, autoVacuum)
+		})
+	}
+}
+
+func TestConnectionPragmas(t *testing.T) {
+	for _, testcase := range []struct {
+		name            string
+		opts            []cfg.Option[Config]
+		wantTempStore   int
+		wantForeignKeys int
+	}{
+		{
+			name: "Default",
+		},
+		{
+			name:          "TempStoreMemory",
+			opts:          []cfg.Option[Config]{WithTempStore("memory")},
+			wantTempStore: 2,
+		},
+		{
+			name:          "TempStoreFile",
+			opts:          []cfg.Option[Config]{WithTempStore("FILE")},
+			wantTempStore: 1,
+		},
+		{
+			name: "TempStoreInvalid/NoOp",
+			opts: []cfg.Option[Config]{WithTempStore("disk")},
+		},
+		{
+			name:            "ForeignKeys",
+			opts:            []cfg.Option[Config]{WithForeignKeys(true)},
+			wantForeignKeys: 1,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](
+				cfg.New(append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...),
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			conn, err := index.db.Conn(ctx)
+			require.NoError(t, err)
+
+			defer conn.Close()
+
+			var tempStore, foreignKeys int
+			require.NoError(t, conn.QueryRowContext(ctx, "PRAGMA temp_store;").Scan(&tempStore))
+			require.NoError(t, conn.QueryRowContext(ctx, "PRAGMA foreign_keys;").Scan(&foreignKeys))
+
+			require.Equal(t, testcase.wantTempStore, tempStore)
+			require.Equal(t, testcase.wantForeignKeys, foreignKeys)
+		})
+	}
+}
+
+func TestDatabaseAccess(t *testing.T) {
+	for _, testcase := range []struct {
+		name       string
+		uri        func(t *testing.T) string
+		permission bool
+		msg        string
+	}{
+		{
+			name: "MissingParent",
+			uri: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "missing", "index.db")
+			},
+			msg: "does not exist and cannot be created",
+		},
+		{
+			name: "Directory",
+			uri: func(t *testing.T) string {
+				return t.TempDir()
+			},
+			msg: "is a directory",
+		},
+		{
+			name: "ReadOnlyDirectory",
+			uri: func(t *testing.T) string {
+				dir := filepath.Join(t.TempDir(), "readonly")
+				require.NoError(t, os.Mkdir(dir, 0o500))
+
+				return filepath.Join(dir, "index.db")
+			},
+			permission: true,
+			msg:        "permission denied creating",
+		},
+		{
+			name: "ReadOnlyFile",
+			uri: func(t *testing.T) string {
+				uri := filepath.Join(t.TempDir(), "index.db")
+				require.NoError(t, os.WriteFile(uri, nil, 0o400))
+
+				return uri
+			},
+			permission: true,
+			msg:        "permission denied opening",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			if testcase.permission && (runtime.GOOS == "windows" || os.Geteuid() == 0) {
+				t.Skip("file permissions are not enforced for this user")
+			}
+
+			uri := testcase.uri(t)
+
+			_, err := NewIndex[int, string](uri)
+			require.ErrorIs(t, err, ErrDatabaseAccess)
+			require.ErrorContains(t, err, uri)
+			require.ErrorContains(t, err, testcase.msg)
+
+			if testcase.permission {
+				require.ErrorIs(t, err, fs.ErrPermission)
+			}
+		})
+	}
+}
+
+func TestRawDSN(t *testing.T) {
+	for _, testcase := range []struct {
+		name     string
+		dsn      string
+		opts     []cfg.Option[Config]
+		inMemory bool
+	}{
+		{
+			name: "FileScheme",
+			dsn:  "file:%s?_pragma=busy_timeout(5000)",
+		},
+		{
+			name: "PlainPath",
+			dsn:  "%s?_pragma=busy_timeout(5000)",
+		},
+		{
+			name: "OverridesURI",
+			dsn:  "file:%s?_pragma=busy_timeout(5000)",
+			opts: []cfg.Option[Config]{WithURI(inMemory)},
+		},
+		{
+			name:     "InMemory",
+			dsn:      "file::memory:?_pragma=busy_timeout(5000)",
+			inMemory: true,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "index.db")
+
+			dsn := testcase.dsn
+			if !testcase.inMemory {
+				dsn = fmt.Sprintf(dsn, path)
+			}
+
+			index, err := newIndex[int, string](
+				cfg.New(append(testcase.opts, WithRawDSN(dsn))...),
+				Attribute[int, string]{Key: 1, Value: "some data"},
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			conn, err := index.db.Conn(ctx)
+			require.NoError(t, err)
+
+			defer conn.Close()
+
+			var busyTimeout int
+			require.NoError(t, conn.QueryRowContext(ctx, "PRAGMA busy_timeout;").Scan(&busyTimeout))
+			require.Equal(t, 5000, busyTimeout)
+			require.Equal(t, testcase.inMemory, index.inMemory)
+
+			res, err := index.Search(ctx, "data")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{{Key: 1, Value: "some data"}}, res)
+
+			if testcase.inMemory {
+				return
+			}
+
+			require.Equal(t, path, index.uri)
+			require.FileExists(t, path)
+		})
+	}
+}
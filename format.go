@@ -0,0 +1,181 @@
+package fts
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// OutputFormat defines the text representation of search results written by Format.
+type OutputFormat string
+
+const (
+	// FormatTable writes the results as an aligned, plain-text table, with a KEY and VALUE header.
+	FormatTable OutputFormat = "table"
+	// FormatJSON writes the results as an (indented) JSON array of objects with a key and a value.
+	FormatJSON OutputFormat = "json"
+	// FormatCSV writes the results as CSV records, with a key and value header.
+	FormatCSV OutputFormat = "csv"
+)
+
+// ByteEncoding defines the text representation of []byte keys and values, when results are marshaled to JSON with
+// MarshalResults or search terms are logged by the logged Indexer (see WithByteEncoding).
+type ByteEncoding string
+
+const (
+	// ByteEncodingRaw renders []byte values as (unencoded) text.
+	ByteEncodingRaw ByteEncoding = "raw"
+	// ByteEncodingBase64 renders []byte values as standard, padded base64 text.
+	ByteEncodingBase64 ByteEncoding = "base64"
+	// ByteEncodingHex renders []byte values as lowercase hexadecimal text.
+	ByteEncodingHex ByteEncoding = "hex"
+)
+
+// encode returns the input value as text in the ByteEncoding if it is a []byte, or as is otherwise (or if the
+// ByteEncoding is not set).
+func (e ByteEncoding) encode(v any) any {
+	b, ok := v.([]byte)
+	if !ok {
+		return v
+	}
+
+	switch e {
+	case ByteEncodingRaw:
+		return string(b)
+	case ByteEncodingBase64:
+		return base64.StdEncoding.EncodeToString(b)
+	case ByteEncodingHex:
+		return hex.EncodeToString(b)
+	default:
+		return v
+	}
+}
+
+type formattedAttribute struct {
+	Key   any `json:"key"`
+	Value any `json:"value"`
+}
+
+// Format writes the input results to the input io.Writer in the input OutputFormat, as a convenience for command-line
+// tools built on an Index.
+//
+// Keys and values are written in their plain form: character types as text, numbers as numbers, sql.Null* types as
+// their value (or empty, if not valid), and types implementing fmt.Stringer (like CompositeKey) as their string.
+//
+// This call returns an ErrInvalidFormat error if the format is not supported, or an error if writing to the io.Writer
+// fails.
+func Format[K SQLType, V SQLType](results []Attribute[K, V], w io.Writer, format OutputFormat) error {
+	attrs := make([]formattedAttribute, 0, len(results))
+
+	for idx := range results {
+		attrs = append(attrs, formattedAttribute{
+			Key:   plainValue(results[idx].Key),
+			Value: plainValue(results[idx].Value),
+		})
+	}
+
+	switch format {
+	case FormatTable:
+		return formatTable(attrs, w)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(attrs)
+	case FormatCSV:
+		return formatCSV(attrs, w)
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidFormat, format)
+	}
+}
+
+// MarshalResults returns the JSON encoding of the input results, as an array of objects with a key and a value, such
+// as to serve them from a JSON API. Keys and values are written in their plain form, as in Format; where []byte keys
+// and values are rendered in the Index's ByteEncoding (see WithByteEncoding), or as raw text by default.
+//
+// This call returns an error if the results cannot be marshaled to JSON.
+func (i *Index[K, V]) MarshalResults(results []Attribute[K, V]) ([]byte, error) {
+	attrs := make([]formattedAttribute, 0, len(results))
+
+	for idx := range results {
+		attrs = append(attrs, formattedAttribute{
+			Key:   plainValue(i.byteEncoding.encode(results[idx].Key)),
+			Value: plainValue(i.byteEncoding.encode(results[idx].Value)),
+		})
+	}
+
+	return json.Marshal(attrs)
+}
+
+func formatTable(attrs []formattedAttribute, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if _, err := fmt.Fprintln(tw, "KEY\tVALUE"); err != nil {
+		return err
+	}
+
+	for idx := range attrs {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\n", plainText(attrs[idx].Key), plainText(attrs[idx].Value)); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
+
+func formatCSV(attrs []formattedAttribute, w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"key", "value"}); err != nil {
+		return err
+	}
+
+	for idx := range attrs {
+		if err := cw.Write([]string{plainText(attrs[idx].Key), plainText(attrs[idx].Value)}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// plainValue returns the input key or value in its plain form, as a string, a number, or nil.
+func plainValue(v any) any {
+	switch value := v.(type) {
+	case fmt.Stringer:
+		return value.String()
+	case []byte:
+		return string(value)
+	case []rune:
+		return string(value)
+	case driver.Valuer:
+		plain, err := value.Value()
+		if err != nil {
+			return nil
+		}
+
+		if b, ok := plain.([]byte); ok {
+			return string(b)
+		}
+
+		return plain
+	default:
+		return v
+	}
+}
+
+// plainText returns the input plain value as text, where nil values are empty.
+func plainText(v any) string {
+	if v == nil {
+		return ""
+	}
+
+	return fmt.Sprint(v)
+}
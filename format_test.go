@@ -0,0 +1,110 @@
+package fts
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestFormat(t *testing.T) {
+	results := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: `struck gold, "pure"`},
+		{Key: 10, Value: "gol-- gold!!"},
+	}
+
+	for _, format := range []OutputFormat{FormatTable, FormatJSON, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			require.NoError(t, Format(results, buf, format))
+
+			golden := filepath.Join("testdata", "format_"+string(format)+".golden")
+
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o644))
+			}
+
+			wants, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(wants), buf.String())
+		})
+	}
+}
+
+func TestFormat_PlainValues(t *testing.T) {
+	results := []Attribute[CompositeKey, sql.NullString]{
+		{Key: CompositeKey{Tenant: "acme", ID: "1"}, Value: sql.NullString{String: "gold", Valid: true}},
+		{Key: CompositeKey{Tenant: "acme", ID: "2"}},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, Format(results, buf, FormatCSV))
+	require.Equal(t, "key,value\nacme/1,gold\nacme/2,\n", buf.String())
+}
+
+func TestFormat_InvalidFormat(t *testing.T) {
+	err := Format([]Attribute[int, string]{{Key: 1, Value: "gold"}}, &bytes.Buffer{}, "xml")
+	require.ErrorIs(t, err, ErrInvalidFormat)
+}
+
+func TestIndex_MarshalResults(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		opts  []cfg.Option[Config]
+		wants string
+	}{
+		{
+			name:  "Default",
+			wants: `[{"key":1,"value":"gold rush"}]`,
+		},
+		{
+			name:  "Raw",
+			opts:  []cfg.Option[Config]{WithByteEncoding(ByteEncodingRaw)},
+			wants: `[{"key":1,"value":"gold rush"}]`,
+		},
+		{
+			name:  "Base64",
+			opts:  []cfg.Option[Config]{WithByteEncoding(ByteEncodingBase64)},
+			wants: `[{"key":1,"value":"Z29sZCBydXNo"}]`,
+		},
+		{
+			name:  "Hex",
+			opts:  []cfg.Option[Config]{WithByteEncoding(ByteEncodingHex)},
+			wants: `[{"key":1,"value":"676f6c642072757368"}]`,
+		},
+		{
+			name:  "InvalidEncoding",
+			opts:  []cfg.Option[Config]{WithByteEncoding("base32")},
+			wants: `[{"key":1,"value":"gold rush"}]`,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, []byte](cfg.New(
+				append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...,
+			), Attribute[int, []byte]{Key: 1, Value: []byte("gold rush")})
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.Search(ctx, []byte("gold"))
+			require.NoError(t, err)
+
+			data, err := index.MarshalResults(res)
+			require.NoError(t, err)
+			require.JSONEq(t, testcase.wants, string(data))
+		})
+	}
+}
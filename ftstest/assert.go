@@ -0,0 +1,133 @@
+package ftstest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zalgonoise/cfg"
+	"github.com/zalgonoise/fts"
+)
+
+// Config defines optional settings when asserting search results.
+type Config struct {
+	ordered bool
+}
+
+// WithOrder makes AssertResults compare the results in order, instead of ignoring their order.
+func WithOrder() cfg.Option[Config] {
+	return cfg.Register[Config](func(config Config) Config {
+		config.ordered = true
+
+		return config
+	})
+}
+
+// AssertResults compares the input search results (got) with the expected ones (want), marking the test as failed
+// with a readable diff of the mismatching keys and values if they differ.
+//
+// By default, the results are compared ignoring their order (as a multiset), so that the assertion is resilient to
+// changes in how the results are sorted. The WithOrder option makes the comparison order-sensitive.
+//
+// This call returns true if the results match.
+func AssertResults[K fts.SQLType, V fts.SQLType](
+	t testing.TB, got, want []fts.Attribute[K, V], opts ...cfg.Option[Config],
+) bool {
+	t.Helper()
+
+	config := cfg.New(opts...)
+
+	gotStr := render(got)
+	wantStr := render(want)
+
+	var diff string
+
+	if config.ordered {
+		diff = orderedDiff(gotStr, wantStr)
+	} else {
+		diff = unorderedDiff(gotStr, wantStr)
+	}
+
+	if diff == "" {
+		return true
+	}
+
+	t.Errorf("search results mismatch (-want +got):\n%s", diff)
+
+	return false
+}
+
+func render[K fts.SQLType, V fts.SQLType](attrs []fts.Attribute[K, V]) []string {
+	out := make([]string, 0, len(attrs))
+
+	for i := range attrs {
+		out = append(out, fmt.Sprintf("%s: %s", format(attrs[i].Key), format(attrs[i].Value)))
+	}
+
+	return out
+}
+
+func format(value any) string {
+	switch v := value.(type) {
+	case []byte:
+		return fmt.Sprintf("%q", string(v))
+	case []rune:
+		return fmt.Sprintf("%q", string(v))
+	default:
+		return fmt.Sprintf("%#v", v)
+	}
+}
+
+func orderedDiff(got, want []string) string {
+	var sb strings.Builder
+
+	for i := 0; i < max(len(got), len(want)); i++ {
+		switch {
+		case i >= len(got):
+			fmt.Fprintf(&sb, "  [%d] - %s\n", i, want[i])
+		case i >= len(want):
+			fmt.Fprintf(&sb, "  [%d] + %s\n", i, got[i])
+		case got[i] != want[i]:
+			fmt.Fprintf(&sb, "  [%d] - %s\n", i, want[i])
+			fmt.Fprintf(&sb, "  [%d] + %s\n", i, got[i])
+		}
+	}
+
+	return sb.String()
+}
+
+func unorderedDiff(got, want []string) string {
+	counts := make(map[string]int, len(want))
+
+	for i := range want {
+		counts[want[i]]++
+	}
+
+	unexpected := make([]string, 0, len(got))
+
+	for i := range got {
+		if counts[got[i]] > 0 {
+			counts[got[i]]--
+
+			continue
+		}
+
+		unexpected = append(unexpected, got[i])
+	}
+
+	var sb strings.Builder
+
+	for i := range want {
+		if counts[want[i]] > 0 {
+			counts[want[i]]--
+
+			fmt.Fprintf(&sb, "  - %s\n", want[i])
+		}
+	}
+
+	for i := range unexpected {
+		fmt.Fprintf(&sb, "  + %s\n", unexpected[i])
+	}
+
+	return sb.String()
+}
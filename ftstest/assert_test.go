@@ -0,0 +1,91 @@
+package ftstest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+	"github.com/zalgonoise/fts"
+)
+
+type recorder struct {
+	testing.TB
+
+	failed bool
+	output string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failed = true
+	r.output = fmt.Sprintf(format, args...)
+}
+
+func TestAssertResults(t *testing.T) {
+	for _, testcase := range []struct {
+		name   string
+		got    []fts.Attribute[int, []byte]
+		want   []fts.Attribute[int, []byte]
+		opts   []cfg.Option[Config]
+		ok     bool
+		output string
+	}{
+		{
+			name: "Unordered/Match",
+			got:  []fts.Attribute[int, []byte]{{Key: 2, Value: []byte("b")}, {Key: 1, Value: []byte("a")}},
+			want: []fts.Attribute[int, []byte]{{Key: 1, Value: []byte("a")}, {Key: 2, Value: []byte("b")}},
+			ok:   true,
+		},
+		{
+			name: "Unordered/Mismatch",
+			got:  []fts.Attribute[int, []byte]{{Key: 2, Value: []byte("b")}, {Key: 3, Value: []byte("c")}},
+			want: []fts.Attribute[int, []byte]{{Key: 1, Value: []byte("a")}, {Key: 2, Value: []byte("b")}},
+			output: "search results mismatch (-want +got):\n" +
+				"  - 1: \"a\"\n" +
+				"  + 3: \"c\"\n",
+		},
+		{
+			name: "Unordered/Duplicates",
+			got:  []fts.Attribute[int, []byte]{{Key: 1, Value: []byte("a")}, {Key: 1, Value: []byte("a")}},
+			want: []fts.Attribute[int, []byte]{{Key: 1, Value: []byte("a")}},
+			output: "search results mismatch (-want +got):\n" +
+				"  + 1: \"a\"\n",
+		},
+		{
+			name: "Ordered/Match",
+			got:  []fts.Attribute[int, []byte]{{Key: 1, Value: []byte("a")}, {Key: 2, Value: []byte("b")}},
+			want: []fts.Attribute[int, []byte]{{Key: 1, Value: []byte("a")}, {Key: 2, Value: []byte("b")}},
+			opts: []cfg.Option[Config]{WithOrder()},
+			ok:   true,
+		},
+		{
+			name: "Ordered/Mismatch",
+			got:  []fts.Attribute[int, []byte]{{Key: 2, Value: []byte("b")}, {Key: 1, Value: []byte("a")}},
+			want: []fts.Attribute[int, []byte]{{Key: 1, Value: []byte("a")}, {Key: 2, Value: []byte("b")}},
+			opts: []cfg.Option[Config]{WithOrder()},
+			output: "search results mismatch (-want +got):\n" +
+				"  [0] - 1: \"a\"\n" +
+				"  [0] + 2: \"b\"\n" +
+				"  [1] - 2: \"b\"\n" +
+				"  [1] + 1: \"a\"\n",
+		},
+		{
+			name: "Ordered/MissingResult",
+			got:  []fts.Attribute[int, []byte]{{Key: 1, Value: []byte("a")}},
+			want: []fts.Attribute[int, []byte]{{Key: 1, Value: []byte("a")}, {Key: 2, Value: []byte("b")}},
+			opts: []cfg.Option[Config]{WithOrder()},
+			output: "search results mismatch (-want +got):\n" +
+				"  [1] - 2: \"b\"\n",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+
+			require.Equal(t, testcase.ok, AssertResults(r, testcase.got, testcase.want, testcase.opts...))
+			require.Equal(t, !testcase.ok, r.failed)
+			require.Equal(t, testcase.output, r.output)
+		})
+	}
+}
@@ -5,7 +5,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
 
+	"github.com/zalgonoise/cfg"
 	"github.com/zalgonoise/x/errs"
 	_ "modernc.org/sqlite"
 )
@@ -13,11 +18,41 @@ import (
 const (
 	errDomain = errs.Domain("fts")
 
-	ErrZero     = errs.Kind("zero")
-	ErrNotFound = errs.Kind("not found")
-
-	ErrAttributes = errs.Entity("attributes")
-	ErrKeyword    = errs.Entity("keyword")
+	ErrZero      = errs.Kind("zero")
+	ErrNotFound  = errs.Kind("not found")
+	ErrClosed    = errs.Kind("closed")
+	ErrTruncated = errs.Kind("truncated")
+	ErrInMemory  = errs.Kind("in-memory")
+	ErrCorrupt   = errs.Kind("corrupt")
+	ErrInvalid   = errs.Kind("invalid")
+	ErrExceeded  = errs.Kind("exceeded")
+	ErrTooShort  = errs.Kind("too short")
+	ErrNoAccess  = errs.Kind("inaccessible")
+	ErrNull      = errs.Kind("null")
+	ErrOpen      = errs.Kind("open")
+
+	ErrAttributes  = errs.Entity("attributes")
+	ErrKeyword     = errs.Entity("keyword")
+	ErrIndex       = errs.Entity("index")
+	ErrResult      = errs.Entity("result")
+	ErrRotation    = errs.Entity("rotation")
+	ErrDatabase    = errs.Entity("database")
+	ErrColumn      = errs.Entity("column")
+	ErrCompression = errs.Entity("compression")
+	ErrRateLimit   = errs.Entity("rate limit")
+	ErrQuery       = errs.Entity("query")
+	ErrExtractor   = errs.Entity("extractor")
+	ErrTransformer = errs.Entity("transformer")
+	ErrFormat      = errs.Entity("format")
+	ErrTimestamps  = errs.Entity("timestamps")
+	ErrBoosts      = errs.Entity("boosts")
+	ErrLanguage    = errs.Entity("language")
+	ErrMetadata    = errs.Entity("metadata")
+	ErrValue       = errs.Entity("value")
+	ErrCircuit     = errs.Entity("circuit")
+	ErrInfix       = errs.Entity("infix")
+	ErrTokenizer   = errs.Entity("tokenizer")
+	ErrIndexer     = errs.Entity("indexer")
 )
 
 const (
@@ -28,19 +63,55 @@ INSERT INTO fulltext_search (id, val)
 	VALUES (?, ?);
 `
 
-	searchQuery = `
-SELECT id, val FROM fulltext_search(?);
+	deleteQuery = `
+DELETE FROM fulltext_search
+	WHERE id IN (%s);
 `
 
-	deleteQuery = `
+	// deleteMatchQuery deletes the rows of a single key through the full-text index: the candidate rows are found by
+	// matching the key as a phrase in the key column, and the equality check drops the candidates with another key.
+	deleteMatchQuery = `
 DELETE FROM fulltext_search
-	WHERE id MATCH ?;
+	WHERE rowid IN (SELECT rowid FROM fulltext_search WHERE fulltext_search MATCH ?1)
+	AND id = ?2;
 `
+
+	// maxMatchedDeleteKeys is the maximum number of keys deleted one by one through the full-text index (see
+	// deleteMatchQuery), above which they are deleted in batches, each scanning the table once.
+	maxMatchedDeleteKeys = 64
+
+	// maxDeleteBatch is the maximum number of keys deleted by a single statement, within SQLite's default limit of
+	// 999 bound parameters in older versions (SQLITE_MAX_VARIABLE_NUMBER).
+	maxDeleteBatch = 999
+
+	vacuumQuery = `VACUUM;`
 )
 
 var (
-	ErrZeroAttributes  = errs.WithDomain(errDomain, ErrZero, ErrAttributes)
-	ErrNotFoundKeyword = errs.WithDomain(errDomain, ErrNotFound, ErrKeyword)
+	ErrZeroAttributes     = errs.WithDomain(errDomain, ErrZero, ErrAttributes)
+	ErrNotFoundKeyword    = errs.WithDomain(errDomain, ErrNotFound, ErrKeyword)
+	ErrClosedIndex        = errs.WithDomain(errDomain, ErrClosed, ErrIndex)
+	ErrResultTruncated    = errs.WithDomain(errDomain, ErrTruncated, ErrResult)
+	ErrInMemoryRotation   = errs.WithDomain(errDomain, ErrInMemory, ErrRotation)
+	ErrCorruptDatabase    = errs.WithDomain(errDomain, ErrCorrupt, ErrDatabase)
+	ErrInvalidColumn      = errs.WithDomain(errDomain, ErrInvalid, ErrColumn)
+	ErrInvalidCompression = errs.WithDomain(errDomain, ErrInvalid, ErrCompression)
+	ErrRateLimited        = errs.WithDomain(errDomain, ErrExceeded, ErrRateLimit)
+	ErrInvalidQuery       = errs.WithDomain(errDomain, ErrInvalid, ErrQuery)
+	ErrInvalidExtractor   = errs.WithDomain(errDomain, ErrInvalid, ErrExtractor)
+	ErrInvalidTransformer = errs.WithDomain(errDomain, ErrInvalid, ErrTransformer)
+	ErrQueryTooShort      = errs.WithDomain(errDomain, ErrTooShort, ErrQuery)
+	ErrInvalidFormat      = errs.WithDomain(errDomain, ErrInvalid, ErrFormat)
+	ErrInvalidTimestamps  = errs.WithDomain(errDomain, ErrInvalid, ErrTimestamps)
+	ErrInvalidBoosts      = errs.WithDomain(errDomain, ErrInvalid, ErrBoosts)
+	ErrDatabaseAccess     = errs.WithDomain(errDomain, ErrNoAccess, ErrDatabase)
+	ErrNotFoundLanguage   = errs.WithDomain(errDomain, ErrNotFound, ErrLanguage)
+	ErrInvalidMetadata    = errs.WithDomain(errDomain, ErrInvalid, ErrMetadata)
+	ErrNullValue          = errs.WithDomain(errDomain, ErrNull, ErrValue)
+	ErrCircuitOpen        = errs.WithDomain(errDomain, ErrOpen, ErrCircuit)
+	ErrInvalidInfix       = errs.WithDomain(errDomain, ErrInvalid, ErrInfix)
+	ErrInvalidTokenizer   = errs.WithDomain(errDomain, ErrInvalid, ErrTokenizer)
+	ErrZeroIndexer        = errs.WithDomain(errDomain, ErrZero, ErrIndexer)
 )
 
 // Index exposes fast full-text search by leveraging the SQLite FTS5 feature.
@@ -55,91 +126,422 @@ var (
 //
 // The expressions, syntax and example phrases for these queries can be found in section 3. of the reference document
 // above; providing means of performing more complex queries over indexed data.
+//
+// Reads and writes in an Index go through the same database handle, and write calls only return once their
+// transaction is committed (even with a write queue). This means that a search issued after a write returns on the
+// same Index always observes it (read-after-write consistency).
 type Index[K SQLType, V SQLType] struct {
-	db *sql.DB
+	mu           sync.RWMutex
+	db           *sql.DB
+	uri          string
+	rawDSN       string
+	cacheMode    string
+	pragmas      []string
+	initPragmas  []string
+	maxOpenConns int
+	inMemory     bool
+	tokenizer    tokenizer
+	store        storage
+	extract      func(V) string
+
+	vacuumOnShutdown bool
+	skipNullValues   bool
+	maxTxnDuration   time.Duration
+	clock            func() time.Time
+	byteEncoding     ByteEncoding
+	insertQuery      string
+	deleteQuery      string
+	deleteMatchQuery string
+	deleteRowQuery   string
+	updateIfQuery    string
+	maxResults       int
+	queryRewriters   []QueryRewriter
+	validateQueries  bool
+	fallbackToLike   bool
+	minQueryLength   int
+	transformers     []ResultTransformer[K, V]
+	cache            *resultCache[K, V]
+	changes          changeFeed[K]
+	shutdownHooks    []func(ctx context.Context) error
+	shutdownOnce     sync.Once
+
+	writesMu   sync.RWMutex
+	writes     chan writeOp
+	writesDone chan struct{}
+	closed     bool
 }
 
 // Search will look for matches for the input value through the indexed terms, returning a collection of matching
 // Attribute, which will contain both key and (full) value for that match.
 //
+// This call is a shorthand for SearchWithOpts with the default SearchOpts (no limit, unordered, including values).
+//
 // This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
 // ErrNotFoundKeyword error if there are zero results from the query.
 func (i *Index[K, V]) Search(ctx context.Context, searchTerm V) (res []Attribute[K, V], err error) {
-	rows, err := i.db.QueryContext(ctx, searchQuery, searchTerm)
+	return i.SearchWithOpts(ctx, searchTerm, SearchOpts{IncludeValue: true})
+}
+
+// SearchTop will look for the n best matches for the input value through the indexed terms, returning a collection of
+// matching Attribute sorted by relevance (best match first).
+//
+// Unlike truncating the results of Search, the results are ordered by the FTS5 rank and limited within the same
+// query: every match is still scored and sorted by SQLite, but only the n best matches are read back and materialized
+// as Attribute. An n value of zero or below returns all matches, by rank.
+//
+// This call is a shorthand for SearchWithOpts, ordered by rank, limited to n results and including values.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query.
+func (i *Index[K, V]) SearchTop(ctx context.Context, searchTerm V, n int) (res []Attribute[K, V], err error) {
+	return i.SearchWithOpts(ctx, searchTerm, SearchOpts{
+		Limit:        n,
+		Order:        OrderRank,
+		IncludeValue: true,
+	})
+}
+
+// SearchPaginated will look for matches for the input value through the indexed terms, returning a page of at most
+// limit matching Attribute, after skipping the first offset matches. A limit of zero or below means no limit, and a
+// negative offset is treated as zero.
+//
+// This call is a shorthand for SearchWithOpts, limited to limit results from offset and including values.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query and the offset is zero. An offset past the last
+// match returns an empty result with no error.
+func (i *Index[K, V]) SearchPaginated(
+	ctx context.Context, searchTerm V, limit, offset int,
+) (res []Attribute[K, V], err error) {
+	return i.SearchWithOpts(ctx, searchTerm, SearchOpts{
+		Limit:        limit,
+		Offset:       offset,
+		IncludeValue: true,
+	})
+}
+
+// Insert indexes new attributes in the Index, via the input Attribute's key and value content.
+//
+// A database transaction is performed in order to ensure that the query is executed as quickly as possible; in case
+// multiple items are provided as input. This is especially useful for the initial load sequence. If the context is
+// canceled while the transaction is open, it is rolled back and the context's error is returned; so that none of the
+// attributes are committed.
+//
+// Attributes with a NULL value (an invalid sql.Null* type) can't be tokenized, so they are rejected with an
+// ErrNullValue error, rolling back the whole insert; or skipped, if the Index is configured with WithSkipNullValues.
+//
+// If the Index is configured with a maximum transaction duration (see WithMaxTxnDuration), the insert is split into
+// several transactions, each committed once it has been open for that long. In that case, only the attributes in the
+// current transaction are rolled back on an error or a canceled context.
+//
+// If the Index is configured with a write queue, the call is enqueued and blocks until it is processed.
+func (i *Index[K, V]) Insert(ctx context.Context, attrs ...Attribute[K, V]) error {
+	return i.write(ctx, func(ctx context.Context) error {
+		_, err := i.insert(ctx, attrs...)
+
+		return err
+	})
+}
+
+// insert implements Insert, returning the row IDs assigned to the inserted attributes, in insertion order (where
+// skipped attributes have a row ID of zero).
+func (i *Index[K, V]) insert(ctx context.Context, attrs ...Attribute[K, V]) ([]int64, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	tx, err := i.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	defer rows.Close()
+	rowIDs := make([]int64, 0, len(attrs))
+	keys := make([]K, 0, len(attrs))
+	txStart := i.clock()
 
-	res = make([]Attribute[K, V], 0, minAlloc)
+	for idx := range attrs {
+		if err = ctx.Err(); err != nil {
+			return nil, errors.Join(err, rollback(tx))
+		}
 
-	for rows.Next() {
-		attr := new(Attribute[K, V])
+		if i.maxTxnDuration > 0 && i.clock().Sub(txStart) >= i.maxTxnDuration {
+			if tx, err = i.splitTx(ctx, tx, keys); err != nil {
+				return nil, err
+			}
 
-		if err = rows.Scan(&attr.Key, &attr.Value); err != nil {
-			return nil, err
+			keys = make([]K, 0, len(attrs)-idx)
+			txStart = i.clock()
 		}
 
-		res = append(res, *attr)
+		skip, err := i.checkNullValue(attrs[idx])
+		if err != nil {
+			return nil, errors.Join(err, rollback(tx))
+		}
+
+		if skip {
+			rowIDs = append(rowIDs, 0)
+
+			continue
+		}
+
+		result, err := tx.ExecContext(ctx, i.insertQuery, i.insertArgs(attrs[idx])...)
+		if err != nil {
+			return nil, errors.Join(err, rollback(tx))
+		}
+
+		rowID, err := result.LastInsertId()
+		if err != nil {
+			return nil, errors.Join(err, rollback(tx))
+		}
+
+		rowIDs = append(rowIDs, rowID)
+		keys = append(keys, attrs[idx].Key)
 	}
 
-	if len(res) == 0 {
-		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	if err = i.changes.commit(tx, func() ChangeEvent[K] {
+		return ChangeEvent[K]{Op: ChangeInsert, Keys: keys}
+	}); err != nil {
+		return nil, err
 	}
 
-	return res, nil
+	return rowIDs, nil
 }
 
-// Insert indexes new attributes in the Index, via the input Attribute's key and value content.
+// Delete removes attributes in the Index, which match input K-type keys.
 //
-// A database transaction is performed in order to ensure that the query is executed as quickly as possible; in case
-// multiple items are provided as input. This is especially useful for the initial load sequence.
-func (i *Index[K, V]) Insert(ctx context.Context, attrs ...Attribute[K, V]) error {
+// Keys are matched by equality with the key column, using the Index's key collation if configured with one (see
+// WithKeyCollation), or SQLite's BINARY collation otherwise. Keys holding FTS5 query syntax (such as "a-b" or
+// "x OR y") are matched literally; previous versions matched keys with the FTS5 MATCH operator instead, which also
+// deleted the attributes whose keys merely contained the key's tokens.
+//
+// The key column of the FTS5 table has no index to look keys up by equality, so the cost of a delete depends on the
+// number of keys:
+//   - up to 64 string or integer keys are deleted one by one, by matching each key as a phrase in the full-text index
+//     and checking the candidate rows for equality; which only touches the rows sharing the key's tokens. A key that
+//     is not found this way (because it is absent, or has no indexed tokens) is deleted with a scan of the table.
+//   - otherwise, the keys are deleted in batches of up to 999 keys per statement (with an `id IN (...)` clause), each
+//     scanning the table once; so that deleting many keys takes a few scans rather than one per key.
+//
+// With external content (e.g. with WithInsertTimestamps), the keys are looked up through an index on the
+// fulltext_values table instead, and are always deleted in batches. With a key collation, each batch scans the table.
+//
+// All keys are deleted within a single database transaction. If the context is canceled while the transaction is
+// open, it is rolled back and the context's error is returned; so that none of the keys are deleted.
+//
+// If the Index is configured with a write queue, the call is enqueued and blocks until it is processed.
+func (i *Index[K, V]) Delete(ctx context.Context, keys ...K) error {
+	return i.write(ctx, func(ctx context.Context) error {
+		return i.delete(ctx, keys...)
+	})
+}
+
+func (i *Index[K, V]) delete(ctx context.Context, keys ...K) error {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
 	tx, err := i.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
-	for idx := range attrs {
-		if _, err = tx.ExecContext(ctx, insertValueQuery, attrs[idx].Key, attrs[idx].Value); err != nil {
+	if err = i.deleteKeys(ctx, tx, keys); err != nil {
+		return errors.Join(err, rollback(tx))
+	}
+
+	if err = ctx.Err(); err != nil {
+		return errors.Join(err, rollback(tx))
+	}
+
+	return i.changes.commit(tx, func() ChangeEvent[K] {
+		return ChangeEvent[K]{Op: ChangeDelete, Keys: append(make([]K, 0, len(keys)), keys...)}
+	})
+}
+
+// execer describes the types that write statements can be executed on, such as a transaction.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// deleteKeys deletes the rows matching the input keys over the input execer, either one by one through the full-text
+// index or in batches, as described in Delete.
+func (i *Index[K, V]) deleteKeys(ctx context.Context, tx execer, keys []K) error {
+	if i.deleteMatchQuery == "" || len(keys) > maxMatchedDeleteKeys {
+		return i.deleteBatches(ctx, tx, keys)
+	}
+
+	for idx := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if phrase, ok := matchPhrase(keys[idx]); ok {
+			res, err := tx.ExecContext(ctx, i.deleteMatchQuery, phrase, keys[idx])
+			if err != nil {
+				return err
+			}
+
+			n, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			if n > 0 {
+				continue
+			}
+		}
+
+		if err := i.deleteBatches(ctx, tx, keys[idx:idx+1]); err != nil {
 			return err
 		}
 	}
 
-	if err = tx.Commit(); err != nil {
-		return tx.Rollback()
+	return nil
+}
+
+// deleteBatches deletes the rows matching the input keys over the input execer, in batches of up to maxDeleteBatch
+// keys per statement.
+func (i *Index[K, V]) deleteBatches(ctx context.Context, tx execer, keys []K) error {
+	for _, batch := range batches(keys, maxDeleteBatch) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		args := make([]any, 0, len(batch))
+		for idx := range batch {
+			args = append(args, batch[idx])
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(i.deleteQuery, placeholders(len(batch))), args...); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// Delete removes attributes in the Index, which match input K-type keys.
+// matchPhrase returns an FTS5 query matching the input key as a phrase in the key column, for the keys whose text in
+// the full-text index is known (strings and integers) and holds at least one letter or digit. Other keys can't be
+// looked up through the full-text index.
+func matchPhrase[K SQLType](key K) (string, bool) {
+	var text string
+
+	switch v := any(key).(type) {
+	case string:
+		text = v
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		text = fmt.Sprint(v)
+	default:
+		return "", false
+	}
+
+	if strings.IndexFunc(text, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+		return "", false
+	}
+
+	return `id : "` + strings.ReplaceAll(text, `"`, `""`) + `"`, true
+}
+
+// Shutdown gracefully closes the Index SQLite database, by calling its Close method.
 //
-// A database transaction is performed in order to ensure that the query is executed as quickly as possible; in case
-// multiple items are provided as input.
-func (i *Index[K, V]) Delete(ctx context.Context, keys ...K) error {
-	tx, err := i.db.BeginTx(ctx, nil)
+// Any shutdown hooks registered in the Index are called first, in reverse order of registration. If the Index is
+// configured with a write queue, any pending writes are drained before the database is closed. If the Index is
+// configured to vacuum on shutdown, a VACUUM command is issued (for file-backed databases) before closing it. The
+// channels of the Index's subscribers (see Subscribe) are closed once done.
+func (i *Index[K, V]) Shutdown(ctx context.Context) error {
+	hooksErr := i.runShutdownHooks(ctx)
+
+	defer i.changes.close()
+
+	err := errors.Join(hooksErr, i.drainWrites(ctx))
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
 	if err != nil {
-		return err
+		return errors.Join(err, i.db.Close())
 	}
 
-	for idx := range keys {
-		if _, err = tx.ExecContext(ctx, deleteQuery, keys[idx]); err != nil {
-			return err
+	if i.vacuumOnShutdown && !i.inMemory {
+		if _, err := i.db.ExecContext(ctx, vacuumQuery); err != nil {
+			return errors.Join(err, i.db.Close())
 		}
 	}
 
-	if err = tx.Commit(); err != nil {
-		return tx.Rollback()
+	return i.db.Close()
+}
+
+// runShutdownHooks calls the Index's shutdown hooks in reverse order of registration, joining their errors. Each hook
+// is only called once, even if Shutdown is called multiple times.
+func (i *Index[K, V]) runShutdownHooks(ctx context.Context) (err error) {
+	i.shutdownOnce.Do(func() {
+		errs := make([]error, 0, len(i.shutdownHooks))
+
+		for idx := len(i.shutdownHooks) - 1; idx >= 0; idx-- {
+			errs = append(errs, i.shutdownHooks[idx](ctx))
+		}
+
+		err = errors.Join(errs...)
+	})
+
+	return err
+}
+
+// scanRows scans all rows from the input sql.Rows with the input scan function, closing the rows once done.
+//
+// If maxResults is above zero and there are more rows than that, only the first maxResults rows are scanned and
+// returned, alongside an ErrResultTruncated error.
+func scanRows[T any](rows *sql.Rows, maxResults int, scan func(rows *sql.Rows) (T, error)) ([]T, error) {
+	defer rows.Close()
+
+	res := make([]T, 0, minAlloc)
+
+	for rows.Next() {
+		if maxResults > 0 && len(res) == maxResults {
+			return res, ErrResultTruncated
+		}
+
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, item)
 	}
 
-	return nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
 }
 
-// Shutdown gracefully closes the Index SQLite database, by calling its Close method
-func (i *Index[K, V]) Shutdown(_ context.Context) error {
-	return i.db.Close()
+// batches splits the input items into consecutive batches of up to size items each.
+func batches[T any](items []T, size int) [][]T {
+	res := make([][]T, 0, (len(items)+size-1)/size)
+
+	for start := 0; start < len(items); start += size {
+		res = append(res, items[start:min(start+size, len(items))])
+	}
+
+	return res
+}
+
+// placeholders returns a comma-separated list of n SQL parameter placeholders.
+func placeholders(n int) string {
+	if n <= 0 {
+		return ""
+	}
+
+	return strings.Repeat("?, ", n-1) + "?"
+}
+
+// rollback aborts the input transaction, ignoring sql.ErrTxDone errors as the transaction may have been rolled back
+// already (e.g. when its context is canceled).
+func rollback(tx *sql.Tx) error {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		return err
+	}
+
+	return nil
 }
 
 // Attribute describes an entry to be added or returned from the Index, supporting types that are compatible
@@ -155,24 +557,125 @@ type Attribute[K SQLType, V SQLType] struct {
 // Otherwise, the URI is treated as a database URI and validated as an OS path. The latter option allows persistence
 // of the Index.
 //
-// An error is returned if the database fails when being open, initialized, and loaded with the input Attribute.
+// An error is returned if the database fails when being open, initialized, and loaded with the input Attribute. If the
+// database file is corrupt (or not a SQLite database), the error wraps ErrCorruptDatabase.
 func NewIndex[K SQLType, V SQLType](uri string, attrs ...Attribute[K, V]) (*Index[K, V], error) {
-	db, err := open(uri)
+	return newIndex[K, V](Config{uri: uri}, attrs...)
+}
+
+// NewIndexWithOptions creates an Index loaded with the input set of Attribute, configured with the input options (such
+// as WithURI or WithWriteQueue); so that the Index-only methods (like UpdateIf, Rotate or Subscribe) are available on
+// a configured Index, without asserting the type of the Indexer returned by New.
+//
+// The options that decorate an Indexer (WithLogger, WithLogHandler, WithMetrics and WithTrace, and their related
+// options) are ignored, as the returned value is the concrete Index type; use New to create a decorated Indexer.
+//
+// An error is returned if the database fails when being open, initialized, and loaded with the input Attribute. If the
+// database file is corrupt (or not a SQLite database), the error wraps ErrCorruptDatabase.
+func NewIndexWithOptions[K SQLType, V SQLType](
+	attrs []Attribute[K, V], opts ...cfg.Option[Config],
+) (*Index[K, V], error) {
+	return newIndex[K, V](cfg.New[Config](opts...), attrs...)
+}
+
+func newIndex[K SQLType, V SQLType](config Config, attrs ...Attribute[K, V]) (*Index[K, V], error) {
+	if config.rawDSN != "" {
+		config.uri = dsnPath(config.rawDSN)
+	}
+
+	tok := tokenizer{
+		name:       config.tokenizerName,
+		args:       config.tokenizerArgs,
+		porter:     config.porterStemmer,
+		tokenChars: config.tokenChars,
+		separators: config.separators,
+	}
+
+	store := storage{
+		timestamps: config.insertTimestamps,
+		boosts:     config.documentBoosts,
+		metadata:   config.metadataColumns,
+		infix:      config.infixSearch,
+	}
+	if config.codec != nil {
+		store.codec = config.codec.Name()
+		codecs.Store(store.codec, config.codec)
+	}
+
+	var extract func(V) string
+	if config.searchTextExtractor != nil {
+		fn, ok := config.searchTextExtractor.(func(V) string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T does not match the Index's value type", ErrInvalidExtractor,
+				config.searchTextExtractor)
+		}
+
+		extract = fn
+		store.searchText = true
+	}
+
+	transformers, err := resultTransformersFor[K, V](config.resultTransformers)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = initDatabase(db); err != nil {
+	clock := config.clock
+	if clock == nil {
+		clock = time.Now
+	}
+
+	openDB := openDatabase
+	if config.lazyOpen && !isInMemory(config.uri) {
+		openDB = openLazy
+	}
+
+	db, err := openDB(config, tok, store)
+	if err != nil {
 		return nil, err
 	}
 
 	index := &Index[K, V]{
-		db: db,
+		db:               db,
+		uri:              config.uri,
+		rawDSN:           config.rawDSN,
+		cacheMode:        config.cacheMode,
+		pragmas:          config.pragmas,
+		initPragmas:      config.initPragmas,
+		maxOpenConns:     config.maxOpenConns,
+		inMemory:         isInMemory(config.uri),
+		tokenizer:        tok,
+		store:            store,
+		extract:          extract,
+		vacuumOnShutdown: config.vacuumOnShutdown,
+		skipNullValues:   config.skipNullValues,
+		maxTxnDuration:   config.maxTxnDuration,
+		clock:            clock,
+		byteEncoding:     config.byteEncoding,
+		insertQuery:      insertQueryFor(store),
+		deleteQuery:      deleteQueryFor(config.keyCollation, store),
+		deleteMatchQuery: deleteMatchQueryFor(config.keyCollation, store),
+		deleteRowQuery:   deleteByRowIDQueryFor(store),
+		updateIfQuery:    updateIfQueryFor(config.keyCollation, store),
+		maxResults:       config.maxResults,
+		queryRewriters:   config.queryRewriters,
+		validateQueries:  config.validateQueries,
+		fallbackToLike:   config.fallbackToLike,
+		minQueryLength:   config.minQueryLength,
+		transformers:     transformers,
+		shutdownHooks:    config.shutdownHooks,
+	}
+
+	if config.resultCacheSize > 0 {
+		index.cache = newResultCache[K, V](config.resultCacheSize, config.metrics)
+	}
+
+	if config.writeQueueDepth > 0 {
+		index.startWriteQueue(config.writeQueueDepth)
 	}
 
 	if len(attrs) > 0 {
 		if err = index.Insert(context.Background(), attrs...); err != nil {
-			closeErr := index.db.Close()
+			closeErr := index.Shutdown(context.Background())
 
 			return nil, errors.Join(err, closeErr)
 		}
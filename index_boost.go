@@ -0,0 +1,76 @@
+package fts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// BoostedAttribute is an Attribute inserted alongside a ranking boost (see InsertBoosted).
+type BoostedAttribute[K SQLType, V SQLType] struct {
+	Attribute[K, V]
+
+	// Boost is subtracted from the attribute's BM25 score when ranking search results, where lower scores are better
+	// matches. A positive boost ranks the attribute higher, and a negative one ranks it lower. As a reference, the BM25
+	// scores of matches in small documents usually range from zero to a few units.
+	Boost float64
+}
+
+// InsertBoosted indexes new attributes in the Index, like Insert, storing each attribute's ranking boost alongside it.
+// Searches ordered by relevance (such as SearchTop, or SearchWithOpts with OrderRank) rank the attributes by their BM25
+// score minus their boost; so that certain attributes (e.g. pinned or sponsored ones) outrank more relevant matches.
+//
+// The Index must be configured with WithDocumentBoosts. The boost of an attribute is kept when its value is updated
+// (e.g. with UpdateIf).
+//
+// Attributes with a NULL value are rejected or skipped, as in Insert.
+//
+// This call returns an ErrInvalidBoosts error if the Index does not record boosts. If the context is canceled while the
+// transaction is open, it is rolled back and the context's error is returned; so that none of the attributes are
+// committed. If the Index is configured with a write queue, the call is enqueued and blocks until it is processed.
+func (i *Index[K, V]) InsertBoosted(ctx context.Context, attrs ...BoostedAttribute[K, V]) error {
+	if !i.store.boosts {
+		return fmt.Errorf("%w: the Index is not configured with WithDocumentBoosts", ErrInvalidBoosts)
+	}
+
+	query := insertBoostedQueryFor(i.store)
+
+	return i.write(ctx, func(ctx context.Context) error {
+		i.mu.RLock()
+		defer i.mu.RUnlock()
+
+		tx, err := i.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		keys := make([]K, 0, len(attrs))
+
+		for idx := range attrs {
+			if err = ctx.Err(); err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+
+			skip, err := i.checkNullValue(attrs[idx].Attribute)
+			if err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+
+			if skip {
+				continue
+			}
+
+			args := append(i.insertArgs(attrs[idx].Attribute), attrs[idx].Boost)
+
+			if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+
+			keys = append(keys, attrs[idx].Key)
+		}
+
+		return i.changes.commit(tx, func() ChangeEvent[K] {
+			return ChangeEvent[K]{Op: ChangeInsert, Keys: keys}
+		})
+	})
+}
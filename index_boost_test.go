@@ -0,0 +1,70 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_InsertBoosted(t *testing.T) {
+	relevant := Attribute[int, string]{Key: 1, Value: "gold gold gold"}
+	sponsored := Attribute[int, string]{Key: 2, Value: "a long story about mining towns, railroads and, somewhere, gold"}
+
+	for _, testcase := range []struct {
+		name  string
+		boost float64
+		wants []Attribute[int, string]
+	}{
+		{
+			name:  "Boosted",
+			boost: 10,
+			wants: []Attribute[int, string]{sponsored, relevant},
+		},
+		{
+			name:  "NotBoosted",
+			wants: []Attribute[int, string]{relevant, sponsored},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New(
+				WithURI(filepath.Join(t.TempDir(), "index.db")),
+				WithDocumentBoosts(),
+			), relevant)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			require.NoError(t, index.InsertBoosted(ctx, BoostedAttribute[int, string]{
+				Attribute: sponsored,
+				Boost:     testcase.boost,
+			}))
+
+			res, err := index.SearchTop(ctx, "gold", 0)
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestIndex_InsertBoostedWithoutBoosts(t *testing.T) {
+	ctx := context.Background()
+	uri := filepath.Join(t.TempDir(), "index.db")
+
+	index, err := NewIndex[int, string](uri)
+	require.NoError(t, err)
+
+	err = index.InsertBoosted(ctx, BoostedAttribute[int, string]{Attribute: Attribute[int, string]{Key: 1, Value: "gold"}})
+	require.ErrorIs(t, err, ErrInvalidBoosts)
+	require.NoError(t, index.Shutdown(ctx))
+
+	// boosts are part of the schema
+	_, err = newIndex[int, string](cfg.New(WithURI(uri), WithDocumentBoosts()))
+	require.ErrorIs(t, err, ErrInvalidBoosts)
+}
@@ -0,0 +1,69 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cancelAfterContext is a context.Context that reports being canceled after its Err method is called a set number of
+// times, allowing to cancel a write operation deterministically, in the middle of its transaction.
+type cancelAfterContext struct {
+	context.Context
+
+	calls int64
+	after int64
+}
+
+func (c *cancelAfterContext) Err() error {
+	if atomic.AddInt64(&c.calls, 1) > c.after {
+		return context.Canceled
+	}
+
+	return nil
+}
+
+func TestIndex_CancelWrites(t *testing.T) {
+	const numAttrs = 100
+
+	attrs := make([]Attribute[int, string], 0, numAttrs)
+	keys := make([]int, 0, numAttrs)
+
+	for i := 0; i < numAttrs; i++ {
+		attrs = append(attrs, Attribute[int, string]{Key: i, Value: fmt.Sprintf("gold entry %d", i)})
+		keys = append(keys, i)
+	}
+
+	t.Run("Insert", func(t *testing.T) {
+		index, err := NewIndex[int, string](filepath.Join(t.TempDir(), "index.db"))
+		require.NoError(t, err)
+
+		ctx := &cancelAfterContext{Context: context.Background(), after: 1}
+
+		require.ErrorIs(t, index.Insert(ctx, attrs...), context.Canceled)
+
+		_, err = index.Search(context.Background(), "gold")
+		require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+		require.NoError(t, index.Shutdown(context.Background()))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+		require.NoError(t, err)
+
+		ctx := &cancelAfterContext{Context: context.Background(), after: 1}
+
+		require.ErrorIs(t, index.Delete(ctx, keys...), context.Canceled)
+
+		res, err := index.Search(context.Background(), "gold")
+		require.NoError(t, err)
+		require.Len(t, res, numAttrs)
+
+		require.NoError(t, index.Shutdown(context.Background()))
+	})
+}
@@ -0,0 +1,19 @@
+package fts
+
+import "context"
+
+const checkpointQuery = `PRAGMA wal_checkpoint(TRUNCATE);`
+
+// Checkpoint copies the contents of the write-ahead log of a WAL-mode database back into the database file, and
+// truncates the log; which is useful when automatic checkpoints are disabled (see WithAutoCheckpoint). It is a no-op
+// for databases that are not in WAL mode.
+//
+// This call returns an error if the checkpoint fails.
+func (i *Index[K, V]) Checkpoint(ctx context.Context) error {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	_, err := i.db.ExecContext(ctx, checkpointQuery)
+
+	return err
+}
@@ -0,0 +1,101 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestWithAutoCheckpoint(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		opts  []cfg.Option[Config]
+		wants int
+	}{
+		{
+			name:  "Default",
+			wants: 1000,
+		},
+		{
+			name:  "Custom",
+			opts:  []cfg.Option[Config]{WithAutoCheckpoint(64)},
+			wants: 64,
+		},
+		{
+			name:  "Disabled",
+			opts:  []cfg.Option[Config]{WithAutoCheckpoint(0)},
+			wants: 0,
+		},
+		{
+			name:  "Negative/NoOp",
+			opts:  []cfg.Option[Config]{WithAutoCheckpoint(-1)},
+			wants: 1000,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](
+				cfg.New(append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...),
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			// the pragma is set on every connection, so both must report it
+			first, err := index.db.Conn(ctx)
+			require.NoError(t, err)
+
+			defer first.Close()
+
+			second, err := index.db.Conn(ctx)
+			require.NoError(t, err)
+
+			defer second.Close()
+
+			var pages int
+
+			require.NoError(t, first.QueryRowContext(ctx, "PRAGMA wal_autocheckpoint;").Scan(&pages))
+			require.Equal(t, testcase.wants, pages)
+
+			require.NoError(t, second.QueryRowContext(ctx, "PRAGMA wal_autocheckpoint;").Scan(&pages))
+			require.Equal(t, testcase.wants, pages)
+		})
+	}
+}
+
+func TestIndex_Checkpoint(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "index.db")
+
+	index, err := newIndex[int, string](cfg.New(WithURI(path), WithAutoCheckpoint(0)))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	_, err = index.db.ExecContext(ctx, "PRAGMA journal_mode=WAL;")
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, index.Insert(ctx, Attribute[int, string]{Key: i, Value: fmt.Sprintf("value number %d", i)}))
+	}
+
+	stat, err := os.Stat(path + "-wal")
+	require.NoError(t, err)
+	require.Positive(t, stat.Size())
+
+	require.NoError(t, index.Checkpoint(ctx))
+
+	stat, err = os.Stat(path + "-wal")
+	require.NoError(t, err)
+	require.Zero(t, stat.Size())
+}
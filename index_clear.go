@@ -0,0 +1,46 @@
+package fts
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	clearQuery = `DELETE FROM fulltext_search;`
+
+	// clearExternalQuery clears the fulltext_values table, whose triggers remove the rows from the full-text index.
+	clearExternalQuery = `DELETE FROM fulltext_values;`
+)
+
+// Clear removes all attributes in the Index, within a single database transaction, keeping its tables and its
+// database connection open; so that the Index can be reloaded (e.g. on a periodic reindex, or between tests) without
+// creating it again. Unlike Shutdown, the Index remains usable after this call.
+//
+// Subscribers receive a single ChangeEvent with a ChangeClear op, without keys. If the Index is configured with a write
+// queue, the call is enqueued and blocks until it is processed.
+//
+// This call returns an error if the underlying SQL query fails, in which case no attributes are removed.
+func (i *Index[K, V]) Clear(ctx context.Context) error {
+	return i.write(ctx, func(ctx context.Context) error {
+		i.mu.RLock()
+		defer i.mu.RUnlock()
+
+		tx, err := i.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		query := clearQuery
+		if i.store.external() {
+			query = clearExternalQuery
+		}
+
+		if _, err = tx.ExecContext(ctx, query); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		return i.changes.commit(tx, func() ChangeEvent[K] {
+			return ChangeEvent[K]{Op: ChangeClear}
+		})
+	})
+}
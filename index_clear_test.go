@@ -0,0 +1,63 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_Clear(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold"},
+	}
+
+	for _, testcase := range []struct {
+		name string
+		opts []cfg.Option[Config]
+	}{
+		{
+			name: "Default",
+		},
+		{
+			name: "ExternalContent",
+			opts: []cfg.Option[Config]{WithInsertTimestamps(), WithInfixSearch()},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New(
+				append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...,
+			), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			events, unsubscribe := index.Subscribe(ctx)
+			defer unsubscribe()
+
+			require.NoError(t, index.Clear(ctx))
+			require.Equal(t, ChangeClear, (<-events).Op)
+
+			count, err := index.Count(ctx)
+			require.NoError(t, err)
+			require.Zero(t, count)
+
+			_, err = index.Search(ctx, "gold")
+			require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+			// the tables are kept, so the Index is still usable
+			require.NoError(t, index.Insert(ctx, Attribute[int, string]{Key: 3, Value: "gold rush"}))
+
+			res, err := index.Search(ctx, "gold")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{{Key: 3, Value: "gold rush"}}, res)
+		})
+	}
+}
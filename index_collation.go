@@ -0,0 +1,75 @@
+package fts
+
+import (
+	"fmt"
+	"regexp"
+
+	"modernc.org/sqlite"
+)
+
+const (
+	deleteWithCollationQuery = `
+DELETE FROM fulltext_search
+	WHERE id COLLATE %s IN (%%s);
+`
+
+	updateIfWithCollationQuery = `
+UPDATE fulltext_search
+	SET val = ?1
+	WHERE id = ?2 COLLATE %s AND val = ?3;
+`
+)
+
+var collationName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// RegisterCollation makes the input comparison function available to SQLite as a collation with the input name, which
+// can then be used in an Index with the WithKeyCollation option.
+//
+// The comparison function must return a negative number if left is less than right, zero if both are equal, and a
+// positive number if left is greater than right; and it must always return the same result for the same inputs.
+//
+// The collation is registered in the SQLite driver, and is only available to database connections opened after this
+// call. This means that collations should be registered before creating any Index that uses them. SQLite's built-in
+// collations (BINARY, NOCASE and RTRIM) do not need to be registered.
+func RegisterCollation(name string, fn func(left, right string) int) error {
+	if !collationName.MatchString(name) {
+		return fmt.Errorf("invalid collation name: %q", name)
+	}
+
+	return sqlite.RegisterCollationUtf8(name, fn)
+}
+
+func deleteQueryFor(keyCollation string, store storage) string {
+	switch {
+	case store.external() && keyCollation != "":
+		return fmt.Sprintf(deleteExternalWithCollationQuery, keyCollation)
+	case store.external():
+		return deleteExternalQuery
+	case keyCollation != "":
+		return fmt.Sprintf(deleteWithCollationQuery, keyCollation)
+	default:
+		return deleteQuery
+	}
+}
+
+// deleteMatchQueryFor returns the query deleting the rows of a single key through the full-text index, or an empty
+// string if keys can't be looked up this way: with external content (where keys are looked up through an index on the
+// fulltext_values table) or with a key collation (which the full-text index does not follow).
+func deleteMatchQueryFor(keyCollation string, store storage) string {
+	if store.external() || keyCollation != "" {
+		return ""
+	}
+
+	return deleteMatchQuery
+}
+
+func updateIfQueryFor(keyCollation string, store storage) string {
+	switch {
+	case store.external():
+		return updateIfExternalQueryFor(keyCollation, store)
+	case keyCollation != "":
+		return fmt.Sprintf(updateIfWithCollationQuery, keyCollation)
+	default:
+		return updateIfQuery
+	}
+}
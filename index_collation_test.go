@@ -0,0 +1,85 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+var registerCollationOnce sync.Once
+
+func TestIndex_KeyCollation(t *testing.T) {
+	registerCollationOnce.Do(func() {
+		require.NoError(t, RegisterCollation("TRIMMED_NOCASE", func(left, right string) int {
+			return strings.Compare(
+				strings.ToLower(strings.TrimSpace(left)),
+				strings.ToLower(strings.TrimSpace(right)),
+			)
+		}))
+	})
+
+	for _, testcase := range []struct {
+		name      string
+		collation string
+		key       string
+		wants     []string
+	}{
+		{
+			name:      "NoCase/MatchesOnlyExactKey",
+			collation: "NOCASE",
+			key:       "gold",
+			wants:     []string{"gold mine", "silver"},
+		},
+		{
+			name:      "Binary/CaseSensitive",
+			collation: "BINARY",
+			key:       "gold",
+			wants:     []string{"Gold", "gold mine", "silver"},
+		},
+		{
+			name:      "Custom/TrimmedNoCase",
+			collation: "TRIMMED_NOCASE",
+			key:       "  GOLD ",
+			wants:     []string{"gold mine", "silver"},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[string, string](cfg.New[Config](
+				WithURI(filepath.Join(t.TempDir(), "index.db")),
+				WithKeyCollation(testcase.collation),
+			),
+				Attribute[string, string]{Key: "Gold", Value: "struck gold"},
+				Attribute[string, string]{Key: "gold mine", Value: "more gold"},
+				Attribute[string, string]{Key: "silver", Value: "not gold"},
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			require.NoError(t, index.Delete(ctx, testcase.key))
+
+			res, err := index.Search(ctx, "gold")
+			require.NoError(t, err)
+
+			keys := make([]string, 0, len(res))
+			for i := range res {
+				keys = append(keys, res[i].Key)
+			}
+
+			require.ElementsMatch(t, testcase.wants, keys)
+		})
+	}
+}
+
+func TestRegisterCollation_InvalidName(t *testing.T) {
+	require.Error(t, RegisterCollation("no spaces; allowed", strings.Compare))
+}
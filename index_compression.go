@@ -0,0 +1,125 @@
+package fts
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sync"
+
+	"modernc.org/sqlite"
+)
+
+const (
+	compressFunc   = "fts_compress"
+	decompressFunc = "fts_decompress"
+)
+
+// Codec compresses and decompresses the values stored in an Index, as configured with the WithValueCompression
+// option.
+//
+// The codec's name is stored in the database schema, so an Index created with a codec must always be opened with a
+// codec of the same name. Names must be valid SQL identifiers (letters, digits and underscores).
+type Codec interface {
+	// Name returns the unique name of the Codec.
+	Name() string
+	// Compress returns the compressed form of the input data.
+	Compress(data []byte) ([]byte, error)
+	// Decompress returns the original data from the input compressed data.
+	Decompress(data []byte) ([]byte, error)
+}
+
+// codecs holds the Codec registered by each Index with value compression, by name; which are looked-up by the SQL
+// functions that compress and decompress the values.
+var codecs sync.Map
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction(compressFunc, 2, compress)
+	sqlite.MustRegisterDeterministicScalarFunction(decompressFunc, 2, decompress)
+}
+
+// GzipCodec returns a Codec using gzip compression, with the default compression level.
+func GzipCodec() Codec {
+	return gzipCodec{}
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Name() string { return "gzip" }
+
+func (gzipCodec) Compress(data []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (gzipCodec) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+func lookupCodec(name driver.Value) (Codec, error) {
+	codec, ok := codecs.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("unregistered codec: %v", name)
+	}
+
+	return codec.(Codec), nil
+}
+
+// compress is the SQL function that compresses a value (its second argument) with the Codec named by its first
+// argument. Non-character values are compressed in their text form.
+func compress(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	codec, err := lookupCodec(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := args[1].(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return codec.Compress(v)
+	case string:
+		return codec.Compress([]byte(v))
+	default:
+		return codec.Compress([]byte(fmt.Sprint(v)))
+	}
+}
+
+// decompress is the SQL function that decompresses a value (its second argument) with the Codec named by its first
+// argument, returning it as text.
+func decompress(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	codec, err := lookupCodec(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := args[1].([]byte)
+	if !ok {
+		return args[1], nil
+	}
+
+	value, err := codec.Decompress(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(value), nil
+}
@@ -0,0 +1,129 @@
+package fts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_ValueCompression(t *testing.T) {
+	ctx := context.Background()
+	large := strings.Repeat("struck gold in the old copper mine, ", 4096)
+
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: large},
+		{Key: 2, Value: "some data"},
+		{Key: 3, Value: "probably bronze"},
+	}
+
+	sizes := make(map[string]int64, 2)
+
+	for _, testcase := range []struct {
+		name string
+		opts []cfg.Option[Config]
+	}{
+		{name: "Uncompressed"},
+		{name: "Compressed", opts: []cfg.Option[Config]{WithValueCompression(GzipCodec())}},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "index.db")
+
+			index, err := newIndex[int, string](cfg.New(append(testcase.opts, WithURI(path))...), attrs...)
+			require.NoError(t, err)
+
+			res, err := index.Search(ctx, "gold")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{{Key: 1, Value: large}}, res)
+
+			res, err = index.SearchWithOpts(ctx, "bronze", SearchOpts{
+				IncludeValue: true,
+				Highlight:    &HighlightOpts{Open: "[", Close: "]"},
+			})
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{{Key: 3, Value: "probably [bronze]"}}, res)
+
+			swapped, err := index.UpdateIf(ctx, 2, "some data", "some other data")
+			require.NoError(t, err)
+			require.True(t, swapped)
+
+			res, err = index.Search(ctx, "other")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{{Key: 2, Value: "some other data"}}, res)
+
+			require.NoError(t, index.Delete(ctx, 1))
+
+			_, err = index.Search(ctx, "gold")
+			require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+			require.NoError(t, index.Insert(ctx, attrs[0]))
+			require.NoError(t, index.Shutdown(ctx))
+
+			stat, err := os.Stat(path)
+			require.NoError(t, err)
+
+			sizes[testcase.name] = stat.Size()
+		})
+	}
+
+	require.Less(t, sizes["Compressed"], sizes["Uncompressed"])
+}
+
+func TestIndex_ValueCompressionMismatch(t *testing.T) {
+	ctx := context.Background()
+
+	for _, testcase := range []struct {
+		name   string
+		create []cfg.Option[Config]
+		open   []cfg.Option[Config]
+		err    error
+	}{
+		{
+			name:   "Success/Reopen",
+			create: []cfg.Option[Config]{WithValueCompression(GzipCodec())},
+			open:   []cfg.Option[Config]{WithValueCompression(GzipCodec())},
+		},
+		{
+			name:   "Fail/MissingCompression",
+			create: []cfg.Option[Config]{WithValueCompression(GzipCodec())},
+			err:    ErrInvalidCompression,
+		},
+		{
+			name: "Fail/UnexpectedCompression",
+			open: []cfg.Option[Config]{WithValueCompression(GzipCodec())},
+			err:  ErrInvalidCompression,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "index.db")
+
+			index, err := newIndex[int, string](
+				cfg.New(append(testcase.create, WithURI(path))...),
+				Attribute[int, string]{Key: 1, Value: "some data"},
+			)
+			require.NoError(t, err)
+			require.NoError(t, index.Shutdown(ctx))
+
+			index, err = newIndex[int, string](cfg.New(append(testcase.open, WithURI(path))...))
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.Search(ctx, "data")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{{Key: 1, Value: "some data"}}, res)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_ReadAfterWrite(t *testing.T) {
+	const numIterations = 500
+
+	for _, testcase := range []struct {
+		name string
+		opts []cfg.Option[Config]
+	}{
+		{
+			name: "Default",
+		},
+		{
+			name: "WithWriteQueue",
+			opts: []cfg.Option[Config]{WithWriteQueue(4)},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](
+				cfg.New(append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...),
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			for i := 0; i < numIterations; i++ {
+				term := fmt.Sprintf("token%d", i)
+
+				require.NoError(t, index.Insert(ctx, Attribute[int, string]{Key: i, Value: "entry " + term}))
+
+				res, err := index.Search(ctx, term)
+				require.NoError(t, err, "missed read of iteration %d", i)
+				require.Equal(t, []Attribute[int, string]{{Key: i, Value: "entry " + term}}, res)
+
+				require.NoError(t, index.Delete(ctx, i))
+
+				_, err = index.Search(ctx, term)
+				require.ErrorIs(t, err, ErrNotFoundKeyword, "stale read of iteration %d", i)
+			}
+		})
+	}
+}
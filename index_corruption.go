@@ -0,0 +1,104 @@
+package fts
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const corruptSuffixFormat = "%s.corrupt-%d"
+
+// journalSuffixes lists the suffixes of the files that SQLite keeps alongside a database file, which belong to it.
+var journalSuffixes = []string{"-wal", "-shm", "-journal"}
+
+// openDatabase opens and initializes the SQLite database for the input Config.
+//
+// If the database file is corrupt, the returned error wraps ErrCorruptDatabase; unless the Config is set to recover
+// from corruption, in which case the file is moved aside and a fresh database is created in its place.
+func openDatabase(config Config, tok tokenizer, store storage) (*sql.DB, error) {
+	db, err := open(config.uri, config.rawDSN, config.memoryName, config.cacheMode, config.pragmas, config.maxOpenConns)
+	if err != nil {
+		return nil, err
+	}
+
+	err = initDatabase(db, tok, store, config.initPragmas)
+	if err == nil {
+		return db, nil
+	}
+
+	closeErr := db.Close()
+	if !isCorrupt(err) {
+		return nil, errors.Join(err, closeErr)
+	}
+
+	if !config.recoverOnCorruption || isInMemory(config.uri) {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptDatabase, err)
+	}
+
+	aside, moveErr := moveAside(config.uri)
+	if moveErr != nil {
+		return nil, errors.Join(fmt.Errorf("%w: %w", ErrCorruptDatabase, err), moveErr)
+	}
+
+	logger := slog.Default()
+	if config.logHandler != nil {
+		logger = slog.New(config.logHandler)
+	}
+
+	logger.Warn("corrupt database file moved aside, starting with an empty index",
+		slog.String("uri", config.uri),
+		slog.String("moved_to", aside),
+		slog.String("error", err.Error()),
+	)
+
+	db, err = open(config.uri, config.rawDSN, config.memoryName, config.cacheMode, config.pragmas, config.maxOpenConns)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = initDatabase(db, tok, store, config.initPragmas); err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+
+	return db, nil
+}
+
+// isCorrupt returns true if the input error is a SQLite error reporting a corrupt database, or a file that is not a
+// database.
+func isCorrupt(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_CORRUPT, sqlite3.SQLITE_NOTADB:
+		return true
+	default:
+		return false
+	}
+}
+
+// moveAside renames the input database file (and its journal files, if any) with a ".corrupt-<unix timestamp>"
+// suffix, returning the new path of the database file.
+func moveAside(uri string) (string, error) {
+	aside := fmt.Sprintf(corruptSuffixFormat, uri, time.Now().Unix())
+
+	if err := os.Rename(uri, aside); err != nil {
+		return "", err
+	}
+
+	for _, suffix := range journalSuffixes {
+		if err := os.Rename(uri+suffix, aside+suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return aside, err
+		}
+	}
+
+	return aside, nil
+}
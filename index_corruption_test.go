@@ -0,0 +1,61 @@
+package fts
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestNewIndex_CorruptDatabase(t *testing.T) {
+	for _, testcase := range []struct {
+		name string
+		opts []cfg.Option[Config]
+		err  error
+	}{
+		{
+			name: "Fail/Corrupt",
+			err:  ErrCorruptDatabase,
+		},
+		{
+			name: "Success/RecoverOnCorruption",
+			opts: []cfg.Option[Config]{WithRecoverOnCorruption()},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			dir := t.TempDir()
+			path := filepath.Join(dir, "index.db")
+
+			require.NoError(t, os.WriteFile(path, bytes.Repeat([]byte("not a database "), 512), 0o600))
+
+			index, err := newIndex[int, string](
+				cfg.New(append(testcase.opts, WithURI(path))...),
+				Attribute[int, string]{Key: 1, Value: "some data"},
+			)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.Search(ctx, "data")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{{Key: 1, Value: "some data"}}, res)
+
+			aside, err := filepath.Glob(path + ".corrupt-*")
+			require.NoError(t, err)
+			require.Len(t, aside, 1)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package fts
+
+import "context"
+
+// Count returns the number of rows in the Index, such as to display the number of indexed documents or to decide when
+// to run maintenance (e.g. with Optimize). Attributes sharing a key are counted once per value. An empty Index has a
+// count of zero.
+//
+// This call returns an error if the underlying SQL query fails.
+func (i *Index[K, V]) Count(ctx context.Context) (count int64, err error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if err = i.db.QueryRowContext(ctx, countQuery).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
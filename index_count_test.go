@@ -0,0 +1,45 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Count(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		attrs []Attribute[int, string]
+		wants int64
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name: "DuplicateKeys",
+			attrs: []Attribute[int, string]{
+				{Key: 1, Value: "some data"},
+				{Key: 2, Value: "struck gold"},
+				{Key: 2, Value: "struck silver"},
+			},
+			wants: 3,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), testcase.attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			count, err := index.Count(ctx)
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, count)
+		})
+	}
+}
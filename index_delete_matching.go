@@ -0,0 +1,92 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const matchingRowIDsQuery = `
+SELECT rowid FROM fulltext_search(?);
+`
+
+// DeleteMatchingAny removes all attributes in the Index that match any of the input search terms, returning the number
+// of deleted attributes. This purges every attribute matching a list of terms (such as banned words) in one pass.
+//
+// The search terms are combined into a single FTS5 query with the OR operator (each term wrapped in parentheses, so it
+// may be a search expression of its own), so an attribute matched by several terms is only deleted (and counted) once.
+// The matching row IDs are queried and deleted within the same database transaction. If the context is canceled while
+// the transaction is open, it is rolled back and the context's error is returned; so that none of the attributes are
+// deleted.
+//
+// Calling DeleteMatchingAny with no search terms is a no-op. If the Index is configured with a write queue, the call
+// is enqueued and blocks until it is processed.
+func (i *Index[K, V]) DeleteMatchingAny(ctx context.Context, searchTerms ...V) (n int, err error) {
+	if len(searchTerms) == 0 {
+		return 0, nil
+	}
+
+	terms := make([]string, 0, len(searchTerms))
+
+	for idx := range searchTerms {
+		term, ok := charString(searchTerms[idx])
+		if !ok {
+			term = fmt.Sprint(searchTerms[idx])
+		}
+
+		terms = append(terms, "("+term+")")
+	}
+
+	query := strings.Join(terms, " OR ")
+
+	err = i.write(ctx, func(ctx context.Context) error {
+		i.mu.RLock()
+		defer i.mu.RUnlock()
+
+		tx, err := i.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.QueryContext(ctx, matchingRowIDsQuery, query)
+		if err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		rowIDs, err := scanRows(rows, 0, func(rows *sql.Rows) (rowID int64, err error) {
+			return rowID, rows.Scan(&rowID)
+		})
+		if err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		for idx := range rowIDs {
+			if err = ctx.Err(); err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+
+			if _, err = tx.ExecContext(ctx, i.deleteRowQuery, rowIDs[idx]); err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+		}
+
+		// a transaction that only read the matches is rolled back, without waiting for other writers to commit
+		if len(rowIDs) == 0 {
+			return rollback(tx)
+		}
+
+		if err = i.changes.commit(tx, func() ChangeEvent[K] {
+			return ChangeEvent[K]{Op: ChangeDelete, RowIDs: rowIDs}
+		}); err != nil {
+			return err
+		}
+
+		n = len(rowIDs)
+
+		return nil
+	})
+
+	return n, err
+}
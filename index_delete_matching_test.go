@@ -0,0 +1,72 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_DeleteMatchingAny(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "spam offer"},
+		{Key: 2, Value: "scam offer"},
+		{Key: 3, Value: "spam and scam"},
+		{Key: 4, Value: "genuine offer"},
+	}
+
+	for _, testcase := range []struct {
+		name      string
+		terms     []string
+		wantsN    int
+		remaining []Attribute[int, string]
+	}{
+		{
+			name:      "Success/OverlappingTerms",
+			terms:     []string{"spam", "scam"},
+			wantsN:    3,
+			remaining: []Attribute[int, string]{attrs[3]},
+		},
+		{
+			name:      "Success/Expression",
+			terms:     []string{"spam NOT scam", "genuine"},
+			wantsN:    2,
+			remaining: []Attribute[int, string]{attrs[1], attrs[2]},
+		},
+		{
+			name:      "Success/NoMatches",
+			terms:     []string{"phishing"},
+			remaining: attrs,
+		},
+		{
+			name:      "Success/NoTerms",
+			remaining: attrs,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			n, err := index.DeleteMatchingAny(ctx, testcase.terms...)
+			require.NoError(t, err)
+			require.Equal(t, testcase.wantsN, n)
+
+			res, err := index.SearchWithOpts(ctx, "offer OR and", SearchOpts{IncludeValue: true, Order: OrderSequence})
+			if len(testcase.remaining) == 0 {
+				require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.remaining, res)
+		})
+	}
+}
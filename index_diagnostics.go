@@ -0,0 +1,84 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const (
+	tableSQLQuery = `
+SELECT sql FROM sqlite_master 
+	WHERE name = 'fulltext_search';
+`
+
+	countQuery = `SELECT count(*) FROM fulltext_search;`
+
+	pragmaQuery = `PRAGMA %s;`
+)
+
+var diagnosticPragmas = []string{"journal_mode", "synchronous", "page_size", "cache_size"}
+
+// Diagnostics is a structured dump of an Index's state and configuration, meant to be serialized as JSON and attached
+// to support requests.
+type Diagnostics struct {
+	SQLiteVersion string            `json:"sqlite_version"`
+	FTS5          bool              `json:"fts5"`
+	TableSQL      string            `json:"table_sql"`
+	RowCount      int64             `json:"row_count"`
+	Tokenizer     string            `json:"tokenizer"`
+	Pragmas       map[string]string `json:"pragmas"`
+}
+
+// Diagnostics gathers a structured dump of the Index's state and configuration, consisting of the SQLite version and
+// FTS5 availability, the statement that created the FTS5 table, the number of indexed rows, the tokenizer, and the
+// database's journal_mode, synchronous, page_size and cache_size pragmas.
+//
+// The tokenizer is read from the statement that created the FTS5 table, as it is the one in use; which may differ from
+// the configured one when opening an existing (persisted) table.
+func (i *Index[K, V]) Diagnostics(ctx context.Context) (Diagnostics, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	info, err := i.engineInfo(ctx)
+	if err != nil {
+		return Diagnostics{}, err
+	}
+
+	diag := Diagnostics{
+		SQLiteVersion: info.Version,
+		FTS5:          info.FTS5,
+		Pragmas:       make(map[string]string, len(diagnosticPragmas)),
+	}
+
+	if err = i.db.QueryRowContext(ctx, tableSQLQuery).Scan(&diag.TableSQL); err != nil {
+		return Diagnostics{}, err
+	}
+
+	diag.Tokenizer = tableTokenizer(diag.TableSQL)
+
+	if err = i.db.QueryRowContext(ctx, countQuery).Scan(&diag.RowCount); err != nil {
+		return Diagnostics{}, err
+	}
+
+	for _, pragma := range diagnosticPragmas {
+		var value string
+
+		if err = i.db.QueryRowContext(ctx, fmt.Sprintf(pragmaQuery, pragma)).Scan(&value); err != nil {
+			return Diagnostics{}, err
+		}
+
+		diag.Pragmas[pragma] = value
+	}
+
+	return diag, nil
+}
+
+// PoolStats returns the statistics of the Index's database connection pool, such as the number of open, in-use and
+// idle connections, and how often (and for how long) callers waited for a connection.
+func (i *Index[K, V]) PoolStats() sql.DBStats {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.db.Stats()
+}
@@ -0,0 +1,109 @@
+package fts
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_Diagnostics(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := newIndex[int, string](cfg.New[Config](
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithTokenChars("-"),
+	),
+		Attribute[int, string]{Key: 1, Value: "struck gold"},
+		Attribute[int, string]{Key: 2, Value: "gold-plate"},
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	diag, err := index.Diagnostics(ctx)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, diag.SQLiteVersion)
+	require.True(t, diag.FTS5)
+	require.Contains(t, diag.TableSQL, "CREATE VIRTUAL TABLE fulltext_search")
+	require.Equal(t, int64(2), diag.RowCount)
+	require.Equal(t, "unicode61 tokenchars '-'", diag.Tokenizer)
+	require.Len(t, diag.Pragmas, len(diagnosticPragmas))
+
+	data, err := json.Marshal(diag)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"table_sql":"CREATE VIRTUAL TABLE fulltext_search`)
+}
+
+func TestIndex_DiagnosticsExistingTokenizer(t *testing.T) {
+	ctx := context.Background()
+	uri := filepath.Join(t.TempDir(), "index.db")
+
+	index, err := newIndex[int, string](cfg.New[Config](WithURI(uri), WithTokenChars("-")))
+	require.NoError(t, err)
+	require.NoError(t, index.Shutdown(ctx))
+
+	// the existing table keeps its tokenizer, even when opened without configuring it
+	index, err = NewIndex[int, string](uri)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	diag, err := index.Diagnostics(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "unicode61 tokenchars '-'", diag.Tokenizer)
+}
+
+func TestIndex_PoolStats(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		opts  []cfg.Option[Config]
+		wants int
+	}{
+		{
+			name:  "Success/Unbounded",
+			wants: 0,
+		},
+		{
+			name:  "Success/WithMaxOpenConns",
+			opts:  []cfg.Option[Config]{WithMaxOpenConns(4)},
+			wants: 4,
+		},
+		{
+			name:  "Success/InvalidMaxOpenConns",
+			opts:  []cfg.Option[Config]{WithMaxOpenConns(0)},
+			wants: 0,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New[Config](
+				append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...,
+			),
+				Attribute[int, string]{Key: 1, Value: "struck gold"},
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			_, err = index.Search(ctx, "gold")
+			require.NoError(t, err)
+
+			stats := index.PoolStats()
+			require.Equal(t, testcase.wants, stats.MaxOpenConnections)
+			require.Positive(t, stats.OpenConnections)
+			require.Zero(t, stats.InUse)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const (
+	dumpHeader = "BEGIN TRANSACTION;\n"
+	dumpFooter = "COMMIT;\n"
+
+	schemaQuery = `
+SELECT type, name, sql FROM sqlite_master
+	WHERE sql NOT NULL
+	AND name NOT LIKE 'sqlite\_%' ESCAPE '\'
+	ORDER BY rowid;
+`
+
+	valuesColumnsQuery = `
+SELECT name FROM pragma_table_info('fulltext_values')
+	ORDER BY cid;
+`
+
+	dumpSearchQuery = `
+SELECT quote(rowid), quote(id), quote(val) FROM fulltext_search
+	ORDER BY rowid;
+`
+
+	dumpValuesQuery = `
+SELECT %s FROM fulltext_values
+	ORDER BY seq;
+`
+
+	dumpInsertFormat = "INSERT INTO %s (%s) VALUES (%s);\n"
+
+	rollbackQuery = `ROLLBACK;`
+)
+
+// shadowSuffixes lists the suffixes of the shadow tables that FTS5 creates (and fills) itself for each of its virtual
+// tables, after the virtual table's name.
+var shadowSuffixes = []string{"_data", "_idx", "_content", "_docsize", "_config"}
+
+// createStatement matches the start of a CREATE statement, as stored in sqlite_master, up to the object's name.
+var createStatement = regexp.MustCompile(
+	`(?i)^(CREATE\s+(?:VIRTUAL\s+TABLE|TABLE|VIEW|TRIGGER|(?:UNIQUE\s+)?INDEX)\s+)(?:IF\s+NOT\s+EXISTS\s+)?`,
+)
+
+type schemaObject struct {
+	kind string
+	name string
+	sql  string
+}
+
+// Dump writes a textual dump of the Index to the input io.Writer, as SQL statements in the format of the sqlite3 CLI's
+// .dump command: so that it can be replayed with the sqlite3 CLI, or loaded into another Index with ImportSQL.
+//
+// The dump holds the statements creating the Index's tables, views and triggers (with IF NOT EXISTS, so it can be
+// replayed on an Index that already has them), followed by the statements inserting its logical rows, within a
+// single transaction. FTS5's shadow tables are not dumped: the full-text index is rebuilt from the inserted rows. The
+// row IDs are preserved, so that the attributes keep their insertion order. With external content (e.g. with
+// WithValueCompression or WithInsertTimestamps), the rows of the fulltext_values table are dumped instead, and the
+// full-text index is rebuilt by its triggers; in which case compressed values can only be replayed with ImportSQL,
+// where the codec's functions are registered.
+//
+// The rows are read within a single transaction and written as they are scanned, so the dump is a consistent snapshot
+// of the Index that is never held in memory as a whole.
+//
+// This call returns an error if reading the Index's schema or rows fails, or if writing to the io.Writer fails.
+func (i *Index[K, V]) Dump(ctx context.Context, w io.Writer) error {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	tx, err := i.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+
+	if err = dump(ctx, tx, w, i.store.external()); err != nil {
+		return errors.Join(err, rollback(tx))
+	}
+
+	return rollback(tx)
+}
+
+func dump(ctx context.Context, tx *sql.Tx, w io.Writer, external bool) error {
+	rows, err := tx.QueryContext(ctx, schemaQuery)
+	if err != nil {
+		return err
+	}
+
+	objects, err := scanRows(rows, 0, func(rows *sql.Rows) (obj schemaObject, err error) {
+		return obj, rows.Scan(&obj.kind, &obj.name, &obj.sql)
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.WriteString(w, dumpHeader); err != nil {
+		return err
+	}
+
+	for _, obj := range dumpedObjects(objects) {
+		if _, err = fmt.Fprintf(w, "%s;\n", createStatement.ReplaceAllString(obj.sql, "${1}IF NOT EXISTS ")); err != nil {
+			return err
+		}
+	}
+
+	table, columns, query := "fulltext_search", []string{"rowid", "id", "val"}, dumpSearchQuery
+
+	if external {
+		if columns, err = valuesColumns(ctx, tx); err != nil {
+			return err
+		}
+
+		quoted := make([]string, 0, len(columns))
+		for _, column := range columns {
+			quoted = append(quoted, fmt.Sprintf(`quote("%s")`, column))
+		}
+
+		table, query = "fulltext_values", fmt.Sprintf(dumpValuesQuery, strings.Join(quoted, ", "))
+	}
+
+	if err = dumpRows(ctx, tx, w, table, columns, query); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, dumpFooter)
+
+	return err
+}
+
+// dumpedObjects returns the input schema objects without FTS5's shadow tables: the regular tables named after a virtual
+// table with one of FTS5's shadow suffixes, such as fulltext_search_data or fulltext_search_config, which FTS5 creates
+// (and fills) itself. Other objects named after a virtual table, such as the fulltext_infix_insert trigger, are kept.
+func dumpedObjects(objects []schemaObject) []schemaObject {
+	var virtual []string
+
+	for _, obj := range objects {
+		if obj.kind == "table" && isVirtualTable(obj.sql) {
+			virtual = append(virtual, obj.name)
+		}
+	}
+
+	dumped := make([]schemaObject, 0, len(objects))
+
+	for _, obj := range objects {
+		if isShadowObject(obj, virtual) {
+			continue
+		}
+
+		dumped = append(dumped, obj)
+	}
+
+	return dumped
+}
+
+func isVirtualTable(sql string) bool {
+	return strings.HasPrefix(strings.ToUpper(strings.Join(strings.Fields(sql), " ")), "CREATE VIRTUAL TABLE")
+}
+
+func isShadowObject(obj schemaObject, virtual []string) bool {
+	if obj.kind != "table" || isVirtualTable(obj.sql) {
+		return false
+	}
+
+	for _, name := range virtual {
+		for _, suffix := range shadowSuffixes {
+			if obj.name == name+suffix {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func valuesColumns(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, valuesColumnsQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRows(rows, 0, func(rows *sql.Rows) (name string, err error) {
+		return name, rows.Scan(&name)
+	})
+}
+
+// dumpRows writes an INSERT statement into the input table for each row of the input query, which yields the quoted
+// (SQL literal) values of the input columns.
+func dumpRows(ctx context.Context, tx *sql.Tx, w io.Writer, table string, columns []string, query string) error {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	values := make([]string, len(columns))
+	dest := make([]any, len(columns))
+
+	for idx := range values {
+		dest[idx] = &values[idx]
+	}
+
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return err
+		}
+
+		for idx := range values {
+			values[idx] = escapeLineBreaks(values[idx])
+		}
+
+		_, err = fmt.Fprintf(w, dumpInsertFormat, table, strings.Join(columns, ", "), strings.Join(values, ", "))
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// escapeLineBreaks replaces the line breaks in the input SQL literal (as returned by SQLite's quote() function) with
+// char() calls, as the sqlite3 CLI's .dump command does; so that each INSERT statement is written in a single line.
+// Only text literals can contain line breaks, so the replacement always splits a quoted string.
+func escapeLineBreaks(literal string) string {
+	return strings.NewReplacer("\r", "'||char(13)||'", "\n", "'||char(10)||'").Replace(literal)
+}
+
+// ImportSQL executes the SQL statements read from the input io.Reader in the Index's database, such as a dump written
+// by Dump; which loads its rows into an Index with the same storage configuration (e.g. a fresh one).
+//
+// The statements are executed as a single script, on a single connection. If a statement fails, the script's open
+// transaction (if any) is rolled back and the error is returned. Imported rows are not published to the Index's
+// subscribers (see Subscribe). If the Index is configured with a write queue, the call is enqueued and blocks until
+// it is processed.
+//
+// This call returns an error if reading from the io.Reader fails, or if executing the statements fails.
+func (i *Index[K, V]) ImportSQL(ctx context.Context, r io.Reader) error {
+	script, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return i.write(ctx, func(ctx context.Context) error {
+		i.mu.RLock()
+		defer i.mu.RUnlock()
+
+		conn, err := i.db.Conn(ctx)
+		if err != nil {
+			return err
+		}
+
+		defer conn.Close()
+
+		if _, err = conn.ExecContext(ctx, string(script)); err != nil {
+			// fails harmlessly if the script failed outside of a transaction
+			_, _ = conn.ExecContext(context.Background(), rollbackQuery)
+
+			return err
+		}
+
+		return nil
+	})
+}
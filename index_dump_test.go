@@ -0,0 +1,127 @@
+package fts
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_Dump(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "struck gold"},
+		{Key: 2, Value: "it's a\nmulti-line\r\nvalue"},
+		{Key: 3, Value: "silver lining"},
+	}
+
+	for _, testcase := range []struct {
+		name   string
+		opts   []cfg.Option[Config]
+		replay bool
+		infix  bool
+	}{
+		{
+			name:   "Default",
+			replay: true,
+		},
+		{
+			name: "ExternalContent",
+			opts: []cfg.Option[Config]{WithValueCompression(GzipCodec()), WithInsertTimestamps()},
+		},
+		{
+			name:   "InfixSearch",
+			opts:   []cfg.Option[Config]{WithInfixSearch()},
+			replay: true,
+			infix:  true,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New(append(testcase.opts,
+				WithURI(filepath.Join(t.TempDir(), "index.db")),
+			)...), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			// the row IDs are preserved, including the gap left by a deleted row
+			require.NoError(t, index.Delete(ctx, 1))
+
+			buf := &bytes.Buffer{}
+			require.NoError(t, index.Dump(ctx, buf))
+
+			dump := buf.String()
+			require.Contains(t, dump, "BEGIN TRANSACTION;\n")
+			require.Contains(t, dump, "CREATE VIRTUAL TABLE IF NOT EXISTS fulltext_search")
+			require.NotContains(t, dump, "fulltext_search_data")
+			require.NotContains(t, dump, "fulltext_search_content")
+
+			// each INSERT statement is written in a single line
+			require.Contains(t, dump, "'it''s a'||char(10)||'multi-line'||char(13)||''||char(10)||'value'")
+
+			fresh, err := newIndex[int, string](cfg.New(append(testcase.opts,
+				WithURI(filepath.Join(t.TempDir(), "fresh.db")),
+			)...))
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, fresh.Shutdown(ctx))
+			}()
+
+			require.NoError(t, fresh.ImportSQL(ctx, buf))
+
+			wants, err := index.List(ctx, OrderSequence)
+			require.NoError(t, err)
+
+			res, err := fresh.List(ctx, OrderSequence)
+			require.NoError(t, err)
+			require.Equal(t, wants, res)
+
+			rowIDs, err := fresh.InsertReturning(ctx, Attribute[int, string]{Key: 4, Value: "copper"})
+			require.NoError(t, err)
+			require.Equal(t, []int64{4}, rowIDs)
+
+			// the full-text index is rebuilt from the imported rows
+			res, err = fresh.Search(ctx, "multi")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{attrs[1]}, res)
+
+			if !testcase.replay {
+				return
+			}
+
+			// replaying the dump on an empty database, as with the sqlite3 CLI, recreates a working Index
+			uri := filepath.Join(t.TempDir(), "replayed.db")
+
+			db, err := open(uri, "", "", "", nil, 0)
+			require.NoError(t, err)
+
+			_, err = db.ExecContext(ctx, dump)
+			require.NoError(t, err)
+			require.NoError(t, db.Close())
+
+			replayed, err := NewIndexWithOptions[int, string](nil, append(testcase.opts, WithURI(uri))...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, replayed.Shutdown(ctx))
+			}()
+
+			res, err = replayed.List(ctx, OrderSequence)
+			require.NoError(t, err)
+			require.Equal(t, wants, res)
+
+			if testcase.infix {
+				res, err = replayed.SearchInfix(ctx, "ilve")
+				require.NoError(t, err)
+				require.Equal(t, []Attribute[int, string]{attrs[2]}, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package fts
+
+import "context"
+
+const (
+	sqliteVersionQuery = `SELECT sqlite_version();`
+
+	createFTS5ProbeQuery = `
+CREATE VIRTUAL TABLE temp.fts5_probe 
+	USING FTS5(probe);
+`
+
+	dropFTS5ProbeQuery = `DROP TABLE temp.fts5_probe;`
+)
+
+// EngineInfo describes the SQLite engine that backs an Index, as provided by the embedded driver.
+type EngineInfo struct {
+	// Version is the SQLite version string, as returned by `SELECT sqlite_version()`.
+	Version string
+	// FTS5 reports whether the FTS5 extension is compiled in.
+	FTS5 bool
+}
+
+// EngineInfo returns the SQLite version and whether the FTS5 extension is available in the SQLite engine backing the
+// Index. This is useful for diagnostics, when behavior differs across builds.
+//
+// The FTS5 availability is checked by creating (and dropping) a temporary FTS5 table.
+func (i *Index[K, V]) EngineInfo(ctx context.Context) (EngineInfo, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.engineInfo(ctx)
+}
+
+func (i *Index[K, V]) engineInfo(ctx context.Context) (EngineInfo, error) {
+	conn, err := i.db.Conn(ctx)
+	if err != nil {
+		return EngineInfo{}, err
+	}
+
+	defer conn.Close()
+
+	var info EngineInfo
+
+	if err = conn.QueryRowContext(ctx, sqliteVersionQuery).Scan(&info.Version); err != nil {
+		return EngineInfo{}, err
+	}
+
+	if _, err = conn.ExecContext(ctx, createFTS5ProbeQuery); err != nil {
+		// a failure to create the probe table means that the fts5 module is not available
+		return info, nil
+	}
+
+	info.FTS5 = true
+
+	if _, err = conn.ExecContext(ctx, dropFTS5ProbeQuery); err != nil {
+		return info, err
+	}
+
+	return info, nil
+}
@@ -0,0 +1,29 @@
+package fts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_EngineInfo(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := NewIndex[int, string]("")
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	info, err := index.EngineInfo(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, info.Version)
+	require.True(t, info.FTS5)
+
+	// the probe table is dropped, so the check can be repeated
+	info, err = index.EngineInfo(ctx)
+	require.NoError(t, err)
+	require.True(t, info.FTS5)
+}
@@ -0,0 +1,85 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const (
+	keyColumn   = "id"
+	valueColumn = "val"
+
+	explainScoresQuery = `
+SELECT id, val,
+	bm25(fulltext_search, 1.0, 0.0),
+	bm25(fulltext_search, 0.0, 1.0)
+	FROM fulltext_search(?)
+	ORDER BY rank, rowid;
+`
+)
+
+// ExplainedAttribute is an Attribute returned from a search, alongside the breakdown of its relevance score per
+// (indexed) column.
+//
+// The scores are the BM25 contributions of each column, as computed by FTS5's bm25() auxiliary function, inverted so
+// that a higher score means a more relevant match. Columns that did not contribute to the match are omitted.
+type ExplainedAttribute[K SQLType, V SQLType] struct {
+	Attribute[K, V]
+
+	Scores map[string]float64
+}
+
+// SearchExplainScores will look for matches for the input value through the indexed terms, returning a collection of
+// matching ExplainedAttribute sorted by relevance (best match first), which contain the key and (full) value for that
+// match, as well as the per-column breakdown of its score.
+//
+// Each column's contribution is calculated by weighting the bm25() function so that only that column is accounted
+// for. As bm25() saturates the term frequencies across all columns, the contributions are not additive: when a search
+// term matches both the "id" and the "val" columns (since keys are also indexed), their sum may differ from the
+// overall score of the match. When only the value matches, its contribution under "val" is the overall score.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query. If the Index is configured with a maximum number
+// of results and the search yields more than that, the capped results are returned alongside an ErrResultTruncated
+// error.
+func (i *Index[K, V]) SearchExplainScores(ctx context.Context, searchTerm V) ([]ExplainedAttribute[K, V], error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	rows, err := i.db.QueryContext(ctx, explainScoresQuery, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (ExplainedAttribute[K, V], error) {
+		var (
+			attr       = ExplainedAttribute[K, V]{Scores: make(map[string]float64, 2)}
+			keyScore   float64
+			valueScore float64
+		)
+
+		if err := rows.Scan(&attr.Key, &attr.Value, &keyScore, &valueScore); err != nil {
+			return attr, err
+		}
+
+		if keyScore != 0 {
+			attr.Scores[keyColumn] = -keyScore
+		}
+
+		if valueScore != 0 {
+			attr.Scores[valueColumn] = -valueScore
+		}
+
+		return attr, nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	return res, nil
+}
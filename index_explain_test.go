@@ -0,0 +1,42 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchExplainScores(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"),
+		Attribute[string, string]{Key: "gold-mine", Value: "struck gold"},
+		Attribute[string, string]{Key: "copper-mine", Value: "some gold in the copper"},
+		Attribute[string, string]{Key: "bronze-mine", Value: "probably bronze"},
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	res, err := index.SearchExplainScores(ctx, "gold")
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	// the key and the value both match, so both columns contribute to a better overall score
+	require.Equal(t, "gold-mine", res[0].Key)
+	require.Len(t, res[0].Scores, 2)
+	require.Greater(t, res[0].Scores[keyColumn], 0.0)
+	require.Greater(t, res[0].Scores[valueColumn], 0.0)
+
+	// only the value matches, so the whole score is listed under the value column
+	require.Equal(t, "copper-mine", res[1].Key)
+	require.Len(t, res[1].Scores, 1)
+	require.Greater(t, res[1].Scores[valueColumn], 0.0)
+
+	_, err = index.SearchExplainScores(ctx, "silver")
+	require.ErrorIs(t, err, ErrNotFoundKeyword)
+}
@@ -0,0 +1,348 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	checkValuesTableExists = `
+SELECT EXISTS(SELECT 1 FROM sqlite_master
+	WHERE type='table'
+	AND name='fulltext_values');
+`
+
+	checkSearchTextColumnExists = `
+SELECT EXISTS(SELECT 1 FROM pragma_table_info('fulltext_values')
+	WHERE name='text');
+`
+
+	checkInsertedColumnExists = `
+SELECT EXISTS(SELECT 1 FROM pragma_table_info('fulltext_values')
+	WHERE name='inserted');
+`
+
+	checkBoostColumnExists = `
+SELECT EXISTS(SELECT 1 FROM pragma_table_info('fulltext_values')
+	WHERE name='boost');
+`
+
+	contentViewSQLQuery = `
+SELECT sql FROM sqlite_master
+	WHERE type='view'
+	AND name='fulltext_content';
+`
+
+	createValuesTableQuery = `
+CREATE TABLE fulltext_values (
+	seq INTEGER PRIMARY KEY,
+	id,
+	val BLOB%s
+);
+`
+
+	// insertedColumn stores the time of insertion of each row, as a Unix timestamp in milliseconds.
+	insertedColumn = `,
+	inserted INTEGER NOT NULL DEFAULT (CAST((julianday('now') - 2440587.5) * 86400000 AS INTEGER))`
+
+	// boostColumn stores the ranking boost of each row (see InsertBoosted).
+	boostColumn = `,
+	boost REAL NOT NULL DEFAULT 0`
+
+	createContentViewQuery = `
+CREATE VIEW fulltext_content AS
+	SELECT seq, id, %s AS val FROM fulltext_values;
+`
+
+	createExternalTableQuery = `
+CREATE VIRTUAL TABLE fulltext_search
+	USING FTS5(id, val, content='fulltext_content', content_rowid='seq'%s);
+`
+
+	createInsertTriggerQuery = `
+CREATE TRIGGER fulltext_values_insert AFTER INSERT ON fulltext_values BEGIN
+	INSERT INTO fulltext_search (rowid, id, val)
+		VALUES (new.seq, new.id, %[1]s);
+END;
+`
+
+	createDeleteTriggerQuery = `
+CREATE TRIGGER fulltext_values_delete AFTER DELETE ON fulltext_values BEGIN
+	INSERT INTO fulltext_search (fulltext_search, rowid, id, val)
+		VALUES ('delete', old.seq, old.id, %[1]s);
+END;
+`
+
+	createUpdateTriggerQuery = `
+CREATE TRIGGER fulltext_values_update AFTER UPDATE ON fulltext_values BEGIN
+	INSERT INTO fulltext_search (fulltext_search, rowid, id, val)
+		VALUES ('delete', old.seq, old.id, %[1]s);
+	INSERT INTO fulltext_search (rowid, id, val)
+		VALUES (new.seq, new.id, %[2]s);
+END;
+`
+
+	insertExternalQuery = `
+INSERT INTO fulltext_values (id, val%s)
+	VALUES (?, %s%s);
+`
+
+	// createValuesKeyIndexQuery indexes the keys of the fulltext_values table, so that deletes look them up by equality
+	// without scanning the table. It is also created on existing databases, which predate it.
+	createValuesKeyIndexQuery = `
+CREATE INDEX IF NOT EXISTS fulltext_values_id
+	ON fulltext_values (id);
+`
+
+	deleteExternalQuery = `
+DELETE FROM fulltext_values
+	WHERE id IN (%s);
+`
+
+	deleteExternalWithCollationQuery = `
+DELETE FROM fulltext_values
+	WHERE id COLLATE %s IN (%%s);
+`
+
+	deleteByRowIDExternalQuery = `
+DELETE FROM fulltext_values
+	WHERE seq = ?;
+`
+
+	updateIfExternalQuery = `
+UPDATE fulltext_values
+	SET val = %[1]s%[2]s
+	WHERE id = ?2
+	AND %[3]s = ?3;
+`
+
+	updateIfExternalWithCollationQuery = `
+UPDATE fulltext_values
+	SET val = %[1]s%[2]s
+	WHERE id = ?2 COLLATE %[4]s
+	AND %[3]s = ?3;
+`
+)
+
+// storage describes how the values of an Index are stored: either directly in the fulltext_search table, or in the
+// fulltext_values table, as the external content of the fulltext_search table; when the values are compressed with a
+// Codec, when the indexed text is derived from them with a search text extractor, and / or when their insertion time,
+// ranking boost or metadata is recorded, and / or when they are also indexed for infix searches.
+//
+// The storage is part of the database schema: the options that define it must be set when the database is created and
+// every time it is opened; otherwise opening it returns an error for the mismatched option (e.g. ErrInvalidCompression
+// for WithValueCompression).
+type storage struct {
+	codec      string
+	searchText bool
+	timestamps bool
+	boosts     bool
+	metadata   []string
+	infix      bool
+}
+
+// external returns true if the values are stored in the fulltext_values table.
+func (s storage) external() bool {
+	return s.codec != "" || s.searchText || s.timestamps || s.boosts || len(s.metadata) > 0 || s.infix
+}
+
+// value returns the SQL expression that reads the (decompressed) value in the input column.
+func (s storage) value(column string) string {
+	if s.codec == "" {
+		return column
+	}
+
+	return fmt.Sprintf("%s('%s', %s)", decompressFunc, s.codec, column)
+}
+
+// stored returns the SQL expression that writes the input value parameter in the fulltext_values table.
+func (s storage) stored(param string) string {
+	if s.codec == "" {
+		return param
+	}
+
+	return fmt.Sprintf("%s('%s', %s)", compressFunc, s.codec, param)
+}
+
+// indexed returns the SQL expression for the text that is indexed for the input row, in the triggers on the
+// fulltext_values table.
+func (s storage) indexed(row string) string {
+	if s.searchText {
+		return row + ".text"
+	}
+
+	return s.value(row + ".val")
+}
+
+// createExternalTables creates the schema of an Index with external content: the values are stored (optionally
+// compressed) in the fulltext_values table, alongside their search text if it is derived from them; and read through
+// the fulltext_content view, which is the external content table of the fulltext_search table. Triggers on the
+// fulltext_values table keep the full-text index in sync with it, indexing either the value or its search text; as
+// well as the trigram index of the fulltext_infix table, if configured for infix searches.
+func createExternalTables(ctx context.Context, db *sql.DB, tok tokenizer, store storage) error {
+	var columns string
+	if store.searchText {
+		columns += ",\n\ttext TEXT"
+	}
+
+	if store.timestamps {
+		columns += insertedColumn
+	}
+
+	if store.boosts {
+		columns += boostColumn
+	}
+
+	for _, name := range store.metadata {
+		columns += ",\n\t" + metadataColumnPrefix + name
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	queries := []string{
+		fmt.Sprintf(createValuesTableQuery, columns),
+		fmt.Sprintf(createContentViewQuery, store.value("val")),
+		fmt.Sprintf(createExternalTableQuery, tok.spec()),
+		fmt.Sprintf(createInsertTriggerQuery, store.indexed("new")),
+		fmt.Sprintf(createDeleteTriggerQuery, store.indexed("old")),
+		fmt.Sprintf(createUpdateTriggerQuery, store.indexed("old"), store.indexed("new")),
+	}
+
+	if store.infix {
+		queries = append(queries, infixTableQueries(store)...)
+	}
+
+	for _, query := range queries {
+		if _, err = tx.ExecContext(ctx, query); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+	}
+
+	return tx.Commit()
+}
+
+// checkStorage verifies that the storage of an existing database matches the input storage, returning an
+// ErrInvalidCompression error if its value compression differs, an ErrInvalidExtractor error if it differs in having
+// a search text column, an ErrInvalidTimestamps error if it differs in recording insertion times, an ErrInvalidBoosts
+// error if it differs in recording ranking boosts, an ErrInvalidMetadata error if its metadata columns differ, or an
+// ErrInvalidInfix error if it differs in having an infix index.
+func checkStorage(ctx context.Context, db *sql.DB, store storage) error {
+	var external, searchText, timestamps, boosts bool
+	if err := db.QueryRowContext(ctx, checkValuesTableExists).Scan(&external); err != nil {
+		return err
+	}
+
+	var viewSQL string
+
+	if external {
+		if err := db.QueryRowContext(ctx, checkSearchTextColumnExists).Scan(&searchText); err != nil {
+			return err
+		}
+
+		if err := db.QueryRowContext(ctx, checkInsertedColumnExists).Scan(&timestamps); err != nil {
+			return err
+		}
+
+		if err := db.QueryRowContext(ctx, checkBoostColumnExists).Scan(&boosts); err != nil {
+			return err
+		}
+
+		if err := db.QueryRowContext(ctx, contentViewSQLQuery).Scan(&viewSQL); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case searchText && !store.searchText:
+		return fmt.Errorf("%w: database has a search text column, configured without an extractor", ErrInvalidExtractor)
+	case !searchText && store.searchText:
+		return fmt.Errorf("%w: database has no search text column, configured with an extractor", ErrInvalidExtractor)
+	}
+
+	switch {
+	case timestamps && !store.timestamps:
+		return fmt.Errorf("%w: database records insertion times, configured without them", ErrInvalidTimestamps)
+	case !timestamps && store.timestamps:
+		return fmt.Errorf("%w: database does not record insertion times, configured with them", ErrInvalidTimestamps)
+	}
+
+	switch {
+	case boosts && !store.boosts:
+		return fmt.Errorf("%w: database records ranking boosts, configured without them", ErrInvalidBoosts)
+	case !boosts && store.boosts:
+		return fmt.Errorf("%w: database does not record ranking boosts, configured with them", ErrInvalidBoosts)
+	}
+
+	if err := checkMetadata(ctx, db, external, store.metadata); err != nil {
+		return err
+	}
+
+	if err := checkInfix(ctx, db, store.infix); err != nil {
+		return err
+	}
+
+	compressed := strings.Contains(viewSQL, decompressFunc+"(")
+
+	switch {
+	case !compressed && store.codec == "":
+		return nil
+	case !compressed:
+		return fmt.Errorf("%w: database has no value compression, configured with %q", ErrInvalidCompression, store.codec)
+	case store.codec == "":
+		return fmt.Errorf("%w: database has value compression, configured without it", ErrInvalidCompression)
+	case !strings.Contains(viewSQL, fmt.Sprintf("%s('%s'", decompressFunc, store.codec)):
+		return fmt.Errorf("%w: database is compressed with a different codec than %q", ErrInvalidCompression, store.codec)
+	default:
+		return nil
+	}
+}
+
+// insertColumns returns the columns set when inserting a value in the fulltext_values table besides its key and value,
+// and their parameters: its search text and its insertion time, if stored; in the order of the Index's insertArgs.
+func (s storage) insertColumns() (columns, params string) {
+	if s.searchText {
+		columns, params = columns+", text", params+", ?"
+	}
+
+	if s.timestamps {
+		columns, params = columns+", inserted", params+", ?"
+	}
+
+	return columns, params
+}
+
+func insertQueryFor(store storage) string {
+	if !store.external() {
+		return insertValueQuery
+	}
+
+	columns, params := store.insertColumns()
+
+	return fmt.Sprintf(insertExternalQuery, columns, store.stored("?"), params)
+}
+
+// insertBoostedQueryFor returns the query inserting a value alongside its ranking boost, in the fulltext_values table.
+func insertBoostedQueryFor(store storage) string {
+	columns, params := store.insertColumns()
+
+	return fmt.Sprintf(insertExternalQuery, columns+", boost", store.stored("?"), params+", ?")
+}
+
+func updateIfExternalQueryFor(keyCollation string, store storage) string {
+	var setText string
+	if store.searchText {
+		setText = ", text = ?4"
+	}
+
+	if keyCollation != "" {
+		return fmt.Sprintf(updateIfExternalWithCollationQuery, store.stored("?1"), setText, store.value("val"), keyCollation)
+	}
+
+	return fmt.Sprintf(updateIfExternalQuery, store.stored("?1"), setText, store.value("val"))
+}
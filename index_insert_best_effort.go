@@ -0,0 +1,85 @@
+package fts
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	savepointQuery         = `SAVEPOINT insert_attribute;`
+	releaseSavepointQuery  = `RELEASE SAVEPOINT insert_attribute;`
+	rollbackSavepointQuery = `ROLLBACK TO SAVEPOINT insert_attribute;`
+)
+
+// InsertBestEffort indexes new attributes in the Index, like Insert; but instead of failing the whole insert when an
+// attribute cannot be inserted, it skips that attribute and carries on with the rest. This is useful when loading
+// imperfect data, where skipping bad rows is preferred over failing the whole load.
+//
+// All attributes are inserted in a single database transaction, where each attribute is inserted within its own
+// savepoint, so that a failure only rolls back that attribute. It returns the number of inserted attributes, and the
+// errors for the attributes that were skipped, by their index in the input slice. Attributes with a NULL value (an
+// invalid sql.Null* type) fail with an ErrNullValue error; unless the Index is configured with WithSkipNullValues, in
+// which case they are skipped without an error.
+//
+// This call returns an error (and no inserted attributes) if the transaction cannot be opened or committed, or if the
+// context is canceled while the transaction is open, in which case the transaction is rolled back.
+//
+// If the Index is configured with a write queue, the call is enqueued and blocks until it is processed.
+func (i *Index[K, V]) InsertBestEffort(
+	ctx context.Context, attrs []Attribute[K, V],
+) (inserted int, failures map[int]error, err error) {
+	err = i.write(ctx, func(ctx context.Context) error {
+		inserted, failures = 0, make(map[int]error)
+		keys := make([]K, 0, len(attrs))
+
+		i.mu.RLock()
+		defer i.mu.RUnlock()
+
+		tx, err := i.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		for idx := range attrs {
+			if err = ctx.Err(); err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+
+			if skip, nullErr := i.checkNullValue(attrs[idx]); skip || nullErr != nil {
+				if nullErr != nil {
+					failures[idx] = nullErr
+				}
+
+				continue
+			}
+
+			if _, err = tx.ExecContext(ctx, savepointQuery); err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+
+			if _, err = tx.ExecContext(ctx, i.insertQuery, i.insertArgs(attrs[idx])...); err != nil {
+				failures[idx] = err
+
+				if _, err = tx.ExecContext(ctx, rollbackSavepointQuery); err != nil {
+					return errors.Join(err, rollback(tx))
+				}
+			} else {
+				inserted++
+				keys = append(keys, attrs[idx].Key)
+			}
+
+			if _, err = tx.ExecContext(ctx, releaseSavepointQuery); err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+		}
+
+		return i.changes.commit(tx, func() ChangeEvent[K] {
+			return ChangeEvent[K]{Op: ChangeInsert, Keys: keys}
+		})
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return inserted, failures, nil
+}
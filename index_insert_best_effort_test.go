@@ -0,0 +1,105 @@
+package fts
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+var errRejectedValue = errors.New("rejected value")
+
+// rejectingCodec is a Codec that fails to compress any value containing "bad", simulating attributes that cannot be
+// inserted.
+type rejectingCodec struct {
+	Codec
+}
+
+func (rejectingCodec) Name() string { return "rejecting" }
+
+func (c rejectingCodec) Compress(data []byte) ([]byte, error) {
+	if strings.Contains(string(data), "bad") {
+		return nil, errRejectedValue
+	}
+
+	return c.Codec.Compress(data)
+}
+
+func TestIndex_InsertBestEffort(t *testing.T) {
+	for _, testcase := range []struct {
+		name     string
+		attrs    []Attribute[int, string]
+		inserted int
+		failed   []int
+		wants    []Attribute[int, string]
+	}{
+		{
+			name: "Success/AllGood",
+			attrs: []Attribute[int, string]{
+				{Key: 1, Value: "some data"},
+				{Key: 2, Value: "struck gold"},
+			},
+			inserted: 2,
+			wants: []Attribute[int, string]{
+				{Key: 1, Value: "some data"},
+				{Key: 2, Value: "struck gold"},
+			},
+		},
+		{
+			name: "Success/Mixed",
+			attrs: []Attribute[int, string]{
+				{Key: 1, Value: "some data"},
+				{Key: 2, Value: "bad data"},
+				{Key: 3, Value: "struck gold"},
+				{Key: 4, Value: "another bad one"},
+				{Key: 5, Value: "probably bronze"},
+			},
+			inserted: 3,
+			failed:   []int{1, 3},
+			wants: []Attribute[int, string]{
+				{Key: 1, Value: "some data"},
+				{Key: 3, Value: "struck gold"},
+				{Key: 5, Value: "probably bronze"},
+			},
+		},
+		{
+			name: "Success/AllBad",
+			attrs: []Attribute[int, string]{
+				{Key: 1, Value: "bad"},
+			},
+			failed: []int{0},
+			wants:  []Attribute[int, string]{},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New(
+				WithURI(filepath.Join(t.TempDir(), "index.db")),
+				WithValueCompression(rejectingCodec{GzipCodec()}),
+			))
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			inserted, failures, err := index.InsertBestEffort(ctx, testcase.attrs)
+			require.NoError(t, err)
+			require.Equal(t, testcase.inserted, inserted)
+			require.Len(t, failures, len(testcase.failed))
+
+			for _, idx := range testcase.failed {
+				require.ErrorContains(t, failures[idx], errRejectedValue.Error())
+			}
+
+			res, err := index.List(ctx, OrderSequence)
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+)
+
+// lazyConnector is a driver.Connector that opens and initializes the Index's database (creating its file and tables,
+// if needed) on its first connection, rather than when the Index is created. The initialization runs once, even with
+// concurrent connections; and its error, if any, is returned on every connection attempt.
+type lazyConnector struct {
+	driver driver.Driver
+	dsn    string
+
+	once sync.Once
+	init func() error
+	err  error
+}
+
+// Connect initializes the database if this is the first connection, and opens a new connection to it.
+func (c *lazyConnector) Connect(context.Context) (driver.Conn, error) {
+	c.once.Do(func() {
+		c.err = c.init()
+	})
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return c.driver.Open(c.dsn)
+}
+
+// Driver returns the SQLite driver.
+func (c *lazyConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// openLazy returns a connection pool for the Index's database that only opens and initializes the database on its
+// first connection (see WithLazyOpen). The initialization goes through openDatabase, with its own connection pool, so
+// it also recovers from corruption if configured to.
+func openLazy(config Config, tok tokenizer, store storage) (*sql.DB, error) {
+	// sql.Open doesn't connect to the database, which is only used to retrieve the registered SQLite driver.
+	probe, err := sql.Open("sqlite", "")
+	if err != nil {
+		return nil, err
+	}
+
+	drv := probe.Driver()
+
+	if err = probe.Close(); err != nil {
+		return nil, err
+	}
+
+	db := sql.OpenDB(&lazyConnector{
+		driver: drv,
+		dsn:    dataSourceName(config.uri, config.rawDSN, config.memoryName, config.cacheMode, config.pragmas),
+		init: func() error {
+			db, err := openDatabase(config, tok, store)
+			if err != nil {
+				return err
+			}
+
+			return db.Close()
+		},
+	})
+
+	setPoolLimits(db, config.uri, config.cacheMode, config.maxOpenConns)
+
+	return db, nil
+}
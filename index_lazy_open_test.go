@@ -0,0 +1,105 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_LazyOpen(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		first func(ctx context.Context, index *Index[int, string]) error
+		err   error
+	}{
+		{
+			name: "Insert",
+			first: func(ctx context.Context, index *Index[int, string]) error {
+				return index.Insert(ctx, Attribute[int, string]{Key: 1, Value: "gold"})
+			},
+		},
+		{
+			name: "Search",
+			first: func(ctx context.Context, index *Index[int, string]) error {
+				_, err := index.Search(ctx, "gold")
+
+				return err
+			},
+			err: ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			uri := filepath.Join(t.TempDir(), "index.db")
+
+			index, err := newIndex[int, string](cfg.New(WithURI(uri), WithLazyOpen()))
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			require.NoFileExists(t, uri)
+
+			err = testcase.first(ctx, index)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.FileExists(t, uri)
+
+			require.NoError(t, index.Insert(ctx, Attribute[int, string]{Key: 2, Value: "silver"}))
+
+			res, err := index.Search(ctx, "silver")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{{Key: 2, Value: "silver"}}, res)
+		})
+	}
+}
+
+func TestIndex_LazyOpenConcurrent(t *testing.T) {
+	const numWorkers = 8
+
+	ctx := context.Background()
+
+	index, err := newIndex[int, string](cfg.New(
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithLazyOpen(),
+	))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, numWorkers)
+
+	for n := 0; n < numWorkers; n++ {
+		wg.Add(1)
+
+		go func(key int) {
+			defer wg.Done()
+
+			errs <- index.Insert(ctx, Attribute[int, string]{Key: key, Value: "gold"})
+		}(n)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	res, err := index.Search(ctx, "gold")
+	require.NoError(t, err)
+	require.Len(t, res, numWorkers)
+}
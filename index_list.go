@@ -0,0 +1,45 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	listQuery = `
+SELECT id, val FROM fulltext_search;
+`
+
+	listBySequenceQuery = `
+SELECT id, val FROM fulltext_search
+	ORDER BY rowid;
+`
+)
+
+// List returns all the Attribute in the Index, sorted by the input Order.
+//
+// OrderSequence returns the attributes in insertion order; while OrderNone returns them in the order they are yielded
+// by SQLite, which is not guaranteed to be stable. Since there is no search term to rank the attributes by, OrderRank
+// is handled as OrderNone.
+//
+// This call returns an error if the underlying SQL query fails, or if scanning for the results fails. An empty Index
+// yields an empty slice of attributes. If the Index is configured with a maximum number of results and it holds more
+// than that, the capped results are returned alongside an ErrResultTruncated error.
+func (i *Index[K, V]) List(ctx context.Context, order Order) ([]Attribute[K, V], error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	query := listQuery
+	if order == OrderSequence {
+		query = listBySequenceQuery
+	}
+
+	rows, err := i.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr Attribute[K, V], err error) {
+		return attr, rows.Scan(&attr.Key, &attr.Value)
+	})
+}
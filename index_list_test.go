@@ -0,0 +1,95 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_List(t *testing.T) {
+	for _, testcase := range []struct {
+		name    string
+		inserts [][]Attribute[int, string]
+		deletes []int
+		after   []Attribute[int, string]
+		wants   []Attribute[int, string]
+	}{
+		{
+			name:  "Success/Empty",
+			wants: []Attribute[int, string]{},
+		},
+		{
+			name: "Success/InsertionOrder",
+			inserts: [][]Attribute[int, string]{
+				{{Key: 30, Value: "third key first"}, {Key: 10, Value: "first key second"}},
+				{{Key: 20, Value: "second key third"}},
+			},
+			wants: []Attribute[int, string]{
+				{Key: 30, Value: "third key first"},
+				{Key: 10, Value: "first key second"},
+				{Key: 20, Value: "second key third"},
+			},
+		},
+		{
+			name: "Success/AfterDeletingTheLast",
+			inserts: [][]Attribute[int, string]{
+				{{Key: 1, Value: "a"}, {Key: 2, Value: "b"}, {Key: 3, Value: "c"}},
+			},
+			deletes: []int{3},
+			after:   []Attribute[int, string]{{Key: 4, Value: "d"}},
+			wants: []Attribute[int, string]{
+				{Key: 1, Value: "a"},
+				{Key: 2, Value: "b"},
+				{Key: 4, Value: "d"},
+			},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex[int, string](filepath.Join(t.TempDir(), "index.db"))
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			for _, attrs := range testcase.inserts {
+				require.NoError(t, index.Insert(ctx, attrs...))
+			}
+
+			if len(testcase.deletes) > 0 {
+				require.NoError(t, index.Delete(ctx, testcase.deletes...))
+			}
+
+			if len(testcase.after) > 0 {
+				require.NoError(t, index.Insert(ctx, testcase.after...))
+			}
+
+			res, err := index.List(ctx, OrderSequence)
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestIndex_SearchWithOpts_OrderSequence(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"),
+		Attribute[int, string]{Key: 3, Value: "gold gold gold"},
+		Attribute[int, string]{Key: 1, Value: "struck gold"},
+		Attribute[int, string]{Key: 2, Value: "gold"},
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	res, err := index.SearchWithOpts(ctx, "gold", SearchOpts{Order: OrderSequence})
+	require.NoError(t, err)
+	require.Equal(t, []Attribute[int, string]{{Key: 3}, {Key: 1}, {Key: 2}}, res)
+}
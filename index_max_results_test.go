@@ -0,0 +1,60 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_MaxResults(t *testing.T) {
+	const (
+		numAttrs   = 1000
+		maxResults = 100
+	)
+
+	ctx := context.Background()
+
+	attrs := make([]Attribute[int, string], 0, numAttrs)
+	for i := 0; i < numAttrs; i++ {
+		attrs = append(attrs, Attribute[int, string]{Key: i, Value: fmt.Sprintf("gold entry %d", i)})
+	}
+
+	index, err := newIndex[int, string](cfg.New[Config](
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithMaxResults(maxResults),
+	), attrs...)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	t.Run("Search/Truncated", func(t *testing.T) {
+		res, err := index.Search(ctx, "gold")
+		require.ErrorIs(t, err, ErrResultTruncated)
+		require.Len(t, res, maxResults)
+	})
+
+	t.Run("Search/BelowCap", func(t *testing.T) {
+		res, err := index.SearchTop(ctx, "gold", maxResults)
+		require.NoError(t, err)
+		require.Len(t, res, maxResults)
+	})
+
+	t.Run("SearchWithTotal/Truncated", func(t *testing.T) {
+		res, total, err := index.SearchWithTotal(ctx, "gold", 0, 0)
+		require.ErrorIs(t, err, ErrResultTruncated)
+		require.Len(t, res, maxResults)
+		require.Equal(t, numAttrs, total)
+	})
+
+	t.Run("SearchExplainScores/Truncated", func(t *testing.T) {
+		res, err := index.SearchExplainScores(ctx, "gold")
+		require.ErrorIs(t, err, ErrResultTruncated)
+		require.Len(t, res, maxResults)
+	})
+}
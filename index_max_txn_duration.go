@@ -0,0 +1,25 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+)
+
+// splitTx commits the input transaction of an insert that has been open for the Index's maximum transaction duration
+// (see WithMaxTxnDuration), publishing the keys inserted in it; and begins a new transaction for the rest of the
+// insert.
+func (i *Index[K, V]) splitTx(ctx context.Context, tx *sql.Tx, keys []K) (*sql.Tx, error) {
+	var event func() ChangeEvent[K]
+
+	if len(keys) > 0 {
+		event = func() ChangeEvent[K] {
+			return ChangeEvent[K]{Op: ChangeInsert, Keys: keys}
+		}
+	}
+
+	if err := i.changes.commit(tx, event); err != nil {
+		return nil, err
+	}
+
+	return i.db.BeginTx(ctx, nil)
+}
@@ -0,0 +1,93 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+// slowCodec is a Codec that takes its delay to compress each value, simulating slow inserts.
+type slowCodec struct {
+	Codec
+
+	delay time.Duration
+}
+
+func (slowCodec) Name() string { return "slow" }
+
+func (c slowCodec) Compress(data []byte) ([]byte, error) {
+	time.Sleep(c.delay)
+
+	return c.Codec.Compress(data)
+}
+
+func TestIndex_MaxTxnDuration(t *testing.T) {
+	const delay = 10 * time.Millisecond
+
+	attrs := make([]Attribute[int, string], 0, 6)
+	wants := make([]int, 0, cap(attrs))
+
+	for i := 0; i < cap(attrs); i++ {
+		attrs = append(attrs, Attribute[int, string]{Key: i, Value: "gold"})
+		wants = append(wants, i)
+	}
+
+	for _, testcase := range []struct {
+		name      string
+		opts      []cfg.Option[Config]
+		minEvents int
+		maxEvents int
+	}{
+		{
+			name:      "Split",
+			opts:      []cfg.Option[Config]{WithMaxTxnDuration(2 * delay)},
+			minEvents: 2,
+			maxEvents: len(attrs),
+		},
+		{
+			name:      "NotSplit",
+			minEvents: 1,
+			maxEvents: 1,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New(append(testcase.opts,
+				WithURI(filepath.Join(t.TempDir(), "index.db")),
+				WithValueCompression(slowCodec{Codec: GzipCodec(), delay: delay}),
+			)...))
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			events, unsubscribe := index.Subscribe(ctx)
+
+			require.NoError(t, index.Insert(ctx, attrs...))
+			unsubscribe()
+
+			// each committed transaction emits its own event
+			keys := make([]int, 0, len(attrs))
+			n := 0
+
+			for event := range events {
+				keys = append(keys, event.Keys...)
+				n++
+			}
+
+			require.GreaterOrEqual(t, n, testcase.minEvents)
+			require.LessOrEqual(t, n, testcase.maxEvents)
+			require.Equal(t, wants, keys)
+
+			res, err := index.Search(ctx, "gold")
+			require.NoError(t, err)
+			require.Len(t, res, len(attrs))
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// metadataColumnPrefix prefixes the names of the metadata columns in the fulltext_values table, so that they never
+	// clash with its other columns.
+	metadataColumnPrefix = "meta_"
+
+	metadataColumnsQuery = `
+SELECT substr(name, 6) FROM pragma_table_info('fulltext_values')
+	WHERE name LIKE 'meta\_%' ESCAPE '\'
+	ORDER BY cid;
+`
+
+	searchMetadataQuery = `
+SELECT fulltext_search.id, fulltext_search.val%s FROM fulltext_search
+	JOIN fulltext_values ON fulltext_values.seq = fulltext_search.rowid
+	WHERE fulltext_search MATCH ?;
+`
+)
+
+// metadataColumnName matches the valid names of metadata columns (see WithMetadataColumns).
+var metadataColumnName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// MetadataAttribute is an Attribute stored alongside its metadata (see InsertWithMetadata and SearchWithMetadata).
+type MetadataAttribute[K SQLType, V SQLType] struct {
+	Attribute[K, V]
+
+	// Metadata maps the names of the Index's metadata columns to their values for this attribute. Columns without a
+	// value are omitted. Values are read back as stored by SQLite: integers as int64, floats as float64, text as
+	// string and blobs as []byte.
+	Metadata map[string]any
+}
+
+// MetadataOrder sorts the results of a search by one of the Index's metadata columns, as set in SearchOpts.OrderBy.
+type MetadataOrder struct {
+	// Column is the name of the metadata column to sort the results by, as set in WithMetadataColumns.
+	Column string
+	// Desc sorts the results in descending order, instead of ascending.
+	Desc bool
+}
+
+// OrderByMetadata returns a MetadataOrder sorting the results of a search by the input metadata column, in descending
+// order if desc is true, to be set in SearchOpts.OrderBy. Results without a value in the column are sorted as NULL
+// values by SQLite (first in ascending order, last in descending order), and ties are sorted in insertion order.
+//
+// The column must be one of the Index's metadata columns (see WithMetadataColumns); otherwise the search fails with an
+// ErrInvalidMetadata error.
+func OrderByMetadata(column string, desc bool) *MetadataOrder {
+	return &MetadataOrder{Column: column, Desc: desc}
+}
+
+// clause returns the ORDER BY clause sorting the results of a search by the MetadataOrder's column, which is expected
+// to be validated as one of the Index's metadata columns.
+func (o *MetadataOrder) clause() string {
+	direction := "ASC"
+	if o.Desc {
+		direction = "DESC"
+	}
+
+	return fmt.Sprintf(" ORDER BY (SELECT %s%s FROM fulltext_values WHERE seq = fulltext_search.rowid) %s, rowid",
+		metadataColumnPrefix, o.Column, direction)
+}
+
+// InsertWithMetadata indexes new attributes in the Index, like Insert, storing each attribute's metadata alongside it.
+// The metadata is not indexed, so it never affects which attributes match a search; it is only returned with the
+// results of SearchWithMetadata.
+//
+// The Index must be configured with WithMetadataColumns, and the keys in each attribute's metadata must be among its
+// column names. The metadata of an attribute is kept when its value is updated (e.g. with UpdateIf). Attributes with a
+// NULL value are rejected or skipped, as in Insert.
+//
+// This call returns an ErrInvalidMetadata error if the Index does not store metadata, or if an attribute has metadata
+// for an unknown column. If the context is canceled while the transaction is open, it is rolled back and the
+// context's error is returned; so that none of the attributes are committed. If the Index is configured with a write
+// queue, the call is enqueued and blocks until it is processed.
+func (i *Index[K, V]) InsertWithMetadata(ctx context.Context, attrs ...MetadataAttribute[K, V]) error {
+	if len(i.store.metadata) == 0 {
+		return fmt.Errorf("%w: the Index is not configured with WithMetadataColumns", ErrInvalidMetadata)
+	}
+
+	for idx := range attrs {
+		for name := range attrs[idx].Metadata {
+			if !slices.Contains(i.store.metadata, name) {
+				return fmt.Errorf("%w: unknown column %q for key %v", ErrInvalidMetadata, name, attrs[idx].Key)
+			}
+		}
+	}
+
+	query := insertMetadataQueryFor(i.store)
+
+	return i.write(ctx, func(ctx context.Context) error {
+		i.mu.RLock()
+		defer i.mu.RUnlock()
+
+		tx, err := i.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		keys := make([]K, 0, len(attrs))
+
+		for idx := range attrs {
+			if err = ctx.Err(); err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+
+			skip, err := i.checkNullValue(attrs[idx].Attribute)
+			if err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+
+			if skip {
+				continue
+			}
+
+			args := i.insertArgs(attrs[idx].Attribute)
+			for _, name := range i.store.metadata {
+				args = append(args, attrs[idx].Metadata[name])
+			}
+
+			if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+
+			keys = append(keys, attrs[idx].Key)
+		}
+
+		return i.changes.commit(tx, func() ChangeEvent[K] {
+			return ChangeEvent[K]{Op: ChangeInsert, Keys: keys}
+		})
+	})
+}
+
+// SearchWithMetadata will look for matches for the input value through the indexed terms, returning a collection of
+// matching MetadataAttribute, with both key and (full) value, and the metadata stored for each match. The metadata is
+// read from the side table within the same query, and is never matched against the search term.
+//
+// The Index must be configured with WithMetadataColumns. As with Search, the search term is checked, rewritten and
+// validated as configured in the Index; however, the Index's result transformers do not apply to these results.
+//
+// This call returns an ErrInvalidMetadata error if the Index does not store metadata, an error if the query is too
+// short or invalid, if the underlying SQL query fails, if scanning for the results fails, or an ErrNotFoundKeyword
+// error if there are zero results from the query. If the Index is configured with a maximum number of results and the
+// search yields more than that, the capped results are returned alongside an ErrResultTruncated error.
+func (i *Index[K, V]) SearchWithMetadata(ctx context.Context, searchTerm V) ([]MetadataAttribute[K, V], error) {
+	if len(i.store.metadata) == 0 {
+		return nil, fmt.Errorf("%w: the Index is not configured with WithMetadataColumns", ErrInvalidMetadata)
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	searchTerm, err := i.prepareQuery(ctx, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns strings.Builder
+	for _, name := range i.store.metadata {
+		columns.WriteString(", fulltext_values." + metadataColumnPrefix + name)
+	}
+
+	rows, err := i.db.QueryContext(ctx, fmt.Sprintf(searchMetadataQuery, columns.String()), searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr MetadataAttribute[K, V], err error) {
+		values := make([]any, len(i.store.metadata))
+		dest := make([]any, 0, len(values)+2)
+		dest = append(dest, &attr.Key, &attr.Value)
+
+		for idx := range values {
+			dest = append(dest, &values[idx])
+		}
+
+		if err = rows.Scan(dest...); err != nil {
+			return attr, err
+		}
+
+		attr.Metadata = make(map[string]any, len(values))
+
+		for idx := range values {
+			if values[idx] != nil {
+				attr.Metadata[i.store.metadata[idx]] = values[idx]
+			}
+		}
+
+		return attr, nil
+	})
+	if err != nil && !errors.Is(err, ErrResultTruncated) {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	return res, err
+}
+
+// insertMetadataQueryFor returns the query inserting a value alongside its metadata, in the fulltext_values table.
+func insertMetadataQueryFor(store storage) string {
+	columns, params := store.insertColumns()
+
+	for _, name := range store.metadata {
+		columns += ", " + metadataColumnPrefix + name
+		params += ", ?"
+	}
+
+	return fmt.Sprintf(insertExternalQuery, columns, store.stored("?"), params)
+}
+
+// checkMetadata verifies that the metadata columns of an existing database match the input names, regardless of their
+// order, returning an ErrInvalidMetadata error if they differ. A database without a fulltext_values table has no
+// metadata columns.
+func checkMetadata(ctx context.Context, db *sql.DB, external bool, names []string) error {
+	var columns []string
+
+	if external {
+		rows, err := db.QueryContext(ctx, metadataColumnsQuery)
+		if err != nil {
+			return err
+		}
+
+		if columns, err = scanRows(rows, 0, func(rows *sql.Rows) (name string, err error) {
+			return name, rows.Scan(&name)
+		}); err != nil {
+			return err
+		}
+	}
+
+	configured := slices.Clone(names)
+
+	slices.Sort(columns)
+	slices.Sort(configured)
+
+	if !slices.Equal(columns, configured) {
+		return fmt.Errorf("%w: database has metadata columns %v, configured with %v", ErrInvalidMetadata, columns, configured)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_SearchWithMetadata(t *testing.T) {
+	attrs := []MetadataAttribute[int, string]{
+		{
+			Attribute: Attribute[int, string]{Key: 1, Value: "gold rush"},
+			Metadata:  map[string]any{"author": "alice", "url": "https://example.com/gold-rush"},
+		},
+		{
+			Attribute: Attribute[int, string]{Key: 2, Value: "struck gold"},
+			Metadata:  map[string]any{"author": "bob"},
+		},
+		{
+			Attribute: Attribute[int, string]{Key: 3, Value: "silver lining"},
+			Metadata:  map[string]any{"author": "alice"},
+		},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		query string
+		wants []MetadataAttribute[int, string]
+		err   error
+	}{
+		{
+			name:  "Success/WithMetadata",
+			query: "gold",
+			wants: attrs[:2],
+		},
+		{
+			name:  "Success/WithoutMetadata",
+			query: "copper",
+			wants: []MetadataAttribute[int, string]{{
+				Attribute: Attribute[int, string]{Key: 4, Value: "copper wire"},
+				Metadata:  map[string]any{},
+			}},
+		},
+		{
+			name:  "Fail/MetadataIsNotMatched",
+			query: "alice",
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New(
+				WithURI(filepath.Join(t.TempDir(), "index.db")),
+				WithMetadataColumns("author", "url"),
+			), Attribute[int, string]{Key: 4, Value: "copper wire"})
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			require.NoError(t, index.InsertWithMetadata(ctx, attrs...))
+
+			res, err := index.SearchWithMetadata(ctx, testcase.query)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.ElementsMatch(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestIndex_InsertWithMetadataInvalid(t *testing.T) {
+	ctx := context.Background()
+	uri := filepath.Join(t.TempDir(), "index.db")
+
+	index, err := newIndex[int, string](cfg.New(WithURI(uri), WithMetadataColumns("author")))
+	require.NoError(t, err)
+
+	err = index.InsertWithMetadata(ctx, MetadataAttribute[int, string]{
+		Attribute: Attribute[int, string]{Key: 1, Value: "gold"},
+		Metadata:  map[string]any{"publisher": "carol"},
+	})
+	require.ErrorIs(t, err, ErrInvalidMetadata)
+	require.NoError(t, index.Shutdown(ctx))
+
+	// metadata columns are part of the schema
+	_, err = newIndex[int, string](cfg.New(WithURI(uri), WithMetadataColumns("author", "url")))
+	require.ErrorIs(t, err, ErrInvalidMetadata)
+
+	_, err = NewIndex[int, string](uri)
+	require.ErrorIs(t, err, ErrInvalidMetadata)
+
+	plain, err := NewIndex[int, string](filepath.Join(t.TempDir(), "plain.db"))
+	require.NoError(t, err)
+
+	err = plain.InsertWithMetadata(ctx, MetadataAttribute[int, string]{Attribute: Attribute[int, string]{Key: 1}})
+	require.ErrorIs(t, err, ErrInvalidMetadata)
+	require.NoError(t, plain.Shutdown(ctx))
+}
+
+func TestIndex_SearchOrderByMetadata(t *testing.T) {
+	attrs := []MetadataAttribute[int, string]{
+		{
+			Attribute: Attribute[int, string]{Key: 1, Value: "gold rush"},
+			Metadata:  map[string]any{"priority": 2},
+		},
+		{
+			Attribute: Attribute[int, string]{Key: 2, Value: "struck gold"},
+			Metadata:  map[string]any{"priority": 10},
+		},
+		{
+			Attribute: Attribute[int, string]{Key: 3, Value: "silver lining"},
+			Metadata:  map[string]any{"priority": 99},
+		},
+		{
+			Attribute: Attribute[int, string]{Key: 4, Value: "gold plated"},
+			Metadata:  map[string]any{"priority": 5},
+		},
+		{
+			Attribute: Attribute[int, string]{Key: 5, Value: "fool's gold"},
+		},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		order *MetadataOrder
+		wants []int
+		err   error
+	}{
+		{
+			name:  "Success/Descending",
+			order: OrderByMetadata("priority", true),
+			wants: []int{2, 4, 1, 5},
+		},
+		{
+			name:  "Success/Ascending",
+			order: OrderByMetadata("priority", false),
+			wants: []int{5, 1, 4, 2},
+		},
+		{
+			name:  "Fail/UnknownColumn",
+			order: OrderByMetadata("rank", true),
+			err:   ErrInvalidMetadata,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New(
+				WithURI(filepath.Join(t.TempDir(), "index.db")),
+				WithMetadataColumns("priority"),
+			))
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			require.NoError(t, index.InsertWithMetadata(ctx, attrs...))
+
+			res, err := index.SearchWithOpts(ctx, "gold", SearchOpts{OrderBy: testcase.order})
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			keys := make([]int, 0, len(res))
+			for i := range res {
+				keys = append(keys, res[i].Key)
+			}
+
+			require.Equal(t, testcase.wants, keys)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_MinQueryLength(t *testing.T) {
+	ctx := context.Background()
+
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold"},
+		{Key: 3, Value: "ouro dourado"},
+	}
+
+	index, err := newIndex[int, string](cfg.New(
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithMinQueryLength(4),
+	), attrs...)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	for _, testcase := range []struct {
+		name  string
+		query string
+		wants []Attribute[int, string]
+		err   error
+	}{
+		{
+			name:  "Fail/BelowThreshold",
+			query: "go*",
+			err:   ErrQueryTooShort,
+		},
+		{
+			name:  "Fail/BelowThresholdWithWhitespace",
+			query: "  go*  ",
+			err:   ErrQueryTooShort,
+		},
+		{
+			name:  "Fail/BelowThresholdInRunes",
+			query: "ou€",
+			err:   ErrQueryTooShort,
+		},
+		{
+			name:  "Success/AtThreshold",
+			query: "gold",
+			wants: []Attribute[int, string]{attrs[1]},
+		},
+		{
+			name:  "Success/AboveThreshold",
+			query: "dourado",
+			wants: []Attribute[int, string]{attrs[2]},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			res, err := index.Search(ctx, testcase.query)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package fts
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// isNullValue returns true if the input value is stored as NULL in the database; that is, if it is an invalid sql.Null*
+// type.
+func isNullValue[V SQLType](value V) bool {
+	valuer, ok := any(value).(driver.Valuer)
+	if !ok {
+		return false
+	}
+
+	v, err := valuer.Value()
+
+	return err == nil && v == nil
+}
+
+// checkNullValue checks whether the input attribute has a NULL value, returning true if it should be skipped (see
+// WithSkipNullValues), or an ErrNullValue error if it should be rejected.
+func (i *Index[K, V]) checkNullValue(attr Attribute[K, V]) (skip bool, err error) {
+	if !isNullValue(attr.Value) {
+		return false, nil
+	}
+
+	if i.skipNullValues {
+		return true, nil
+	}
+
+	return false, fmt.Errorf("%w: key %v", ErrNullValue, attr.Key)
+}
@@ -0,0 +1,97 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_InsertNullValues(t *testing.T) {
+	valid := Attribute[int, sql.NullString]{Key: 1, Value: sql.NullString{String: "struck gold", Valid: true}}
+	null := Attribute[int, sql.NullString]{Key: 2, Value: sql.NullString{}}
+
+	for _, testcase := range []struct {
+		name  string
+		opts  []cfg.Option[Config]
+		wants []Attribute[int, sql.NullString]
+		err   error
+	}{
+		{
+			name: "Default/Rejected",
+			err:  ErrNullValue,
+		},
+		{
+			name:  "WithSkipNullValues/Skipped",
+			opts:  []cfg.Option[Config]{WithSkipNullValues()},
+			wants: []Attribute[int, sql.NullString]{valid},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, sql.NullString](
+				cfg.New(append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...),
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			err = index.Insert(ctx, valid, null)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				// the whole insert is rolled back
+				res, err := index.List(ctx, OrderSequence)
+				require.NoError(t, err)
+				require.Empty(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			res, err := index.List(ctx, OrderSequence)
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestIndex_InsertNullValuesVariants(t *testing.T) {
+	ctx := context.Background()
+
+	valid := Attribute[int, sql.NullString]{Key: 1, Value: sql.NullString{String: "struck gold", Valid: true}}
+	null := Attribute[int, sql.NullString]{Key: 2, Value: sql.NullString{}}
+
+	index, err := newIndex[int, sql.NullString](cfg.New(WithURI(filepath.Join(t.TempDir(), "index.db"))))
+	require.NoError(t, err)
+
+	inserted, failures, err := index.InsertBestEffort(ctx, []Attribute[int, sql.NullString]{null, valid})
+	require.NoError(t, err)
+	require.Equal(t, 1, inserted)
+	require.Len(t, failures, 1)
+	require.ErrorIs(t, failures[0], ErrNullValue)
+	require.NoError(t, index.Shutdown(ctx))
+
+	skipping, err := newIndex[int, sql.NullString](cfg.New(
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithSkipNullValues(),
+	))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, skipping.Shutdown(ctx))
+	}()
+
+	rowIDs, err := skipping.InsertReturning(ctx, null, valid)
+	require.NoError(t, err)
+	require.Len(t, rowIDs, 2)
+	require.Zero(t, rowIDs[0])
+	require.NotZero(t, rowIDs[1])
+}
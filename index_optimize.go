@@ -0,0 +1,56 @@
+package fts
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	optimizeQuery = `INSERT INTO fulltext_search (fulltext_search) VALUES ('optimize');`
+	rebuildQuery  = `INSERT INTO fulltext_search (fulltext_search) VALUES ('rebuild');`
+)
+
+// Optimize merges all the segments of the Index's full-text index into a single one, which makes searches faster at
+// the cost of rewriting the whole index (see the 'optimize' command in the FTS5 reference document).
+//
+// This can take a long time on a large Index, as a single SQL statement. If the context is canceled (or its deadline
+// is exceeded) while the statement runs, the SQLite driver interrupts it (with sqlite3_interrupt), leaving the index
+// as it was before the call, and the context's error is returned.
+//
+// If the Index is configured with a write queue, the call is enqueued and blocks until it is processed.
+func (i *Index[K, V]) Optimize(ctx context.Context) error {
+	return i.write(ctx, func(ctx context.Context) error {
+		return i.execInterruptible(ctx, optimizeQuery)
+	})
+}
+
+// Rebuild discards the Index's full-text index and rebuilds it from the stored values (see the 'rebuild' command in
+// the FTS5 reference document), e.g. to recover from an inconsistent index.
+//
+// This can take a long time on a large Index, as a single SQL statement. If the context is canceled (or its deadline
+// is exceeded) while the statement runs, the SQLite driver interrupts it (with sqlite3_interrupt), leaving the index
+// as it was before the call, and the context's error is returned.
+//
+// If the Index is configured with a write queue, the call is enqueued and blocks until it is processed.
+func (i *Index[K, V]) Rebuild(ctx context.Context) error {
+	return i.write(ctx, func(ctx context.Context) error {
+		return i.execInterruptible(ctx, rebuildQuery)
+	})
+}
+
+// execInterruptible executes the input query, returning the context's error alongside the query's error if the query
+// failed because the context was done (as the driver then returns an interruption error).
+func (i *Index[K, V]) execInterruptible(ctx context.Context, query string) error {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if _, err := i.db.ExecContext(ctx, query); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return errors.Join(ctxErr, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Optimize(t *testing.T) {
+	for _, testcase := range []struct {
+		name string
+		fn   func(ctx context.Context, index *Index[int, string]) error
+	}{
+		{
+			name: "Optimize",
+			fn: func(ctx context.Context, index *Index[int, string]) error {
+				return index.Optimize(ctx)
+			},
+		},
+		{
+			name: "Rebuild",
+			fn: func(ctx context.Context, index *Index[int, string]) error {
+				return index.Rebuild(ctx)
+			},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex[int, string](filepath.Join(t.TempDir(), "index.db"))
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			// separate inserts create separate segments in the full-text index
+			for i := 0; i < 10; i++ {
+				require.NoError(t, index.Insert(ctx, Attribute[int, string]{Key: i, Value: fmt.Sprintf("gold %d", i)}))
+			}
+
+			require.NoError(t, testcase.fn(ctx, index))
+
+			res, err := index.Search(ctx, "gold")
+			require.NoError(t, err)
+			require.Len(t, res, 10)
+		})
+	}
+}
+
+func TestIndex_Optimize_Cancel(t *testing.T) {
+	const (
+		corpusSize  = 200_000
+		cancelAfter = 10 * time.Millisecond
+	)
+
+	if testing.Short() {
+		t.Skip("skipping large corpus test in short mode")
+	}
+
+	ctx := context.Background()
+
+	attrs := make([]Attribute[int, string], 0, corpusSize)
+	for i := 0; i < corpusSize; i++ {
+		attrs = append(attrs, Attribute[int, string]{
+			Key:   i,
+			Value: fmt.Sprintf("entry number %d with some gold and some filler text to tokenize", i),
+		})
+	}
+
+	index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	for _, testcase := range []struct {
+		name string
+		fn   func(ctx context.Context) error
+	}{
+		{name: "Optimize", fn: index.Optimize},
+		{name: "Rebuild", fn: index.Rebuild},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			cancelCtx, cancel := context.WithCancel(ctx)
+			timer := time.AfterFunc(cancelAfter, cancel)
+
+			defer timer.Stop()
+
+			start := time.Now()
+			err := testcase.fn(cancelCtx)
+			interrupted := time.Since(start)
+
+			require.ErrorIs(t, err, context.Canceled)
+
+			// the interrupted statement leaves the index as it was, so the full operation can run afterwards
+			res, err := index.SearchTop(ctx, "gold", 1)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+
+			start = time.Now()
+			require.NoError(t, testcase.fn(ctx))
+			full := time.Since(start)
+
+			require.Less(t, interrupted, full/2, "interrupted after %s, full run took %s", interrupted, full)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package fts
+
+import "context"
+
+const progressBatchSize = 1000
+
+// InsertWithProgress indexes new attributes in the Index, via the input Attribute's key and value content, reporting
+// its progress to the input function as it goes.
+//
+// The attributes are inserted in batches of up to 1000 items, each in its own database transaction. Once a batch is
+// committed, the progress function is called with the number of inserted attributes so far, and the total number of
+// attributes. Since it is called outside any transaction, the progress function can safely use the Index.
+//
+// Unlike Insert, this call is not atomic: if a batch fails, the previously committed batches are kept in the Index and
+// the error is returned without calling the progress function any further.
+func (i *Index[K, V]) InsertWithProgress(
+	ctx context.Context, attrs []Attribute[K, V], fn func(done, total int),
+) error {
+	total := len(attrs)
+
+	for start := 0; start < total; start += progressBatchSize {
+		end := min(start+progressBatchSize, total)
+
+		if err := i.Insert(ctx, attrs[start:end]...); err != nil {
+			return err
+		}
+
+		if fn != nil {
+			fn(end, total)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_InsertWithProgress(t *testing.T) {
+	const numAttrs = 2500
+
+	ctx := context.Background()
+
+	index, err := NewIndex[int, string](filepath.Join(t.TempDir(), "index.db"))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	attrs := make([]Attribute[int, string], 0, numAttrs)
+	for i := 0; i < numAttrs; i++ {
+		attrs = append(attrs, Attribute[int, string]{Key: i, Value: fmt.Sprintf("gold entry %d", i)})
+	}
+
+	var progress []int
+
+	require.NoError(t, index.InsertWithProgress(ctx, attrs, func(done, total int) {
+		require.Equal(t, numAttrs, total)
+
+		// the progress function is called outside the transaction, so it is safe to query the index
+		res, err := index.Search(ctx, "gold")
+		require.NoError(t, err)
+		require.Len(t, res, done)
+
+		progress = append(progress, done)
+	}))
+
+	require.Equal(t, []int{1000, 2000, 2500}, progress)
+}
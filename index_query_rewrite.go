@@ -0,0 +1,66 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+)
+
+// QueryRewriter transforms a search term before it is sent to FTS5, returning the rewritten term or an error if it
+// cannot be rewritten. It can be registered in an Index with the WithQueryRewriter option.
+type QueryRewriter func(ctx context.Context, query string) (string, error)
+
+// rewriteQuery applies the Index's query rewriters, in order, to the input search term. Search terms that are not of a
+// character type (string, []byte or []rune) are returned as-is.
+func (i *Index[K, V]) rewriteQuery(ctx context.Context, searchTerm V) (V, error) {
+	if len(i.queryRewriters) == 0 {
+		return searchTerm, nil
+	}
+
+	query, ok := charString(searchTerm)
+	if !ok {
+		return searchTerm, nil
+	}
+
+	for idx := range i.queryRewriters {
+		rewritten, err := i.queryRewriters[idx](ctx, query)
+		if err != nil {
+			return searchTerm, fmt.Errorf("rewriting query %q: %w", query, err)
+		}
+
+		query = rewritten
+	}
+
+	searchTerm, _ = charValue[V](query)
+
+	return searchTerm, nil
+}
+
+// charString returns the input value as a string, if it is of a character type (string, []byte or []rune).
+func charString[V SQLType](value V) (string, bool) {
+	switch v := any(value).(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	case []rune:
+		return string(v), true
+	default:
+		return "", false
+	}
+}
+
+// charValue returns the input string as a V, if V is a character type (string, []byte or []rune).
+func charValue[V SQLType](s string) (V, bool) {
+	var value V
+
+	switch any(value).(type) {
+	case string:
+		return any(s).(V), true
+	case []byte:
+		return any([]byte(s)).(V), true
+	case []rune:
+		return any([]rune(s)).(V), true
+	default:
+		return value, false
+	}
+}
@@ -0,0 +1,103 @@
+package fts
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_QueryRewriter(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold"},
+		{Key: 3, Value: "golden hour"},
+	}
+
+	errRewrite := errors.New("rewrite failed")
+
+	upper := func(_ context.Context, query string) (string, error) {
+		return strings.ToUpper(query), nil
+	}
+
+	prefix := func(_ context.Context, query string) (string, error) {
+		return query + "*", nil
+	}
+
+	for _, testcase := range []struct {
+		name      string
+		query     string
+		rewriters []QueryRewriter
+		wantQuery string
+		wants     []Attribute[int, string]
+		err       error
+	}{
+		{
+			name:      "Success/NoRewriters",
+			query:     "gold",
+			wantQuery: "gold",
+			wants: []Attribute[int, string]{
+				{Key: 2, Value: "struck gold"},
+			},
+		},
+		{
+			name:      "Success/AppliedInOrder",
+			query:     "gold",
+			rewriters: []QueryRewriter{upper, prefix},
+			wantQuery: "GOLD*",
+			wants: []Attribute[int, string]{
+				{Key: 2, Value: "struck gold"},
+				{Key: 3, Value: "golden hour"},
+			},
+		},
+		{
+			name:  "Fail/RewriterError",
+			query: "gold",
+			rewriters: []QueryRewriter{upper, func(context.Context, string) (string, error) {
+				return "", errRewrite
+			}},
+			err: errRewrite,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var gotQuery string
+
+			opts := make([]cfg.Option[Config], 0, len(testcase.rewriters)+2)
+			opts = append(opts, WithURI(filepath.Join(t.TempDir(), "index.db")))
+
+			for _, rewriter := range testcase.rewriters {
+				opts = append(opts, WithQueryRewriter(rewriter))
+			}
+
+			opts = append(opts, WithQueryRewriter(func(_ context.Context, query string) (string, error) {
+				gotQuery = query
+
+				return query, nil
+			}))
+
+			index, err := newIndex[int, string](cfg.New[Config](opts...), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.Search(ctx, testcase.query)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wantQuery, gotQuery)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
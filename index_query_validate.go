@@ -0,0 +1,448 @@
+package fts
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+type queryTokenKind int
+
+const (
+	tokenEOF queryTokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenLBrace
+	tokenRBrace
+	tokenColon
+	tokenComma
+	tokenPlus
+	tokenStar
+	tokenMinus
+	tokenCaret
+	tokenString
+	tokenBareword
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+var queryPunctuation = map[byte]queryTokenKind{
+	'(': tokenLParen, ')': tokenRParen, '{': tokenLBrace, '}': tokenRBrace, ':': tokenColon,
+	',': tokenComma, '+': tokenPlus, '*': tokenStar, '-': tokenMinus, '^': tokenCaret,
+}
+
+type queryToken struct {
+	kind  queryTokenKind
+	pos   int
+	value string
+}
+
+// queryParser is a lightweight parser for FTS5 query expressions, following the grammar in section 3.1 of the FTS5
+// reference document (https://www.sqlite.org/fts5.html#full_text_query_syntax). It only checks the syntax of the
+// expression: it does not resolve column names, nor does it build an expression tree.
+type queryParser struct {
+	query string
+	pos   int
+	tok   queryToken
+}
+
+// ValidateQuery checks the syntax of the input FTS5 query expression without querying the database, returning an
+// ErrInvalidQuery error describing the first problem found and its (1-based, in characters) position in the query;
+// such as unbalanced parentheses, unterminated quoted strings, operators without operands, or characters that are not
+// valid outside of a quoted string.
+//
+// Column filters are checked for their syntax only, since the column names are not known without a database. A query
+// that passes this validation can still fail if it references unknown columns.
+func ValidateQuery(query string) error {
+	p := &queryParser{query: query}
+
+	if err := p.next(); err != nil {
+		return err
+	}
+
+	if p.tok.kind == tokenEOF {
+		return nil
+	}
+
+	if err := p.parseExpr(); err != nil {
+		return err
+	}
+
+	switch p.tok.kind {
+	case tokenEOF:
+		return nil
+	case tokenRParen:
+		return p.errorf(p.tok.pos, "unbalanced parentheses: unmatched ')'")
+	default:
+		return p.errorf(p.tok.pos, "unexpected %s", p.tok)
+	}
+}
+
+func (t queryToken) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return "quoted string"
+	case tokenBareword, tokenAnd, tokenOr, tokenNot:
+		return fmt.Sprintf("%q", t.value)
+	default:
+		return fmt.Sprintf("'%s'", t.value)
+	}
+}
+
+func (p *queryParser) errorf(pos int, format string, args ...any) error {
+	return fmt.Errorf("%w: %s at position %d", ErrInvalidQuery, fmt.Sprintf(format, args...),
+		utf8.RuneCountInString(p.query[:pos])+1)
+}
+
+func isBarewordRune(r rune) bool {
+	return r >= utf8.RuneSelf || r == '_' || r == 0x1A ||
+		(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// next reads the following token in the query into p.tok.
+func (p *queryParser) next() error {
+	for p.pos < len(p.query) {
+		switch p.query[p.pos] {
+		case ' ', '\t', '\n', '\r', '\f', '\v':
+			p.pos++
+
+			continue
+		}
+
+		break
+	}
+
+	start := p.pos
+	if start >= len(p.query) {
+		p.tok = queryToken{kind: tokenEOF, pos: start}
+
+		return nil
+	}
+
+	if kind, ok := queryPunctuation[p.query[start]]; ok {
+		p.pos++
+		p.tok = queryToken{kind: kind, pos: start, value: p.query[start:p.pos]}
+
+		return nil
+	}
+
+	if p.query[start] == '"' {
+		for p.pos++; ; p.pos++ {
+			if p.pos >= len(p.query) {
+				return p.errorf(start, "unterminated quoted string")
+			}
+
+			if p.query[p.pos] != '"' {
+				continue
+			}
+
+			// a doubled quote is an escaped quote within the string
+			if p.pos+1 < len(p.query) && p.query[p.pos+1] == '"' {
+				p.pos++
+
+				continue
+			}
+
+			p.pos++
+			p.tok = queryToken{kind: tokenString, pos: start, value: p.query[start:p.pos]}
+
+			return nil
+		}
+	}
+
+	for p.pos < len(p.query) {
+		r, size := utf8.DecodeRuneInString(p.query[p.pos:])
+		if !isBarewordRune(r) {
+			break
+		}
+
+		p.pos += size
+	}
+
+	if p.pos == start {
+		r, _ := utf8.DecodeRuneInString(p.query[start:])
+
+		return p.errorf(start, "invalid character %q outside of a quoted string", r)
+	}
+
+	p.tok = queryToken{kind: tokenBareword, pos: start, value: p.query[start:p.pos]}
+
+	switch p.tok.value {
+	case "AND":
+		p.tok.kind = tokenAnd
+	case "OR":
+		p.tok.kind = tokenOr
+	case "NOT":
+		p.tok.kind = tokenNot
+	}
+
+	return nil
+}
+
+// expect consumes the current token if it is of the input kind, returning an error describing the input expectation
+// otherwise.
+func (p *queryParser) expect(kind queryTokenKind, expectation string) error {
+	if p.tok.kind != kind {
+		return p.errorf(p.tok.pos, "expected %s, found %s", expectation, p.tok)
+	}
+
+	return p.next()
+}
+
+func (p *queryParser) startsQuery() bool {
+	switch p.tok.kind {
+	case tokenString, tokenBareword, tokenLParen, tokenLBrace, tokenMinus, tokenCaret:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseExpr parses a sequence of queries joined by AND, OR or NOT operators, or implicitly (by juxtaposition).
+func (p *queryParser) parseExpr() error {
+	if err := p.parseQuery(); err != nil {
+		return err
+	}
+
+	for {
+		switch p.tok.kind {
+		case tokenAnd, tokenOr, tokenNot:
+			operator := p.tok
+
+			if err := p.next(); err != nil {
+				return err
+			}
+
+			if !p.startsQuery() {
+				return p.errorf(operator.pos, "operator %s is missing its right-hand operand", operator)
+			}
+		default:
+			if !p.startsQuery() {
+				return nil
+			}
+		}
+
+		if err := p.parseQuery(); err != nil {
+			return err
+		}
+	}
+}
+
+// parseQuery parses a single query: an optional column filter, followed by a phrase, a NEAR group or a parenthesized
+// expression.
+func (p *queryParser) parseQuery() error {
+	if err := p.parseColumnFilter(); err != nil {
+		return err
+	}
+
+	switch p.tok.kind {
+	case tokenLParen:
+		open := p.tok
+
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		switch p.tok.kind {
+		case tokenRParen:
+			return p.errorf(open.pos, "empty parentheses")
+		case tokenEOF:
+			return p.errorf(open.pos, "unbalanced parentheses: '(' is never closed")
+		}
+
+		if err := p.parseExpr(); err != nil {
+			return err
+		}
+
+		if p.tok.kind == tokenEOF {
+			return p.errorf(open.pos, "unbalanced parentheses: '(' is never closed")
+		}
+
+		return p.expect(tokenRParen, "')'")
+	case tokenBareword:
+		if p.tok.value == "NEAR" && p.peek() == '(' {
+			return p.parseNear()
+		}
+
+		return p.parsePhrase()
+	case tokenCaret:
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		return p.parsePhrase()
+	case tokenAnd, tokenOr, tokenNot:
+		return p.errorf(p.tok.pos, "operator %s is missing its left-hand operand", p.tok)
+	default:
+		return p.parsePhrase()
+	}
+}
+
+// parseColumnFilter parses an optional column filter, such as `val:`, `-id:` or `{id val}:`.
+func (p *queryParser) parseColumnFilter() error {
+	switch p.tok.kind {
+	case tokenMinus:
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		switch {
+		case p.tok.kind == tokenLBrace:
+		case (p.tok.kind == tokenBareword || p.tok.kind == tokenString) && p.peek() == ':':
+		default:
+			return p.errorf(p.tok.pos, "expected a column filter after '-', found %s", p.tok)
+		}
+
+		return p.parseColumnFilter()
+	case tokenLBrace:
+		open := p.tok
+
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		var columns int
+
+		for ; p.tok.kind == tokenBareword || p.tok.kind == tokenString; columns++ {
+			if err := p.next(); err != nil {
+				return err
+			}
+		}
+
+		if columns == 0 {
+			return p.errorf(p.tok.pos, "expected a column name, found %s", p.tok)
+		}
+
+		if p.tok.kind == tokenEOF {
+			return p.errorf(open.pos, "unbalanced braces: '{' is never closed")
+		}
+
+		if err := p.expect(tokenRBrace, "'}'"); err != nil {
+			return err
+		}
+
+		return p.expect(tokenColon, "':' after the column filter")
+	case tokenBareword, tokenString:
+		if p.peek() != ':' {
+			return nil
+		}
+
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		return p.next()
+	default:
+		return nil
+	}
+}
+
+// parsePhrase parses a phrase: one or more (optionally prefixed) strings, joined by the '+' operator.
+func (p *queryParser) parsePhrase() error {
+	for {
+		if p.tok.kind != tokenString && p.tok.kind != tokenBareword {
+			if p.tok.kind == tokenEOF {
+				return p.errorf(p.tok.pos, "expected a term, found end of query")
+			}
+
+			return p.errorf(p.tok.pos, "expected a term, found %s", p.tok)
+		}
+
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		if p.tok.kind == tokenStar {
+			if err := p.next(); err != nil {
+				return err
+			}
+		}
+
+		if p.tok.kind != tokenPlus {
+			return nil
+		}
+
+		if err := p.next(); err != nil {
+			return err
+		}
+	}
+}
+
+// parseNear parses a NEAR group, such as `NEAR(gold silver, 5)`.
+func (p *queryParser) parseNear() error {
+	// consume NEAR
+	if err := p.next(); err != nil {
+		return err
+	}
+
+	open := p.tok
+
+	if err := p.expect(tokenLParen, "'(' after NEAR"); err != nil {
+		return err
+	}
+
+	for phrases := 0; ; phrases++ {
+		switch p.tok.kind {
+		case tokenString, tokenBareword:
+			if err := p.parsePhrase(); err != nil {
+				return err
+			}
+
+			continue
+		case tokenComma:
+			if phrases == 0 {
+				return p.errorf(p.tok.pos, "expected a phrase in the NEAR group, found ','")
+			}
+
+			if err := p.next(); err != nil {
+				return err
+			}
+
+			if p.tok.kind != tokenBareword || !isDigits(p.tok.value) {
+				return p.errorf(p.tok.pos, "expected a distance in the NEAR group, found %s", p.tok)
+			}
+
+			if err := p.next(); err != nil {
+				return err
+			}
+		case tokenEOF:
+			return p.errorf(open.pos, "unbalanced parentheses: '(' is never closed")
+		}
+
+		if phrases == 0 {
+			return p.errorf(p.tok.pos, "expected a phrase in the NEAR group, found %s", p.tok)
+		}
+
+		if p.tok.kind == tokenEOF {
+			return p.errorf(open.pos, "unbalanced parentheses: '(' is never closed")
+		}
+
+		return p.expect(tokenRParen, "')' closing the NEAR group")
+	}
+}
+
+// peek returns the next non-whitespace byte in the query after the current token, or zero at the end of the query.
+func (p *queryParser) peek() byte {
+	for idx := p.pos; idx < len(p.query); idx++ {
+		switch p.query[idx] {
+		case ' ', '\t', '\n', '\r', '\f', '\v':
+			continue
+		}
+
+		return p.query[idx]
+	}
+
+	return 0
+}
+
+func isDigits(s string) bool {
+	for idx := range s {
+		if s[idx] < '0' || s[idx] > '9' {
+			return false
+		}
+	}
+
+	return s != ""
+}
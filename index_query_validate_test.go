@@ -0,0 +1,188 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestValidateQuery(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		query string
+		err   string
+	}{
+		{name: "Success/Empty", query: ""},
+		{name: "Success/Bareword", query: "gold"},
+		{name: "Success/Implicit", query: "struck gold"},
+		{name: "Success/Operators", query: "gold AND (plate OR bar) NOT silver"},
+		{name: "Success/Phrase", query: `"struck gold" OR "say ""gold"""`},
+		{name: "Success/Prefix", query: "gol* + plate"},
+		{name: "Success/Initial", query: "^gold"},
+		{name: "Success/ColumnFilter", query: "val:gold OR -id:gold OR {id val}:(gold plate)"},
+		{name: "Success/Near", query: "NEAR(gold plate, 3) OR NEAR(\"struck gold\" bar)"},
+		{name: "Success/NearBareword", query: "NEAR gold"},
+		{name: "Success/LowercaseOperator", query: "gold and plate"},
+		{name: "Success/Unicode", query: "café OR naïve"},
+		{
+			name:  "Fail/UnclosedParen",
+			query: "gold AND (plate",
+			err:   "unbalanced parentheses: '(' is never closed at position 10",
+		},
+		{
+			name:  "Fail/UnmatchedParen",
+			query: "gold) plate",
+			err:   "unbalanced parentheses: unmatched ')' at position 5",
+		},
+		{
+			name:  "Fail/EmptyParens",
+			query: "gold ()",
+			err:   "empty parentheses at position 6",
+		},
+		{
+			name:  "Fail/TrailingOperator",
+			query: "gold AND",
+			err:   `operator "AND" is missing its right-hand operand at position 6`,
+		},
+		{
+			name:  "Fail/TrailingOperatorInParens",
+			query: "(gold OR) plate",
+			err:   `operator "OR" is missing its right-hand operand at position 7`,
+		},
+		{
+			name:  "Fail/LeadingOperator",
+			query: "NOT gold",
+			err:   `operator "NOT" is missing its left-hand operand at position 1`,
+		},
+		{
+			name:  "Fail/DoubleOperator",
+			query: "gold OR AND plate",
+			err:   `operator "OR" is missing its right-hand operand at position 6`,
+		},
+		{
+			name:  "Fail/UnterminatedQuote",
+			query: `gold "struck plate`,
+			err:   "unterminated quoted string at position 6",
+		},
+		{
+			name:  "Fail/UnterminatedEscapedQuote",
+			query: `"say ""gold""`,
+			err:   "unterminated quoted string at position 1",
+		},
+		{
+			name:  "Fail/Hyphen",
+			query: "gold-plate",
+			err:   `expected a column filter after '-', found "plate" at position 6`,
+		},
+		{
+			name:  "Fail/InvalidCharacterPosition",
+			query: "café & bar",
+			err:   `invalid character '&' outside of a quoted string at position 6`,
+		},
+		{
+			name:  "Fail/DanglingStar",
+			query: "* gold",
+			err:   "expected a term, found '*' at position 1",
+		},
+		{
+			name:  "Fail/TrailingPlus",
+			query: "gold +",
+			err:   "expected a term, found end of query at position 7",
+		},
+		{
+			name:  "Fail/UnclosedNear",
+			query: "NEAR(gold plate",
+			err:   "unbalanced parentheses: '(' is never closed at position 5",
+		},
+		{
+			name:  "Fail/NearDistance",
+			query: "NEAR(gold plate, far)",
+			err:   `expected a distance in the NEAR group, found "far" at position 18`,
+		},
+		{
+			name:  "Fail/UnclosedColumnBraces",
+			query: "{id val",
+			err:   "unbalanced braces: '{' is never closed at position 1",
+		},
+		{
+			name:  "Fail/ColumnFilterWithoutTerm",
+			query: "val:",
+			err:   "expected a term, found end of query at position 5",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			err := ValidateQuery(testcase.query)
+			if testcase.err != "" {
+				require.ErrorIs(t, err, ErrInvalidQuery)
+				require.ErrorContains(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestValidateQuery_MatchesSQLite asserts that ValidateQuery agrees with SQLite's own FTS5 parser on whether each
+// query is valid.
+func TestValidateQuery_MatchesSQLite(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"),
+		Attribute[int, string]{Key: 1, Value: "struck gold plate"},
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	for _, query := range []string{
+		"gold", "struck gold", "gold AND (plate OR bar) NOT silver", `"struck gold" OR "say ""gold"""`,
+		"gol* + plate", "^gold", "val:gold OR -id:gold OR {id val}:(gold plate)", "NEAR(gold plate, 3)",
+		"NEAR gold", "gold and plate", "café OR naïve",
+		"gold AND (plate", "gold) plate", "gold ()", "gold AND", "(gold OR) plate", "NOT gold", "gold OR AND plate",
+		`gold "struck plate`, `"say ""gold""`, "gold-plate", "café & bar", "gold *", "* gold", "gold +", "NEAR(gold plate",
+		"NEAR(gold plate, far)", "{id val", "val:", "-gold", "gold:",
+	} {
+		_, searchErr := index.Search(ctx, query)
+		validateErr := ValidateQuery(query)
+
+		if validateErr != nil {
+			require.Error(t, searchErr, "query %q is valid in SQLite, but not for ValidateQuery: %v", query, validateErr)
+
+			continue
+		}
+
+		if searchErr != nil {
+			require.ErrorIs(t, searchErr, ErrNotFoundKeyword, "query %q is invalid in SQLite", query)
+		}
+	}
+}
+
+func TestIndex_WithQueryValidation(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := newIndex[int, string](cfg.New[Config](
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithQueryValidation(),
+	),
+		Attribute[int, string]{Key: 1, Value: "struck gold"},
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	_, err = index.Search(ctx, "gold AND (plate")
+	require.ErrorIs(t, err, ErrInvalidQuery)
+
+	res, err := index.Search(ctx, "gold AND (struck OR plate)")
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+}
@@ -0,0 +1,193 @@
+package fts
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// CacheStats describes the usage of an Index's result cache (see WithResultCache).
+type CacheStats struct {
+	// Hits is the number of searches served from the cache.
+	Hits uint64
+	// Misses is the number of searches that were queried in the database.
+	Misses uint64
+	// Entries is the number of cached results.
+	Entries int
+}
+
+// HitRatio returns the fraction of searches served from the cache, or zero if there were no searches.
+func (s CacheStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// cacheMetrics is implemented by Metrics that expose the hit ratio of the Index's result cache, such as the metrics
+// package's Prometheus Metrics.
+type cacheMetrics interface {
+	SetCacheHitRatio(ratio float64)
+}
+
+type cacheEntry[K SQLType, V SQLType] struct {
+	key string
+	res []Attribute[K, V]
+}
+
+// resultCache is a least-recently-used cache of search results, keyed by the (rewritten) search term and its
+// SearchOpts. It is invalidated as a whole on every write to the Index.
+type resultCache[K SQLType, V SQLType] struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+	gen     uint64
+	hits    uint64
+	misses  uint64
+	metrics cacheMetrics
+}
+
+func newResultCache[K SQLType, V SQLType](size int, metrics Metrics) *resultCache[K, V] {
+	cache := &resultCache[K, V]{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		order:   list.New(),
+	}
+
+	if m, ok := metrics.(cacheMetrics); ok {
+		cache.metrics = m
+	}
+
+	return cache
+}
+
+// cacheKey returns the key for the results of a search with the input (rewritten) search term and SearchOpts.
+func cacheKey[V SQLType](searchTerm V, opts SearchOpts) string {
+	highlight := "-"
+	if opts.Highlight != nil {
+		highlight = opts.Highlight.Open + "\x00" + opts.Highlight.Close
+	}
+
+	orderBy := "-"
+	if opts.OrderBy != nil {
+		orderBy = fmt.Sprintf("%s\x00%t", opts.OrderBy.Column, opts.OrderBy.Desc)
+	}
+
+	return fmt.Sprintf("%v\x00%d\x00%d\x00%t\x00%d\x00%s\x00%s",
+		searchTerm, opts.Limit, opts.Offset, opts.IncludeValue, opts.Order, highlight, orderBy)
+}
+
+// get returns a copy of the cached results for the input key, if any, registering a hit or a miss; as well as the
+// cache's current generation, to be used when storing the results of a miss.
+func (c *resultCache[K, V]) get(key string) ([]Attribute[K, V], uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if ok {
+		c.hits++
+		c.order.MoveToFront(elem)
+	} else {
+		c.misses++
+	}
+
+	if c.metrics != nil {
+		c.metrics.SetCacheHitRatio(float64(c.hits) / float64(c.hits+c.misses))
+	}
+
+	if !ok {
+		return nil, c.gen, false
+	}
+
+	res := elem.Value.(*cacheEntry[K, V]).res
+
+	return append(make([]Attribute[K, V], 0, len(res)), res...), c.gen, true
+}
+
+// put stores a copy of the input results under the input key, unless the cache was invalidated since the input
+// generation was read (as the results may precede a write), evicting the least recently used entry if the cache is
+// full.
+func (c *resultCache[K, V]) put(gen uint64, key string, res []Attribute[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen != c.gen {
+		return
+	}
+
+	entry := &cacheEntry[K, V]{key: key, res: append(make([]Attribute[K, V], 0, len(res)), res...)}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry[K, V]).key)
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+}
+
+// invalidate discards all cached results.
+func (c *resultCache[K, V]) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	c.entries = make(map[string]*list.Element, c.size)
+	c.order.Init()
+}
+
+func (c *resultCache[K, V]) stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{
+		Hits:    c.hits,
+		Misses:  c.misses,
+		Entries: c.order.Len(),
+	}
+}
+
+// CacheStats returns the usage statistics of the Index's result cache, which are zero if the Index is not configured
+// with one (see WithResultCache).
+func (i *Index[K, V]) CacheStats() CacheStats {
+	if i.cache == nil {
+		return CacheStats{}
+	}
+
+	return i.cache.stats()
+}
+
+// cachedQuery executes the search query for the input search term and SearchOpts, serving it from the Index's result
+// cache if configured with one. Searches within a transaction bypass the cache, as do the searches whose results are
+// truncated or fail.
+func (i *Index[K, V]) cachedQuery(
+	ctx context.Context, q queryer, searchTerm V, opts SearchOpts,
+) ([]Attribute[K, V], error) {
+	if i.cache == nil || q != i.db {
+		return i.query(ctx, q, searchTerm, opts)
+	}
+
+	key := cacheKey(searchTerm, opts)
+
+	res, gen, ok := i.cache.get(key)
+	if ok {
+		return res, nil
+	}
+
+	res, err := i.query(ctx, q, searchTerm, opts)
+	if err == nil {
+		i.cache.put(gen, key, res)
+	}
+
+	return res, err
+}
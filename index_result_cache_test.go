@@ -0,0 +1,102 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+	"github.com/zalgonoise/fts/metrics"
+)
+
+func lowercase(_ context.Context, query string) (string, error) {
+	return strings.ToLower(query), nil
+}
+
+func TestIndex_ResultCache(t *testing.T) {
+	ctx := context.Background()
+
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold"},
+	}
+
+	index, err := newIndex[int, string](cfg.New(
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithQueryRewriter(lowercase),
+		WithResultCache(8),
+	), attrs...)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	// both queries normalize to `gold`, so the second one is served from the first one's cache entry
+	for _, query := range []string{"GOLD", "gold"} {
+		res, err := index.Search(ctx, query)
+		require.NoError(t, err)
+		require.Equal(t, []Attribute[int, string]{attrs[1]}, res)
+	}
+
+	stats := index.CacheStats()
+	require.Equal(t, CacheStats{Hits: 1, Misses: 1, Entries: 1}, stats)
+	require.Equal(t, 0.5, stats.HitRatio())
+
+	// different search options are cached separately
+	_, err = index.SearchTop(ctx, "Gold", 1)
+	require.NoError(t, err)
+	require.Equal(t, CacheStats{Hits: 1, Misses: 2, Entries: 2}, index.CacheStats())
+
+	// writes invalidate the cache
+	require.NoError(t, index.Insert(ctx, Attribute[int, string]{Key: 3, Value: "golden gold"}))
+	require.Zero(t, index.CacheStats().Entries)
+
+	res, err := index.Search(ctx, "gold")
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+}
+
+func TestIndex_ResultCache_Metrics(t *testing.T) {
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+
+	m, err := metrics.New(0, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+
+	indexer, err := New(
+		[]Attribute[int, string]{{Key: 1, Value: "struck gold"}},
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithQueryRewriter(lowercase),
+		WithResultCache(8),
+		WithMetrics(m),
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, indexer.Shutdown(ctx))
+	}()
+
+	for _, query := range []string{"GOLD", "gold", "Gold", "gOLD"} {
+		_, err = indexer.Search(ctx, query)
+		require.NoError(t, err)
+	}
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	var ratio float64
+
+	for _, family := range families {
+		if family.GetName() == "search_cache_hit_ratio" {
+			require.Len(t, family.GetMetric(), 1)
+
+			ratio = family.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+
+	require.Equal(t, 0.75, ratio)
+}
@@ -0,0 +1,47 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+)
+
+// ResultTransformer post-processes the results of a search before they are returned (e.g. to trim or decorate values,
+// or to join them with external data), returning the transformed results or an error if they cannot be transformed.
+// It can be registered in an Index with the WithResultTransformer option.
+type ResultTransformer[K SQLType, V SQLType] func(ctx context.Context, res []Attribute[K, V]) ([]Attribute[K, V], error)
+
+// resultTransformersFor asserts that the input result transformers (as set in a Config) match the input key and value
+// types, returning an ErrInvalidTransformer error otherwise.
+func resultTransformersFor[K SQLType, V SQLType](transformers []any) ([]ResultTransformer[K, V], error) {
+	if len(transformers) == 0 {
+		return nil, nil
+	}
+
+	res := make([]ResultTransformer[K, V], 0, len(transformers))
+
+	for idx := range transformers {
+		fn, ok := transformers[idx].(ResultTransformer[K, V])
+		if !ok {
+			return nil, fmt.Errorf("%w: %T does not match the Index's key and value types", ErrInvalidTransformer,
+				transformers[idx])
+		}
+
+		res = append(res, fn)
+	}
+
+	return res, nil
+}
+
+// transformResults applies the Index's result transformers, in order, to the input search results.
+func (i *Index[K, V]) transformResults(ctx context.Context, res []Attribute[K, V]) ([]Attribute[K, V], error) {
+	for idx := range i.transformers {
+		transformed, err := i.transformers[idx](ctx, res)
+		if err != nil {
+			return nil, fmt.Errorf("transforming results: %w", err)
+		}
+
+		res = transformed
+	}
+
+	return res, nil
+}
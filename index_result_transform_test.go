@@ -0,0 +1,136 @@
+package fts
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_ResultTransformer(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold"},
+		{Key: 3, Value: "golden hour"},
+	}
+
+	errTransform := errors.New("transform failed")
+
+	upper := func(_ context.Context, res []Attribute[int, string]) ([]Attribute[int, string], error) {
+		for idx := range res {
+			res[idx].Value = strings.ToUpper(res[idx].Value)
+		}
+
+		return res, nil
+	}
+
+	suffix := func(_ context.Context, res []Attribute[int, string]) ([]Attribute[int, string], error) {
+		for idx := range res {
+			res[idx].Value += "!"
+		}
+
+		return res, nil
+	}
+
+	for _, testcase := range []struct {
+		name         string
+		query        string
+		transformers []func(context.Context, []Attribute[int, string]) ([]Attribute[int, string], error)
+		wants        []Attribute[int, string]
+		err          error
+	}{
+		{
+			name:  "Success/NoTransformers",
+			query: "gold*",
+			wants: []Attribute[int, string]{
+				{Key: 2, Value: "struck gold"},
+				{Key: 3, Value: "golden hour"},
+			},
+		},
+		{
+			name:  "Success/UppercaseAllResults",
+			query: "gold*",
+			transformers: []func(context.Context, []Attribute[int, string]) ([]Attribute[int, string], error){
+				upper,
+			},
+			wants: []Attribute[int, string]{
+				{Key: 2, Value: "STRUCK GOLD"},
+				{Key: 3, Value: "GOLDEN HOUR"},
+			},
+		},
+		{
+			name:  "Success/AppliedInOrder",
+			query: "gold",
+			transformers: []func(context.Context, []Attribute[int, string]) ([]Attribute[int, string], error){
+				upper, suffix,
+			},
+			wants: []Attribute[int, string]{
+				{Key: 2, Value: "STRUCK GOLD!"},
+			},
+		},
+		{
+			name:  "Fail/TransformerError",
+			query: "gold",
+			transformers: []func(context.Context, []Attribute[int, string]) ([]Attribute[int, string], error){
+				upper,
+				func(context.Context, []Attribute[int, string]) ([]Attribute[int, string], error) {
+					return nil, errTransform
+				},
+			},
+			err: errTransform,
+		},
+		{
+			name:  "Fail/NotFound",
+			query: "silver",
+			transformers: []func(context.Context, []Attribute[int, string]) ([]Attribute[int, string], error){
+				upper,
+			},
+			err: ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			opts := make([]cfg.Option[Config], 0, len(testcase.transformers)+1)
+			opts = append(opts, WithURI(filepath.Join(t.TempDir(), "index.db")))
+
+			for _, transformer := range testcase.transformers {
+				opts = append(opts, WithResultTransformer(transformer))
+			}
+
+			index, err := newIndex[int, string](cfg.New[Config](opts...), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchWithOpts(ctx, testcase.query, SearchOpts{
+				Order:        OrderSequence,
+				IncludeValue: true,
+			})
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestIndex_ResultTransformer_Invalid(t *testing.T) {
+	_, err := newIndex[int, []byte](cfg.New(
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithResultTransformer(func(_ context.Context, res []Attribute[int, string]) ([]Attribute[int, string], error) {
+			return res, nil
+		}),
+	))
+	require.ErrorIs(t, err, ErrInvalidTransformer)
+}
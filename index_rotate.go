@@ -0,0 +1,80 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+)
+
+// Rotate moves the Index's database file to the input archive path, and starts afresh with a new, empty database at
+// the original URI, in the style of log rotation. The archived file is a regular Index database, which can be opened
+// with NewIndex.
+//
+// The current database is checkpointed and closed before it is moved. Other operations on the Index wait for the
+// rotation to complete, and vice-versa.
+//
+// This call returns an ErrInMemoryRotation error if the Index is in-memory, since there is no file to rotate. If the
+// rotation fails after the database is closed (when moving the file, or when creating the new database), the original
+// database is moved back in place if needed and reopened, so that the Index keeps serving it; and the error is
+// returned.
+func (i *Index[K, V]) Rotate(ctx context.Context, archivePath string) error {
+	if i.inMemory {
+		return ErrInMemoryRotation
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, err := i.db.ExecContext(ctx, checkpointQuery); err != nil {
+		return err
+	}
+
+	if err := i.db.Close(); err != nil {
+		return errors.Join(err, i.reopen())
+	}
+
+	if err := os.Rename(i.uri, archivePath); err != nil {
+		return errors.Join(err, i.reopen())
+	}
+
+	db, err := i.openURI()
+	if err != nil {
+		return errors.Join(err, os.Rename(archivePath, i.uri), i.reopen())
+	}
+
+	i.db = db
+
+	if i.cache != nil {
+		i.cache.invalidate()
+	}
+
+	return nil
+}
+
+// reopen opens and initializes the database at the Index's URI, replacing the Index's (closed) database handle. The
+// handle is left unchanged if this fails.
+func (i *Index[K, V]) reopen() error {
+	db, err := i.openURI()
+	if err != nil {
+		return err
+	}
+
+	i.db = db
+
+	return nil
+}
+
+// openURI opens and initializes the database at the Index's URI, with the Index's connection settings and schema.
+func (i *Index[K, V]) openURI() (*sql.DB, error) {
+	db, err := open(i.uri, i.rawDSN, "", i.cacheMode, i.pragmas, i.maxOpenConns)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = initDatabase(db, i.tokenizer, i.store, i.initPragmas); err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+
+	return db, nil
+}
@@ -0,0 +1,131 @@
+package fts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Rotate(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	uri := filepath.Join(dir, "index.db")
+	archive := filepath.Join(dir, "index.1.db")
+
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold"},
+	}
+
+	index, err := NewIndex(uri, attrs...)
+	require.NoError(t, err)
+
+	require.NoError(t, index.Rotate(ctx, archive))
+
+	_, err = index.Search(ctx, "gold")
+	require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+	require.NoError(t, index.Insert(ctx, Attribute[int, string]{Key: 3, Value: "fresh gold"}))
+
+	res, err := index.Search(ctx, "gold")
+	require.NoError(t, err)
+	require.Equal(t, []Attribute[int, string]{{Key: 3, Value: "fresh gold"}}, res)
+
+	require.NoError(t, index.Shutdown(ctx))
+
+	archived, err := NewIndex[int, string](archive)
+	require.NoError(t, err)
+
+	res, err = archived.Search(ctx, "gold")
+	require.NoError(t, err)
+	require.Equal(t, []Attribute[int, string]{{Key: 2, Value: "struck gold"}}, res)
+
+	require.NoError(t, archived.Shutdown(ctx))
+}
+
+func TestIndex_RotateRenameFailure(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	uri := filepath.Join(dir, "index.db")
+	archive := filepath.Join(dir, "missing", "index.1.db")
+
+	index, err := NewIndex(uri, Attribute[int, string]{Key: 1, Value: "struck gold"})
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	require.Error(t, index.Rotate(ctx, archive))
+
+	// the original database is reopened, and keeps serving searches and writes
+	res, err := index.Search(ctx, "gold")
+	require.NoError(t, err)
+	require.Equal(t, []Attribute[int, string]{{Key: 1, Value: "struck gold"}}, res)
+
+	require.NoError(t, index.Insert(ctx, Attribute[int, string]{Key: 2, Value: "fresh gold"}))
+
+	res, err = index.Search(ctx, "fresh")
+	require.NoError(t, err)
+	require.Equal(t, []Attribute[int, string]{{Key: 2, Value: "fresh gold"}}, res)
+
+	_, err = os.Stat(archive)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestIndex_RotateInMemory(t *testing.T) {
+	index, err := NewIndex[int, string]("")
+	require.NoError(t, err)
+
+	require.ErrorIs(t, index.Rotate(context.Background(), filepath.Join(t.TempDir(), "index.db")), ErrInMemoryRotation)
+	require.NoError(t, index.Shutdown(context.Background()))
+}
+
+func TestIndex_RotateConcurrently(t *testing.T) {
+	const numInserts = 200
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	uri := filepath.Join(dir, "index.db")
+	archive := filepath.Join(dir, "index.1.db")
+
+	index, err := NewIndex[int, string](uri)
+	require.NoError(t, err)
+
+	errs := make(chan error, numInserts)
+
+	go func() {
+		for i := 0; i < numInserts; i++ {
+			errs <- index.Insert(ctx, Attribute[int, string]{Key: i, Value: "gold"})
+		}
+
+		close(errs)
+	}()
+
+	require.NoError(t, index.Rotate(ctx, archive))
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	count := func(index *Index[int, string]) int {
+		res, err := index.SearchWithOpts(ctx, "gold", SearchOpts{})
+		if err != nil {
+			require.ErrorIs(t, err, ErrNotFoundKeyword)
+		}
+
+		return len(res)
+	}
+
+	fresh := count(index)
+	require.NoError(t, index.Shutdown(ctx))
+
+	archived, err := NewIndex[int, string](archive)
+	require.NoError(t, err)
+
+	require.Equal(t, numInserts, fresh+count(archived))
+	require.NoError(t, archived.Shutdown(ctx))
+}
@@ -0,0 +1,124 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+const (
+	searchWithRowIDsQuery = `
+SELECT rowid, id, val FROM fulltext_search(?);
+`
+
+	deleteByRowIDQuery = `
+DELETE FROM fulltext_search
+	WHERE rowid = ?;
+`
+)
+
+// RowAttribute is an Attribute returned from a search, alongside its row ID in the Index: a unique, stable identifier
+// of the row holding the attribute, which can be used to delete it with DeleteByRowID.
+type RowAttribute[K SQLType, V SQLType] struct {
+	Attribute[K, V]
+
+	RowID int64
+}
+
+// SearchWithRowIDs will look for matches for the input value through the indexed terms, returning a collection of
+// matching RowAttribute, which contain the key and (full) value for that match, as well as its row ID.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query. If the Index is configured with a maximum number
+// of results and the search yields more than that, the capped results are returned alongside an ErrResultTruncated
+// error.
+func (i *Index[K, V]) SearchWithRowIDs(ctx context.Context, searchTerm V) ([]RowAttribute[K, V], error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	rows, err := i.db.QueryContext(ctx, searchWithRowIDsQuery, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr RowAttribute[K, V], err error) {
+		return attr, rows.Scan(&attr.RowID, &attr.Key, &attr.Value)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	return res, nil
+}
+
+// InsertReturning indexes new attributes in the Index, like Insert, returning the row IDs assigned to them, in the
+// same order as the input attributes. The row IDs identify the inserted rows as in SearchWithRowIDs, so they can be
+// used to cross-reference the attributes from an external index, or to delete them with DeleteByRowID. Attributes with
+// a NULL value that are skipped (see WithSkipNullValues) have a row ID of zero.
+//
+// This call returns an error if the underlying SQL query fails, or if the row ID of an inserted row can't be read. If
+// the context is canceled while the transaction is open, it is rolled back and the context's error is returned; so
+// that none of the attributes are committed, and no row IDs are returned. If the Index is configured with a write
+// queue, the call is enqueued and blocks until it is processed.
+func (i *Index[K, V]) InsertReturning(ctx context.Context, attrs ...Attribute[K, V]) ([]int64, error) {
+	var rowIDs []int64
+
+	err := i.write(ctx, func(ctx context.Context) (err error) {
+		rowIDs, err = i.insert(ctx, attrs...)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rowIDs, nil
+}
+
+// DeleteByRowID removes the attributes in the Index with the input row IDs, as returned by SearchWithRowIDs. Unlike
+// Delete, which matches keys, each row ID identifies exactly one attribute. Row IDs that are not in the Index are
+// ignored.
+//
+// A database transaction is performed in order to ensure that the query is executed as quickly as possible; in case
+// multiple items are provided as input. If the context is canceled while the transaction is open, it is rolled back
+// and the context's error is returned; so that none of the rows are deleted.
+//
+// If the Index is configured with a write queue, the call is enqueued and blocks until it is processed.
+func (i *Index[K, V]) DeleteByRowID(ctx context.Context, rowIDs ...int64) error {
+	return i.write(ctx, func(ctx context.Context) error {
+		i.mu.RLock()
+		defer i.mu.RUnlock()
+
+		tx, err := i.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		for idx := range rowIDs {
+			if err = ctx.Err(); err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+
+			if _, err = tx.ExecContext(ctx, i.deleteRowQuery, rowIDs[idx]); err != nil {
+				return errors.Join(err, rollback(tx))
+			}
+		}
+
+		return i.changes.commit(tx, func() ChangeEvent[K] {
+			return ChangeEvent[K]{Op: ChangeDelete, RowIDs: append(make([]int64, 0, len(rowIDs)), rowIDs...)}
+		})
+	})
+}
+
+func deleteByRowIDQueryFor(store storage) string {
+	if !store.external() {
+		return deleteByRowIDQuery
+	}
+
+	return deleteByRowIDExternalQuery
+}
@@ -0,0 +1,113 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_DeleteByRowID(t *testing.T) {
+	// duplicate keys make key-based deletes remove more than what was found
+	attrs := []Attribute[string, string]{
+		{Key: "report", Value: "struck gold"},
+		{Key: "report", Value: "some data"},
+		{Key: "notes", Value: "gold plate"},
+		{Key: "notes", Value: "probably bronze"},
+	}
+
+	for _, testcase := range []struct {
+		name string
+		opts []cfg.Option[Config]
+	}{
+		{name: "Default"},
+		{name: "Compressed", opts: []cfg.Option[Config]{WithValueCompression(GzipCodec())}},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[string, string](
+				cfg.New(append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...),
+				attrs...,
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			found, err := index.SearchWithRowIDs(ctx, "gold")
+			require.NoError(t, err)
+			require.Len(t, found, 2)
+
+			rowIDs := make([]int64, 0, len(found))
+			for i := range found {
+				rowIDs = append(rowIDs, found[i].RowID)
+			}
+
+			require.NoError(t, index.DeleteByRowID(ctx, rowIDs...))
+
+			_, err = index.Search(ctx, "gold")
+			require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+			res, err := index.List(ctx, OrderSequence)
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[string, string]{
+				{Key: "report", Value: "some data"},
+				{Key: "notes", Value: "probably bronze"},
+			}, res)
+		})
+	}
+}
+
+func TestIndex_InsertReturning(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "struck gold"},
+		{Key: 2, Value: "gold plate"},
+		{Key: 3, Value: "gold rush"},
+	}
+
+	for _, testcase := range []struct {
+		name string
+		opts []cfg.Option[Config]
+	}{
+		{name: "Default"},
+		{name: "Compressed", opts: []cfg.Option[Config]{WithValueCompression(GzipCodec())}},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](
+				cfg.New(append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...),
+				Attribute[int, string]{Key: 0, Value: "some data"},
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			rowIDs, err := index.InsertReturning(ctx, attrs...)
+			require.NoError(t, err)
+			require.Len(t, rowIDs, len(attrs))
+
+			found, err := index.SearchWithRowIDs(ctx, "gold")
+			require.NoError(t, err)
+
+			wants := make([]RowAttribute[int, string], 0, len(attrs))
+			for i := range attrs {
+				wants = append(wants, RowAttribute[int, string]{Attribute: attrs[i], RowID: rowIDs[i]})
+			}
+
+			require.ElementsMatch(t, wants, found)
+
+			require.NoError(t, index.DeleteByRowID(ctx, rowIDs[1]))
+
+			res, err := index.Search(ctx, "gold")
+			require.NoError(t, err)
+			require.ElementsMatch(t, []Attribute[int, string]{attrs[0], attrs[2]}, res)
+		})
+	}
+}
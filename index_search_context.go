@@ -0,0 +1,113 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+const (
+	previewOpen     = "\x01"
+	previewClose    = "\x02"
+	previewEllipsis = "…"
+
+	searchWithContextQuery = `
+SELECT id, val, highlight(fulltext_search, 1, ?, ?)
+	FROM fulltext_search(?)
+	ORDER BY rank, rowid;
+`
+)
+
+// PreviewAttribute is an Attribute returned from a search, alongside a preview of its value: a window of text
+// surrounding the first matched term.
+type PreviewAttribute[K SQLType, V SQLType] struct {
+	Attribute[K, V]
+
+	Preview string
+}
+
+// SearchWithContext will look for matches for the input value through the indexed terms, returning a collection of
+// matching PreviewAttribute sorted by relevance (best match first), which contain the key and (full) value for that
+// match, as well as a preview of its value.
+//
+// The preview is a window of windowChars characters (runes) from the value, centered on the first matched term, with
+// an ellipsis on each side where the value is truncated. If the match is only on the key, the window starts at the
+// beginning of the value. A windowChars value of zero or below returns the whole value as the preview. The value is
+// read as text to build the preview, so this call should only be used with character type values (string, []byte or
+// []rune).
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query. If the Index is configured with a maximum number
+// of results and the search yields more than that, the capped results are returned alongside an ErrResultTruncated
+// error.
+func (i *Index[K, V]) SearchWithContext(
+	ctx context.Context, searchTerm V, windowChars int,
+) ([]PreviewAttribute[K, V], error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	rows, err := i.db.QueryContext(ctx, searchWithContextQuery, previewOpen, previewClose, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr PreviewAttribute[K, V], err error) {
+		var highlighted string
+
+		if err = rows.Scan(&attr.Key, &attr.Value, &highlighted); err != nil {
+			return attr, err
+		}
+
+		attr.Preview = preview(highlighted, windowChars)
+
+		return attr, nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	return res, nil
+}
+
+// preview returns a window of windowChars runes from the input highlighted text (with its markers removed), centered
+// on its first highlighted term.
+func preview(highlighted string, windowChars int) string {
+	var start, end int
+
+	if idx := strings.Index(highlighted, previewOpen); idx >= 0 {
+		start = len([]rune(highlighted[:idx]))
+		end = start
+
+		if closeIdx := strings.Index(highlighted[idx:], previewClose); closeIdx >= 0 {
+			end = start + len([]rune(highlighted[idx+len(previewOpen):idx+closeIdx]))
+		}
+	}
+
+	text := []rune(strings.NewReplacer(previewOpen, "", previewClose, "").Replace(highlighted))
+
+	if windowChars <= 0 || len(text) <= windowChars {
+		return string(text)
+	}
+
+	from := max(0, min((start+end)/2-windowChars/2, len(text)-windowChars))
+	to := from + windowChars
+
+	var sb strings.Builder
+
+	if from > 0 {
+		sb.WriteString(previewEllipsis)
+	}
+
+	sb.WriteString(string(text[from:to]))
+
+	if to < len(text) {
+		sb.WriteString(previewEllipsis)
+	}
+
+	return sb.String()
+}
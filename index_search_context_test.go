@@ -0,0 +1,83 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchWithContext(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "after a long day of digging in the hills we struck gold in the old copper mine near the river"},
+		{Key: 2, Value: "gold at the start"},
+		{Key: 3, Value: "probably bronze"},
+	}
+
+	for _, testcase := range []struct {
+		name   string
+		query  string
+		window int
+		wants  []string
+		err    error
+	}{
+		{
+			name:   "Success/CenteredOnMatch",
+			query:  "gold",
+			window: 20,
+			wants: []string{
+				"gold at the start",
+				"… struck gold in the …",
+			},
+		},
+		{
+			name:   "Success/MatchAtStart",
+			query:  "gold",
+			window: 10,
+			wants: []string{
+				"gold at th…",
+				"…ck gold in…",
+			},
+		},
+		{
+			name:   "Success/WholeValue",
+			query:  "bronze",
+			window: 0,
+			wants:  []string{"probably bronze"},
+		},
+		{
+			name:   "Fail/NoResults",
+			query:  "silver",
+			window: 20,
+			err:    ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchWithContext(ctx, testcase.query, testcase.window)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			previews := make([]string, 0, len(res))
+			for i := range res {
+				previews = append(previews, res[i].Preview)
+			}
+
+			require.Equal(t, testcase.wants, previews)
+		})
+	}
+}
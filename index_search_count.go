@@ -0,0 +1,45 @@
+package fts
+
+import "context"
+
+const (
+	// approxCountCeiling is the number of matches that SearchCountApprox stops counting at.
+	approxCountCeiling = 1000
+
+	searchCountApproxQuery = `
+SELECT count(*) FROM (
+	SELECT 1 FROM fulltext_search(?)
+		LIMIT ?
+);
+`
+)
+
+// SearchCountApprox returns the number of matches for the input search term, counting up to a ceiling of 1000
+// matches: if there are more matches than that, the ceiling is returned alongside a true atLeast value, meaning that
+// there are at least as many matches. This makes it a fast and cheap cardinality estimate for broad queries (e.g. for
+// "about 1,000 results" displays), where an exact count would scan all matches.
+//
+// As in SearchWithOpts, the search term is checked, rewritten and validated as configured in the Index. A search term
+// with no matches returns a zero count and no error.
+//
+// This call returns an error if the search term is rejected or cannot be rewritten, or if the underlying SQL query
+// fails.
+func (i *Index[K, V]) SearchCountApprox(ctx context.Context, searchTerm V) (count int, atLeast bool, err error) {
+	searchTerm, err = i.prepareQuery(ctx, searchTerm)
+	if err != nil {
+		return 0, false, err
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if err = i.db.QueryRowContext(ctx, searchCountApproxQuery, searchTerm, approxCountCeiling+1).Scan(&count); err != nil {
+		return 0, false, err
+	}
+
+	if count > approxCountCeiling {
+		return approxCountCeiling, true, nil
+	}
+
+	return count, false, nil
+}
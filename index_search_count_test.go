@@ -0,0 +1,63 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_SearchCountApprox(t *testing.T) {
+	const numGold = approxCountCeiling + 500
+
+	ctx := context.Background()
+
+	attrs := make([]Attribute[int, string], 0, numGold+10)
+	for i := 0; i < numGold; i++ {
+		attrs = append(attrs, Attribute[int, string]{Key: i, Value: fmt.Sprintf("gold nugget %d", i)})
+	}
+
+	for i := numGold; i < numGold+10; i++ {
+		attrs = append(attrs, Attribute[int, string]{Key: i, Value: fmt.Sprintf("silver coin %d", i)})
+	}
+
+	index, err := newIndex[int, string](cfg.New(WithURI(filepath.Join(t.TempDir(), "index.db"))), attrs...)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	for _, testcase := range []struct {
+		name        string
+		query       string
+		wantCount   int
+		wantAtLeast bool
+	}{
+		{
+			name:        "AboveCeiling",
+			query:       "gold",
+			wantCount:   approxCountCeiling,
+			wantAtLeast: true,
+		},
+		{
+			name:      "BelowCeiling",
+			query:     "silver",
+			wantCount: 10,
+		},
+		{
+			name:  "NoMatches",
+			query: "bronze",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			count, atLeast, err := index.SearchCountApprox(ctx, testcase.query)
+			require.NoError(t, err)
+			require.Equal(t, testcase.wantCount, count)
+			require.Equal(t, testcase.wantAtLeast, atLeast)
+		})
+	}
+}
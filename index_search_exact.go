@@ -0,0 +1,98 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+const (
+	searchExactQuery = `
+SELECT id, val FROM fulltext_search
+	WHERE val = ?;
+`
+
+	searchColumnEqualsQuery = `
+SELECT id, val FROM fulltext_search
+	WHERE fulltext_search MATCH ?1
+	AND length(val) = length(?2)
+	ORDER BY rowid;
+`
+)
+
+// SearchExact returns the Attribute in the Index whose value is exactly equal to the input value, in insertion order.
+//
+// Unlike Search, the value is not tokenized nor matched as an FTS5 expression: it is compared with the stored values
+// by equality, using SQLite's default (BINARY) collation; so the match is case-sensitive and must cover the whole
+// value. This is useful for structured values such as codes or identifiers, stored in the same Index.
+//
+// Since equality on the value column cannot use the full-text index, this call performs a full scan of the table.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query. If the Index is configured with a maximum number
+// of results and the search yields more than that, the capped results are returned alongside an ErrResultTruncated
+// error.
+func (i *Index[K, V]) SearchExact(ctx context.Context, value V) ([]Attribute[K, V], error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	rows, err := i.db.QueryContext(ctx, searchExactQuery, value)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr Attribute[K, V], err error) {
+		return attr, rows.Scan(&attr.Key, &attr.Value)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, value)
+	}
+
+	return res, nil
+}
+
+// SearchColumnEquals returns the Attribute in the Index whose value consists exactly of the tokens in the input phrase,
+// in the same order, in insertion order. For example, "gold" matches a value of "gold" or "Gold", but not "struck gold"
+// nor "gold plate".
+//
+// Unlike SearchExact, the match goes through the full-text index: the phrase is queried as an exact phrase anchored to
+// the start of the value column (`val : ^"phrase"`), so it is tokenized (and case-folded) as configured in the Index.
+// The matches are then limited to the values with the same length (in characters) as the phrase, which discards the
+// values with further tokens after the phrase. Since the length must be the same, the phrase must keep the value's
+// punctuation and spacing (e.g. "gold" does not match "gold!").
+//
+// This call returns an ErrInvalidQuery error if the phrase is empty, an error if the underlying SQL query fails, if
+// scanning for the results fails, or an ErrNotFoundKeyword error if there are zero results from the query. If the
+// Index is configured with a maximum number of results and the search yields more than that, the capped results are
+// returned alongside an ErrResultTruncated error.
+func (i *Index[K, V]) SearchColumnEquals(ctx context.Context, phrase string) ([]Attribute[K, V], error) {
+	if strings.TrimSpace(phrase) == "" {
+		return nil, fmt.Errorf("%w: empty phrase", ErrInvalidQuery)
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	rows, err := i.db.QueryContext(ctx, searchColumnEqualsQuery, "val : ^"+quotePhrase(phrase), phrase)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr Attribute[K, V], err error) {
+		return attr, rows.Scan(&attr.Key, &attr.Value)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, phrase)
+	}
+
+	return res, nil
+}
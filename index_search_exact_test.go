@@ -0,0 +1,132 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchExact(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "gold"},
+		{Key: 2, Value: "SKU-0042"},
+		{Key: 3, Value: "struck Gold"},
+		{Key: 4, Value: "gold"},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		value string
+		wants []Attribute[int, string]
+		err   error
+	}{
+		{
+			name:  "Success/Exact",
+			value: "gold",
+			wants: []Attribute[int, string]{
+				{Key: 1, Value: "gold"},
+				{Key: 4, Value: "gold"},
+			},
+		},
+		{
+			name:  "Success/Code",
+			value: "SKU-0042",
+			wants: []Attribute[int, string]{
+				{Key: 2, Value: "SKU-0042"},
+			},
+		},
+		{
+			name:  "Fail/CaseSensitive",
+			value: "Gold",
+			err:   ErrNotFoundKeyword,
+		},
+		{
+			name:  "Fail/PartialValue",
+			value: "struck",
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchExact(ctx, testcase.value)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestIndex_SearchColumnEquals(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "gold"},
+		{Key: 2, Value: "struck gold"},
+		{Key: 3, Value: "Gold"},
+		{Key: 4, Value: "gold plate"},
+		{Key: 5, Value: "Gold Plate"},
+		{Key: 6, Value: "plate gold"},
+	}
+
+	for _, testcase := range []struct {
+		name   string
+		phrase string
+		wants  []Attribute[int, string]
+		err    error
+	}{
+		{
+			name:   "Success/SingleToken",
+			phrase: "gold",
+			wants:  []Attribute[int, string]{attrs[0], attrs[2]},
+		},
+		{
+			name:   "Success/TokensInOrder",
+			phrase: "gold plate",
+			wants:  []Attribute[int, string]{attrs[3], attrs[4]},
+		},
+		{
+			name:   "Fail/PartialValue",
+			phrase: "struck",
+			err:    ErrNotFoundKeyword,
+		},
+		{
+			name:   "Fail/Empty",
+			phrase: "",
+			err:    ErrInvalidQuery,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchColumnEquals(ctx, testcase.phrase)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const searchExceptQuery = `
+SELECT id, val FROM fulltext_search
+	WHERE rowid NOT IN (SELECT rowid FROM fulltext_search(?));
+`
+
+// SearchExcept returns all the Attribute in the Index which do not match the input term, as a negated search (which
+// FTS5 does not support as a standalone query). The term may be any FTS5 expression, and the results are returned in
+// insertion order.
+//
+// This call always performs a full scan of the table, besides the full-text search for the input term, so its cost
+// grows with the size of the Index rather than with the number of matches. Prefer combining the NOT operator with a
+// positive term in a regular search (e.g. "copper NOT gold") when possible.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if all attributes match the input term (or the Index is empty). If the Index is configured
+// with a maximum number of results and the search yields more than that, the capped results are returned alongside an
+// ErrResultTruncated error.
+func (i *Index[K, V]) SearchExcept(ctx context.Context, excludeTerm V) ([]Attribute[K, V], error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	rows, err := i.db.QueryContext(ctx, searchExceptQuery, excludeTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr Attribute[K, V], err error) {
+		return attr, rows.Scan(&attr.Key, &attr.Value)
+	})
+	if err != nil {
+		return res, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: NOT %v", ErrNotFoundKeyword, excludeTerm)
+	}
+
+	return res, nil
+}
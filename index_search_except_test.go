@@ -0,0 +1,65 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchExcept(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold in the old copper mine"},
+		{Key: 3, Value: "gold gold gold"},
+		{Key: 4, Value: "probably bronze"},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		query string
+		wants []Attribute[int, string]
+		err   error
+	}{
+		{
+			name:  "Success/ExcludeGold",
+			query: "gold",
+			wants: []Attribute[int, string]{
+				{Key: 1, Value: "some data"},
+				{Key: 4, Value: "probably bronze"},
+			},
+		},
+		{
+			name:  "Success/NoMatches",
+			query: "silver",
+			wants: attrs,
+		},
+		{
+			name:  "Fail/AllMatch",
+			query: "gold OR data OR bronze",
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchExcept(ctx, testcase.query)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package fts
+
+import (
+	"context"
+	"errors"
+)
+
+// SearchGrouped runs a Search for the input term, and groups the matching Attribute by the string returned by the input
+// groupFn for each key (e.g. a category prefix in the key), such as for faceted results. Within each group, the results
+// keep the order they are returned by Search.
+//
+// A nil groupFn places all results under an empty-string group.
+//
+// This call returns an error if the underlying search fails, or an ErrNotFoundKeyword error if there are zero results
+// from the query. If the Index is configured with a maximum number of results and the search yields more than that,
+// the capped results are grouped and returned alongside an ErrResultTruncated error.
+func (i *Index[K, V]) SearchGrouped(
+	ctx context.Context, searchTerm V, groupFn func(K) string,
+) (map[string][]Attribute[K, V], error) {
+	res, err := i.Search(ctx, searchTerm)
+	if err != nil && !errors.Is(err, ErrResultTruncated) {
+		return nil, err
+	}
+
+	groups := make(map[string][]Attribute[K, V])
+
+	for idx := range res {
+		var group string
+		if groupFn != nil {
+			group = groupFn(res[idx].Key)
+		}
+
+		groups[group] = append(groups[group], res[idx])
+	}
+
+	return groups, err
+}
@@ -0,0 +1,90 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchGrouped(t *testing.T) {
+	attrs := []Attribute[string, string]{
+		{Key: "docs/install", Value: "install the gold release"},
+		{Key: "blog/launch", Value: "we struck gold"},
+		{Key: "docs/faq", Value: "is it gold plated"},
+		{Key: "blog/update", Value: "silver lining"},
+		{Key: "changelog", Value: "gold support"},
+	}
+
+	prefix := func(key string) string {
+		if category, _, ok := strings.Cut(key, "/"); ok {
+			return category
+		}
+
+		return ""
+	}
+
+	for _, testcase := range []struct {
+		name    string
+		term    string
+		groupFn func(string) string
+		wants   map[string][]Attribute[string, string]
+		err     error
+	}{
+		{
+			name:    "Success/ByPrefix",
+			term:    "gold",
+			groupFn: prefix,
+			wants: map[string][]Attribute[string, string]{
+				"docs": {
+					{Key: "docs/install", Value: "install the gold release"},
+					{Key: "docs/faq", Value: "is it gold plated"},
+				},
+				"blog": {
+					{Key: "blog/launch", Value: "we struck gold"},
+				},
+				"": {
+					{Key: "changelog", Value: "gold support"},
+				},
+			},
+		},
+		{
+			name: "Success/NilGroupFn",
+			term: "silver",
+			wants: map[string][]Attribute[string, string]{
+				"": {
+					{Key: "blog/update", Value: "silver lining"},
+				},
+			},
+		},
+		{
+			name:    "Fail/NotFound",
+			term:    "copper",
+			groupFn: prefix,
+			err:     ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchGrouped(ctx, testcase.term, testcase.groupFn)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	// minInfixLength is the minimum length of an infix search, as the trigram tokenizer only matches substrings of at
+	// least three characters.
+	minInfixLength = 3
+
+	checkInfixTableExists = `
+SELECT EXISTS(SELECT 1 FROM sqlite_master
+	WHERE type='table'
+	AND name='fulltext_infix');
+`
+
+	createInfixTableQuery = `
+CREATE VIRTUAL TABLE fulltext_infix
+	USING FTS5(val, content='fulltext_content', content_rowid='seq', tokenize='trigram');
+`
+
+	createInfixInsertTriggerQuery = `
+CREATE TRIGGER fulltext_infix_insert AFTER INSERT ON fulltext_values BEGIN
+	INSERT INTO fulltext_infix (rowid, val)
+		VALUES (new.seq, %[1]s);
+END;
+`
+
+	createInfixDeleteTriggerQuery = `
+CREATE TRIGGER fulltext_infix_delete AFTER DELETE ON fulltext_values BEGIN
+	INSERT INTO fulltext_infix (fulltext_infix, rowid, val)
+		VALUES ('delete', old.seq, %[1]s);
+END;
+`
+
+	createInfixUpdateTriggerQuery = `
+CREATE TRIGGER fulltext_infix_update AFTER UPDATE ON fulltext_values BEGIN
+	INSERT INTO fulltext_infix (fulltext_infix, rowid, val)
+		VALUES ('delete', old.seq, %[1]s);
+	INSERT INTO fulltext_infix (rowid, val)
+		VALUES (new.seq, %[2]s);
+END;
+`
+
+	searchInfixQuery = `
+SELECT id, val FROM fulltext_content
+	WHERE seq IN (SELECT rowid FROM fulltext_infix(?))
+	ORDER BY seq;
+`
+)
+
+// SearchInfix will look for the attributes containing the input substring anywhere within their indexed text,
+// including in the middle of a word (e.g. "old" matches "gold"), case-insensitively; returning a collection of
+// matching Attribute, in insertion order.
+//
+// The substring is matched literally, as a phrase, so FTS5 query syntax in it is not interpreted. The Index must be
+// configured with WithInfixSearch, which keeps a trigram index of its values (see WithInfixSearch for its size cost).
+//
+// This call returns an ErrInvalidInfix error if the Index has no trigram index, an ErrQueryTooShort error if the
+// substring is shorter than three characters (which the trigram tokenizer can't match), an error if the underlying SQL
+// query fails or if scanning for the results fails, or an ErrNotFoundKeyword error if there are zero results from the
+// query. If the Index is configured with a maximum number of results and the search yields more than that, the capped
+// results are returned alongside an ErrResultTruncated error.
+func (i *Index[K, V]) SearchInfix(ctx context.Context, substring string) ([]Attribute[K, V], error) {
+	if !i.store.infix {
+		return nil, fmt.Errorf("%w: the Index is not configured with WithInfixSearch", ErrInvalidInfix)
+	}
+
+	if n := utf8.RuneCountInString(substring); n < minInfixLength {
+		return nil, fmt.Errorf("%w: %q has %d characters, below the minimum of %d for infix searches",
+			ErrQueryTooShort, substring, n, minInfixLength)
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	rows, err := i.db.QueryContext(ctx, searchInfixQuery, quotePhrase(substring))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr Attribute[K, V], err error) {
+		return attr, rows.Scan(&attr.Key, &attr.Value)
+	})
+	if err != nil && !errors.Is(err, ErrResultTruncated) {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFoundKeyword, substring)
+	}
+
+	return res, err
+}
+
+// infixTableQueries returns the queries creating the fulltext_infix table, a trigram index over the same text as the
+// fulltext_search table; and the triggers keeping it in sync with the fulltext_values table.
+func infixTableQueries(store storage) []string {
+	return []string{
+		createInfixTableQuery,
+		fmt.Sprintf(createInfixInsertTriggerQuery, store.indexed("new")),
+		fmt.Sprintf(createInfixDeleteTriggerQuery, store.indexed("old")),
+		fmt.Sprintf(createInfixUpdateTriggerQuery, store.indexed("old"), store.indexed("new")),
+	}
+}
+
+// checkInfix verifies that an existing database has a trigram index if and only if the input infix setting is true,
+// returning an ErrInvalidInfix error otherwise.
+func checkInfix(ctx context.Context, db *sql.DB, infix bool) error {
+	var exists bool
+	if err := db.QueryRowContext(ctx, checkInfixTableExists).Scan(&exists); err != nil {
+		return err
+	}
+
+	switch {
+	case exists && !infix:
+		return fmt.Errorf("%w: database has an infix index, configured without it", ErrInvalidInfix)
+	case !exists && infix:
+		return fmt.Errorf("%w: database has no infix index, configured with it", ErrInvalidInfix)
+	default:
+		return nil
+	}
+}
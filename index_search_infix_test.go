@@ -0,0 +1,111 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_SearchInfix(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "struck gold"},
+		{Key: 2, Value: "some data"},
+		{Key: 3, Value: "GOLDEN \"age\""},
+	}
+
+	for _, testcase := range []struct {
+		name      string
+		substring string
+		wants     []Attribute[int, string]
+		err       error
+	}{
+		{
+			name:      "Success/WithinWord",
+			substring: "old",
+			wants:     []Attribute[int, string]{attrs[0], attrs[2]},
+		},
+		{
+			name:      "Success/AcrossWords",
+			substring: "me da",
+			wants:     []Attribute[int, string]{attrs[1]},
+		},
+		{
+			name:      "Success/Literal",
+			substring: `"age"`,
+			wants:     []Attribute[int, string]{attrs[2]},
+		},
+		{
+			name:      "Fail/TooShort",
+			substring: "ol",
+			err:       ErrQueryTooShort,
+		},
+		{
+			name:      "Fail/NotFound",
+			substring: "silver",
+			err:       ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New(
+				WithURI(filepath.Join(t.TempDir(), "index.db")),
+				WithInfixSearch(),
+			), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchInfix(ctx, testcase.substring)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestIndex_SearchInfixSync(t *testing.T) {
+	ctx := context.Background()
+	uri := filepath.Join(t.TempDir(), "index.db")
+
+	index, err := newIndex[int, string](cfg.New(WithURI(uri), WithInfixSearch()),
+		Attribute[int, string]{Key: 1, Value: "struck gold"},
+	)
+	require.NoError(t, err)
+
+	// the default tokenizer only matches whole words (or their prefixes)
+	_, err = index.Search(ctx, "old")
+	require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+	res, err := index.SearchInfix(ctx, "old")
+	require.NoError(t, err)
+	require.Equal(t, []Attribute[int, string]{{Key: 1, Value: "struck gold"}}, res)
+
+	// the trigram index follows deletes
+	require.NoError(t, index.Delete(ctx, 1))
+
+	_, err = index.SearchInfix(ctx, "old")
+	require.ErrorIs(t, err, ErrNotFoundKeyword)
+	require.NoError(t, index.Shutdown(ctx))
+
+	// the trigram index is part of the schema
+	_, err = NewIndex[int, string](uri)
+	require.ErrorIs(t, err, ErrInvalidInfix)
+
+	plain, err := NewIndex[int, string](filepath.Join(t.TempDir(), "plain.db"))
+	require.NoError(t, err)
+
+	_, err = plain.SearchInfix(ctx, "old")
+	require.ErrorIs(t, err, ErrInvalidInfix)
+	require.NoError(t, plain.Shutdown(ctx))
+}
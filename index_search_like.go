@@ -0,0 +1,104 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// querySyntaxErrors lists the messages of the SQLite errors reporting that FTS5 failed to parse a query expression.
+var querySyntaxErrors = []string{
+	"fts5: syntax error",
+	"unterminated string",
+	"no such column",
+	"unknown special query",
+}
+
+// isQuerySyntaxError returns true if the input error is a SQLite error reporting a malformed FTS5 query expression.
+func isQuerySyntaxError(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.Code()&0xff != sqlite3.SQLITE_ERROR {
+		return false
+	}
+
+	msg := sqliteErr.Error()
+
+	for _, syntaxErr := range querySyntaxErrors {
+		if strings.Contains(msg, syntaxErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// likeTerm strips the FTS5 operators from the input search term, returning the remaining text with its whitespace
+// collapsed.
+func likeTerm(searchTerm string) string {
+	return strings.Join(strings.Fields(queryOperators.ReplaceAllString(searchTerm, " ")), " ")
+}
+
+// likeQuery builds the SQL query and its arguments for a substring LIKE search with these SearchOpts, for the input
+// (stripped) search term. Highlighting is not supported, and results ordered by rank are sorted in insertion order.
+func (o SearchOpts) likeQuery(term string) (string, []any) {
+	var sb strings.Builder
+
+	sb.WriteString("SELECT id")
+
+	if o.IncludeValue {
+		sb.WriteString(", val")
+	}
+
+	sb.WriteString(` FROM fulltext_search WHERE val LIKE ? ESCAPE '\'`)
+
+	switch {
+	case o.OrderBy != nil:
+		sb.WriteString(o.OrderBy.clause())
+	case o.Order != OrderNone:
+		sb.WriteString(" ORDER BY rowid")
+	}
+
+	limit, offset := o.Limit, o.Offset
+	if limit <= 0 {
+		limit = -1
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	sb.WriteString(" LIMIT ? OFFSET ?;")
+
+	return sb.String(), []any{"%" + escapeLike(term) + "%", limit, offset}
+}
+
+// queryLike executes a substring LIKE search for the input search term and SearchOpts over the input queryer, scanning
+// its results; as a fallback for search terms that FTS5 fails to parse. A search term without any text left once its
+// operators are stripped yields no results.
+func (i *Index[K, V]) queryLike(
+	ctx context.Context, q queryer, searchTerm string, opts SearchOpts,
+) ([]Attribute[K, V], error) {
+	term := likeTerm(searchTerm)
+	if term == "" {
+		return nil, nil
+	}
+
+	query, args := opts.likeQuery(term)
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr Attribute[K, V], err error) {
+		if opts.IncludeValue {
+			return attr, rows.Scan(&attr.Key, &attr.Value)
+		}
+
+		return attr, rows.Scan(&attr.Key)
+	})
+}
@@ -0,0 +1,99 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_FallbackToLike(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "SKU-0042 gold bar"},
+		{Key: 2, Value: "silver ring"},
+		{Key: 3, Value: "old (Gold) coin"},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		opts  []cfg.Option[Config]
+		query string
+		wants []Attribute[int, string]
+		err   error
+		fails bool
+	}{
+		{
+			name:  "Success/UnterminatedString",
+			opts:  []cfg.Option[Config]{WithFallbackToLike()},
+			query: `"gold`,
+			wants: []Attribute[int, string]{attrs[0], attrs[2]},
+		},
+		{
+			name:  "Success/DanglingOperator",
+			opts:  []cfg.Option[Config]{WithFallbackToLike()},
+			query: "silver AND",
+			wants: []Attribute[int, string]{attrs[1]},
+		},
+		{
+			name:  "Success/Substring",
+			opts:  []cfg.Option[Config]{WithFallbackToLike()},
+			query: "(SKU-00",
+			wants: []Attribute[int, string]{attrs[0]},
+		},
+		{
+			name:  "Success/WithQueryValidation",
+			opts:  []cfg.Option[Config]{WithFallbackToLike(), WithQueryValidation()},
+			query: "(gold",
+			wants: []Attribute[int, string]{attrs[0], attrs[2]},
+		},
+		{
+			name:  "Success/ValidQueryUsesFTS",
+			opts:  []cfg.Option[Config]{WithFallbackToLike()},
+			query: "ring",
+			wants: []Attribute[int, string]{attrs[1]},
+		},
+		{
+			name:  "Fail/OnlyOperators",
+			opts:  []cfg.Option[Config]{WithFallbackToLike()},
+			query: `"(`,
+			err:   ErrNotFoundKeyword,
+		},
+		{
+			name:  "Fail/NoFallback",
+			query: `"gold`,
+			fails: true,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New(
+				append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...,
+			), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.Search(ctx, testcase.query)
+
+			switch {
+			case testcase.fails:
+				require.Error(t, err)
+				require.True(t, isQuerySyntaxError(err))
+
+				return
+			case testcase.err != nil:
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.ElementsMatch(t, testcase.wants, res)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// SearchMap2 will look for matches for the input value through the indexed terms of the input Index, returning a
+// collection of the values produced by the input mapper for each matching row. It allows scanning the results into
+// types that the SQLType constraint can't express, such as domain types implementing sql.Scanner.
+//
+// Each row holds the matching key and its (full) value, in this order (as in Search); and the mapper is expected to
+// scan them from the input rows, without advancing or closing them. As with Search, the search term is checked,
+// rewritten and validated as configured in the Index; however, the Index's result transformers do not apply to the
+// mapped results.
+//
+// This call returns an error if the query is too short or invalid, if the underlying SQL query fails, if the mapper
+// fails on any row, or an ErrNotFoundKeyword error if there are zero results from the query. If the Index is configured
+// with a maximum number of results and the search yields more than that, the capped results are returned alongside an
+// ErrResultTruncated error.
+func SearchMap2[K SQLType, V SQLType, T any](
+	ctx context.Context, idx *Index[K, V], searchTerm V, mapper func(*sql.Rows) (T, error),
+) ([]T, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	searchTerm, err := idx.prepareQuery(ctx, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args := SearchOpts{IncludeValue: true}.query(searchTerm, idx.store.boosts)
+
+	rows, err := idx.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, idx.maxResults, mapper)
+	if err != nil && !errors.Is(err, ErrResultTruncated) {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	return res, err
+}
@@ -0,0 +1,99 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// title is a domain type implementing sql.Scanner, outside of the SQLType constraint.
+type title string
+
+func (t *title) Scan(src any) error {
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("unexpected title type %T", src)
+	}
+
+	*t = title(strings.ToUpper(s[:1]) + s[1:])
+
+	return nil
+}
+
+type document struct {
+	ID    int
+	Title title
+}
+
+func TestSearchMap2(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "gold rush"},
+		{Key: 2, Value: "struck gold"},
+		{Key: 3, Value: "silver lining"},
+	}
+
+	errMapper := errors.New("mapper failed")
+
+	for _, testcase := range []struct {
+		name   string
+		query  string
+		mapper func(*sql.Rows) (document, error)
+		wants  []document
+		err    error
+	}{
+		{
+			name:  "Success",
+			query: "gold",
+			mapper: func(rows *sql.Rows) (doc document, err error) {
+				return doc, rows.Scan(&doc.ID, &doc.Title)
+			},
+			wants: []document{
+				{ID: 1, Title: "Gold rush"},
+				{ID: 2, Title: "Struck gold"},
+			},
+		},
+		{
+			name:  "Fail/Mapper",
+			query: "gold",
+			mapper: func(*sql.Rows) (document, error) {
+				return document{}, errMapper
+			},
+			err: errMapper,
+		},
+		{
+			name:  "Fail/NotFound",
+			query: "copper",
+			mapper: func(rows *sql.Rows) (doc document, err error) {
+				return doc, rows.Scan(&doc.ID, &doc.Title)
+			},
+			err: ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := SearchMap2(ctx, index, testcase.query, testcase.mapper)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.ElementsMatch(t, testcase.wants, res)
+		})
+	}
+}
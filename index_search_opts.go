@@ -0,0 +1,246 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Order defines how the results of a search are sorted.
+type Order int
+
+const (
+	// OrderNone returns the results in the order they are yielded by SQLite, without sorting them.
+	OrderNone Order = iota
+	// OrderRank sorts the results by relevance, as computed by the FTS5 rank (best match first). Matches with the same
+	// rank are sorted in insertion order, so that the order of the results (and their pages) is stable.
+	OrderRank
+	// OrderSequence sorts the results in insertion order, by their sequence number (the table's rowid), which is
+	// assigned in increasing order on Insert and is preserved across updates and VACUUM.
+	OrderSequence
+)
+
+// HighlightOpts defines the markers placed around each matched term in a highlighted value.
+type HighlightOpts struct {
+	Open  string
+	Close string
+}
+
+// SearchOpts defines the optional settings of a search, as used in SearchWithOpts.
+//
+// The zero value of SearchOpts searches for all matches, unordered and without their values. The Search method uses
+// the same settings with IncludeValue set to true.
+type SearchOpts struct {
+	// Limit caps the number of returned results. A limit of zero or below means no limit.
+	Limit int
+	// Offset skips the first results of the search. A negative offset is treated as zero.
+	Offset int
+	// IncludeValue sets whether the values are returned in the results; otherwise only the keys are set.
+	IncludeValue bool
+	// Order sets how the results are sorted. If the Index is configured with WithDocumentBoosts, OrderRank accounts for
+	// the boost of each attribute.
+	Order Order
+	// OrderBy, when set, sorts the results by one of the Index's metadata columns (see OrderByMetadata), overriding
+	// Order.
+	OrderBy *MetadataOrder
+	// Highlight, when set, returns the values with each matched term wrapped in the configured markers, using FTS5's
+	// highlight() function. The highlighted value is returned as text, so it should only be used with character type
+	// values (string, []byte or []rune). It has no effect if IncludeValue is false.
+	Highlight *HighlightOpts
+	// Stats, when set, is populated with the SearchStats of the search once it is done (including when it finds no
+	// matches). Setting it costs an additional query, counting the scanned rows.
+	Stats *SearchStats
+}
+
+// query builds the SQL query and its arguments for a search with these SearchOpts, for the input search term. If
+// boosted is true, the results ordered by rank are ordered by their BM25 score minus their boost in the fulltext_values
+// table.
+func (o SearchOpts) query(searchTerm any, boosted bool) (string, []any) {
+	var (
+		sb   strings.Builder
+		args = make([]any, 0, 5)
+	)
+
+	sb.WriteString("SELECT id")
+
+	switch {
+	case !o.IncludeValue:
+	case o.Highlight != nil:
+		sb.WriteString(", highlight(fulltext_search, 1, ?, ?)")
+		args = append(args, o.Highlight.Open, o.Highlight.Close)
+	default:
+		sb.WriteString(", val")
+	}
+
+	sb.WriteString(" FROM fulltext_search(?)")
+	args = append(args, searchTerm)
+
+	switch {
+	case o.OrderBy != nil:
+		sb.WriteString(o.OrderBy.clause())
+	case o.Order == OrderRank && boosted:
+		sb.WriteString(" ORDER BY bm25(fulltext_search) - " +
+			"(SELECT boost FROM fulltext_values WHERE seq = fulltext_search.rowid), rowid")
+	case o.Order == OrderRank:
+		sb.WriteString(" ORDER BY rank, rowid")
+	case o.Order == OrderSequence:
+		sb.WriteString(" ORDER BY rowid")
+	}
+
+	limit, offset := o.Limit, o.Offset
+	if limit <= 0 {
+		limit = -1
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	sb.WriteString(" LIMIT ? OFFSET ?;")
+	args = append(args, limit, offset)
+
+	return sb.String(), args
+}
+
+// SearchWithOpts will look for matches for the input value through the indexed terms, returning a collection of
+// matching Attribute, as configured by the input SearchOpts. It allows limiting and paginating the results, sorting
+// them by relevance or by a metadata column, returning only their keys, or highlighting the matched terms in their
+// values.
+//
+// Search terms shorter than the Index's minimum query length (see WithMinQueryLength) are rejected with an
+// ErrQueryTooShort error. The search term is transformed by the Index's query rewriters (see WithQueryRewriter), if
+// any, before it is queried; and its syntax is validated if the Index is configured with WithQueryValidation. The
+// results are transformed by the Index's result transformers (see WithResultTransformer), if any, before they are
+// returned.
+//
+// This call returns an ErrInvalidMetadata error if the results are ordered by a column that is not one of the Index's
+// metadata columns, or an error if the query is too short, if a query rewriter or result transformer fails, if the
+// query is invalid, if the underlying SQL query fails (or the one counting the scanned rows, with SearchOpts.Stats), if
+// scanning for the results fails, or an ErrNotFoundKeyword error if there are zero results from the query and the
+// offset is zero. An offset past the last match returns an empty result with no error. If the Index is configured with
+// a maximum number of results and the search yields more than that, the capped results are returned alongside an
+// ErrResultTruncated error.
+func (i *Index[K, V]) SearchWithOpts(ctx context.Context, searchTerm V, opts SearchOpts) ([]Attribute[K, V], error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.search(ctx, i.db, searchTerm, opts)
+}
+
+// queryer describes the types that search queries can be executed on: either the Index's database handle, or a
+// transaction.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// search implements SearchWithOpts over the input queryer, expecting the caller to hold the Index's read lock.
+func (i *Index[K, V]) search(ctx context.Context, q queryer, searchTerm V, opts SearchOpts) ([]Attribute[K, V], error) {
+	start := time.Now()
+
+	if opts.OrderBy != nil && !slices.Contains(i.store.metadata, opts.OrderBy.Column) {
+		return nil, fmt.Errorf("%w: cannot order by unknown column %q", ErrInvalidMetadata, opts.OrderBy.Column)
+	}
+
+	searchTerm, err := i.prepareQuery(ctx, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := i.searchQuery(ctx, q, searchTerm, opts)
+
+	if opts.Stats != nil {
+		if statsErr := i.searchStats(ctx, q, searchTerm, start, res, err, opts.Stats); statsErr != nil {
+			return res, errors.Join(err, statsErr)
+		}
+	}
+
+	return res, err
+}
+
+// prepareQuery checks the input search term's length, rewrites it and validates it, as configured in the Index;
+// returning the search term to query.
+func (i *Index[K, V]) prepareQuery(ctx context.Context, searchTerm V) (V, error) {
+	if query, ok := charString(searchTerm); ok && i.minQueryLength > 0 {
+		if n := utf8.RuneCountInString(strings.TrimSpace(query)); n < i.minQueryLength {
+			return searchTerm, fmt.Errorf("%w: %q has %d characters, below the minimum of %d",
+				ErrQueryTooShort, query, n, i.minQueryLength)
+		}
+	}
+
+	searchTerm, err := i.rewriteQuery(ctx, searchTerm)
+	if err != nil {
+		return searchTerm, err
+	}
+
+	// with a LIKE fallback, malformed search terms are left for FTS5 to reject, so they are retried with a LIKE query
+	if query, ok := charString(searchTerm); ok && i.validateQueries && !i.fallbackToLike {
+		if err = ValidateQuery(query); err != nil {
+			return searchTerm, err
+		}
+	}
+
+	return searchTerm, nil
+}
+
+// searchQuery queries the input (final) search term over the input queryer, returning its transformed results.
+func (i *Index[K, V]) searchQuery(
+	ctx context.Context, q queryer, searchTerm V, opts SearchOpts,
+) ([]Attribute[K, V], error) {
+	res, err := i.cachedQuery(ctx, q, searchTerm, opts)
+	if err != nil && !errors.Is(err, ErrResultTruncated) {
+		return res, err
+	}
+
+	if len(res) == 0 && opts.Offset <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	transformed, transformErr := i.transformResults(ctx, res)
+	if transformErr != nil {
+		return nil, transformErr
+	}
+
+	return transformed, err
+}
+
+// query executes the search query for the input search term and SearchOpts over the input queryer, scanning its
+// results. If the Index is configured with WithFallbackToLike and FTS5 fails to parse the search term, it is retried
+// as a substring LIKE query.
+func (i *Index[K, V]) query(ctx context.Context, q queryer, searchTerm V, opts SearchOpts) ([]Attribute[K, V], error) {
+	query, args := opts.query(searchTerm, i.store.boosts)
+
+	res, err := i.scanQuery(ctx, q, query, args, opts)
+	if err == nil || !i.fallbackToLike || !isQuerySyntaxError(err) {
+		return res, err
+	}
+
+	if term, ok := charString(searchTerm); ok {
+		return i.queryLike(ctx, q, term, opts)
+	}
+
+	return res, err
+}
+
+// scanQuery executes the input search query and arguments over the input queryer, scanning its results as configured
+// in the input SearchOpts.
+func (i *Index[K, V]) scanQuery(
+	ctx context.Context, q queryer, query string, args []any, opts SearchOpts,
+) ([]Attribute[K, V], error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr Attribute[K, V], err error) {
+		if opts.IncludeValue {
+			return attr, rows.Scan(&attr.Key, &attr.Value)
+		}
+
+		return attr, rows.Scan(&attr.Key)
+	})
+}
@@ -0,0 +1,171 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchWithOpts(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold in the old copper mine"},
+		{Key: 3, Value: "gold gold gold"},
+		{Key: 4, Value: "probably bronze"},
+		{Key: 5, Value: "good ol' gold plate"},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		query string
+		opts  SearchOpts
+		wants []Attribute[int, string]
+		err   error
+	}{
+		{
+			name:  "Success/Defaults/KeysOnly",
+			query: "gold",
+			wants: []Attribute[int, string]{
+				{Key: 2}, {Key: 3}, {Key: 5},
+			},
+		},
+		{
+			name:  "Success/IncludeValue",
+			query: "gold",
+			opts:  SearchOpts{IncludeValue: true},
+			wants: []Attribute[int, string]{
+				{Key: 2, Value: "struck gold in the old copper mine"},
+				{Key: 3, Value: "gold gold gold"},
+				{Key: 5, Value: "good ol' gold plate"},
+			},
+		},
+		{
+			name:  "Success/OrderRank",
+			query: "gold",
+			opts:  SearchOpts{Order: OrderRank},
+			wants: []Attribute[int, string]{
+				{Key: 3}, {Key: 5}, {Key: 2},
+			},
+		},
+		{
+			name:  "Success/Limit",
+			query: "gold",
+			opts:  SearchOpts{Limit: 2},
+			wants: []Attribute[int, string]{
+				{Key: 2}, {Key: 3},
+			},
+		},
+		{
+			name:  "Success/LimitAndOffset",
+			query: "gold",
+			opts:  SearchOpts{Limit: 2, Offset: 2},
+			wants: []Attribute[int, string]{
+				{Key: 5},
+			},
+		},
+		{
+			name:  "Success/OrderRankWithLimitAndOffset",
+			query: "gold",
+			opts:  SearchOpts{Limit: 1, Offset: 1, Order: OrderRank, IncludeValue: true},
+			wants: []Attribute[int, string]{
+				{Key: 5, Value: "good ol' gold plate"},
+			},
+		},
+		{
+			name:  "Success/OffsetPastEnd",
+			query: "gold",
+			opts:  SearchOpts{Offset: 10},
+			wants: []Attribute[int, string]{},
+		},
+		{
+			name:  "Success/Highlight",
+			query: "gold",
+			opts: SearchOpts{
+				IncludeValue: true,
+				Order:        OrderRank,
+				Limit:        2,
+				Highlight:    &HighlightOpts{Open: "<b>", Close: "</b>"},
+			},
+			wants: []Attribute[int, string]{
+				{Key: 3, Value: "<b>gold</b> <b>gold</b> <b>gold</b>"},
+				{Key: 5, Value: "good ol' <b>gold</b> plate"},
+			},
+		},
+		{
+			name:  "Success/HighlightWithoutValue",
+			query: "gold",
+			opts: SearchOpts{
+				Limit:     1,
+				Highlight: &HighlightOpts{Open: "<b>", Close: "</b>"},
+			},
+			wants: []Attribute[int, string]{
+				{Key: 2},
+			},
+		},
+		{
+			name:  "Fail/NoResults",
+			query: "silver",
+			opts:  SearchOpts{IncludeValue: true},
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchWithOpts(ctx, testcase.query, testcase.opts)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestIndex_SearchWithOpts_RankTiebreaker(t *testing.T) {
+	ctx := context.Background()
+
+	// identical values share the same rank, so their order is only set by the tiebreaker
+	attrs := make([]Attribute[int, string], 0, 20)
+	wants := make([]int, 0, 20)
+
+	for idx := 0; idx < 20; idx++ {
+		key := (idx * 7) % 20
+		attrs = append(attrs, Attribute[int, string]{Key: key, Value: "struck gold"})
+		wants = append(wants, key)
+	}
+
+	index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	for run := 0; run < 3; run++ {
+		keys := make([]int, 0, len(wants))
+
+		for offset := 0; offset < len(wants); offset += 6 {
+			res, err := index.SearchWithOpts(ctx, "gold", SearchOpts{Order: OrderRank, Limit: 6, Offset: offset})
+			require.NoError(t, err)
+
+			for idx := range res {
+				keys = append(keys, res[idx].Key)
+			}
+		}
+
+		require.Equal(t, wants, keys)
+	}
+}
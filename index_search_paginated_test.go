@@ -0,0 +1,104 @@
+package fts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchPaginated(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "gold rush"},
+		{Key: 2, Value: "struck gold"},
+		{Key: 3, Value: "silver lining"},
+		{Key: 4, Value: "gold plated"},
+		{Key: 5, Value: "golden gold"},
+	}
+
+	for _, testcase := range []struct {
+		name   string
+		query  string
+		limit  int
+		offset int
+		wants  []int
+		err    error
+	}{
+		{
+			name:  "Success/FirstPage",
+			query: "gold",
+			limit: 2,
+			wants: []int{1, 2},
+		},
+		{
+			name:   "Success/LastPage",
+			query:  "gold",
+			limit:  2,
+			offset: 2,
+			wants:  []int{4, 5},
+		},
+		{
+			name:  "Success/NoLimit",
+			query: "gold",
+			wants: []int{1, 2, 4, 5},
+		},
+		{
+			name:   "Success/NegativeLimitWithOffset",
+			query:  "gold",
+			limit:  -1,
+			offset: 1,
+			wants:  []int{2, 4, 5},
+		},
+		{
+			name:   "Success/OffsetPastTheEnd",
+			query:  "gold",
+			limit:  2,
+			offset: 10,
+			wants:  []int{},
+		},
+		{
+			name:  "Fail/NoResults",
+			query: "copper",
+			limit: 2,
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			buf := &bytes.Buffer{}
+			indexer := IndexerWithLogs[int, string](index, slog.NewTextHandler(buf, nil))
+
+			res, err := indexer.SearchPaginated(ctx, testcase.query, testcase.limit, testcase.offset)
+
+			// the logged Indexer records the pagination, and passes it through to the Index
+			require.Contains(t, buf.String(), fmt.Sprintf("limit=%d offset=%d", testcase.limit, testcase.offset))
+
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			keys := make([]int, 0, len(res))
+			for i := range res {
+				keys = append(keys, res[i].Key)
+			}
+
+			require.Equal(t, testcase.wants, keys)
+		})
+	}
+}
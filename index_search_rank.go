@@ -0,0 +1,109 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+const searchScoresQuery = `
+SELECT id, val, -bm25(fulltext_search) FROM fulltext_search(?)
+	ORDER BY rank, rowid;
+`
+
+// SearchAboveRank will look for matches for the input value through the indexed terms, returning a collection of
+// matching Attribute sorted by relevance (best match first), discarding the matches whose normalized relevance score
+// is below the input minScore. This trims the long tail of barely-relevant matches in broad searches.
+//
+// The scores are the (inverted) BM25 scores of each match, as computed by FTS5's bm25() auxiliary function, normalized
+// against the best match: the best match scores 1.0, and a match half as relevant scores 0.5. This means that the
+// best match is always returned, and that a minScore of zero or below keeps all matches.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query. If the Index is configured with a maximum number
+// of results and the search yields more than that, only the best matches are scored, and the capped results are
+// returned alongside an ErrResultTruncated error.
+func (i *Index[K, V]) SearchAboveRank(ctx context.Context, searchTerm V, minScore float64) ([]Attribute[K, V], error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	rows, err := i.db.QueryContext(ctx, searchScoresQuery, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (m weightedMatch[K, V], err error) {
+		return m, rows.Scan(&m.attr.Key, &m.attr.Value, &m.score)
+	})
+	if err != nil && !errors.Is(err, ErrResultTruncated) {
+		return nil, err
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	best := matches[0].score
+	res := make([]Attribute[K, V], 0, len(matches))
+
+	for idx := range matches {
+		if idx > 0 && best > 0 && matches[idx].score/best < minScore {
+			break
+		}
+
+		res = append(res, matches[idx].attr)
+	}
+
+	return res, err
+}
+
+// ScoredAttribute is an Attribute returned from a ranked search, alongside its relevance score (see SearchRanked).
+type ScoredAttribute[K SQLType, V SQLType] struct {
+	Attribute[K, V]
+
+	// Score is the relevance of the match: its BM25 score, as computed by FTS5's bm25() auxiliary function, inverted so
+	// that higher scores are better matches. Scores are not normalized, so they are only comparable within the same
+	// search.
+	Score float64
+}
+
+// SearchRanked will look for matches for the input value through the indexed terms, returning a collection of
+// matching ScoredAttribute sorted by relevance (best match first), each carrying its relevance score.
+//
+// FTS5's bm25() function returns lower (negative) scores for better matches; these are inverted, but not normalized,
+// into each ScoredAttribute's Score, so that higher scores are better matches (see SearchAboveRank for normalized
+// scores). As with Search, the search term is checked, rewritten and validated as configured in the Index; however,
+// the Index's result transformers do not apply to these results.
+//
+// This call returns an error if the query is too short or invalid, if the underlying SQL query fails, if scanning for
+// the results fails, or an ErrNotFoundKeyword error if there are zero results from the query. If the Index is
+// configured with a maximum number of results and the search yields more than that, only the best matches are
+// returned, alongside an ErrResultTruncated error.
+func (i *Index[K, V]) SearchRanked(ctx context.Context, searchTerm V) ([]ScoredAttribute[K, V], error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	searchTerm, err := i.prepareQuery(ctx, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := i.db.QueryContext(ctx, searchScoresQuery, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr ScoredAttribute[K, V], err error) {
+		return attr, rows.Scan(&attr.Key, &attr.Value, &attr.Score)
+	})
+	if err != nil && !errors.Is(err, ErrResultTruncated) {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	return res, err
+}
@@ -0,0 +1,127 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchAboveRank(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "gold gold gold"},
+		{Key: 2, Value: "a long story about mining towns, railroads, saloons and, somewhere in the middle, gold"},
+		{Key: 3, Value: "some data"},
+	}
+
+	for _, testcase := range []struct {
+		name     string
+		query    string
+		minScore float64
+		wants    []Attribute[int, string]
+		err      error
+	}{
+		{
+			name:     "Success/HighThreshold",
+			query:    "gold",
+			minScore: 0.9,
+			wants:    []Attribute[int, string]{attrs[0]},
+		},
+		{
+			name:     "Success/NoThreshold",
+			query:    "gold",
+			minScore: 0,
+			wants:    []Attribute[int, string]{attrs[0], attrs[1]},
+		},
+		{
+			name:     "Success/BestMatchAlwaysReturned",
+			query:    "gold",
+			minScore: 2,
+			wants:    []Attribute[int, string]{attrs[0]},
+		},
+		{
+			name:     "Fail/NotFound",
+			query:    "silver",
+			minScore: 0.5,
+			err:      ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchAboveRank(ctx, testcase.query, testcase.minScore)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestIndex_SearchRanked(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "a long story about mining towns, railroads, saloons and, somewhere in the middle, gold"},
+		{Key: 2, Value: "gold gold gold"},
+		{Key: 3, Value: "some data"},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		query string
+		wants []Attribute[int, string]
+		err   error
+	}{
+		{
+			name:  "Success/BestMatchFirst",
+			query: "gold",
+			wants: []Attribute[int, string]{attrs[1], attrs[0]},
+		},
+		{
+			name:  "Fail/NotFound",
+			query: "silver",
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchRanked(ctx, testcase.query)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, res, len(testcase.wants))
+
+			for idx := range res {
+				require.Equal(t, testcase.wants[idx], res[idx].Attribute)
+				require.Positive(t, res[idx].Score)
+
+				if idx > 0 {
+					require.Greater(t, res[idx-1].Score, res[idx].Score)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const searchRecentQuery = `
+SELECT fulltext_search.id, fulltext_search.val FROM fulltext_search
+	JOIN fulltext_values ON fulltext_values.seq = fulltext_search.rowid
+	WHERE fulltext_search MATCH ?
+	AND fulltext_values.inserted >= ?
+	ORDER BY fulltext_search.rowid DESC;
+`
+
+// SearchRecentWindow will look for matches for the input value through the indexed terms, returning a collection of
+// matching Attribute that were inserted within the input time window (that is, at or after the current time minus
+// since), newest first. The full-text match and the recency filter are applied within the same query.
+//
+// The Index must be configured with WithInsertTimestamps, which records the time each value is inserted. As with
+// SearchWithOpts, the search term is checked, rewritten and validated as configured in the Index, and the results are
+// transformed by the Index's result transformers, if any.
+//
+// This call returns an ErrInvalidTimestamps error if the Index does not record insertion times, an error if the query
+// is too short or invalid, if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results within the window. If the Index is configured with a maximum
+// number of results and the search yields more than that, the capped results are returned alongside an
+// ErrResultTruncated error.
+func (i *Index[K, V]) SearchRecentWindow(
+	ctx context.Context, searchTerm V, since time.Duration,
+) ([]Attribute[K, V], error) {
+	if !i.store.timestamps {
+		return nil, fmt.Errorf("%w: the Index is not configured with WithInsertTimestamps", ErrInvalidTimestamps)
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	searchTerm, err := i.prepareQuery(ctx, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := i.db.QueryContext(ctx, searchRecentQuery, searchTerm, i.clock().Add(-since).UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr Attribute[K, V], err error) {
+		return attr, rows.Scan(&attr.Key, &attr.Value)
+	})
+	if err != nil && !errors.Is(err, ErrResultTruncated) {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	transformed, transformErr := i.transformResults(ctx, res)
+	if transformErr != nil {
+		return nil, transformErr
+	}
+
+	return transformed, err
+}
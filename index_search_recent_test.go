@@ -0,0 +1,128 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_SearchRecentWindow(t *testing.T) {
+	old := Attribute[int, string]{Key: 1, Value: "gold from the old mine"}
+	recent := Attribute[int, string]{Key: 2, Value: "gold from the new mine"}
+
+	for _, testcase := range []struct {
+		name  string
+		query string
+		since time.Duration
+		wants []Attribute[int, string]
+		err   error
+	}{
+		{
+			name:  "Success/ExcludesOldMatches",
+			query: "gold",
+			since: time.Minute,
+			wants: []Attribute[int, string]{recent},
+		},
+		{
+			name:  "Success/WideWindow",
+			query: "gold",
+			since: 2 * time.Hour,
+			wants: []Attribute[int, string]{recent, old},
+		},
+		{
+			name:  "Fail/OnlyOldMatches",
+			query: "old",
+			since: time.Minute,
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New(
+				WithURI(filepath.Join(t.TempDir(), "index.db")),
+				WithInsertTimestamps(),
+			), old)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			// backdate the old attribute's insertion by an hour
+			_, err = index.db.ExecContext(ctx,
+				"UPDATE fulltext_values SET inserted = inserted - 3600000 WHERE id = ?;", old.Key)
+			require.NoError(t, err)
+
+			require.NoError(t, index.Insert(ctx, recent))
+
+			res, err := index.SearchRecentWindow(ctx, testcase.query, testcase.since)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestIndex_SearchRecentWindowWithoutTimestamps(t *testing.T) {
+	ctx := context.Background()
+	uri := filepath.Join(t.TempDir(), "index.db")
+
+	index, err := NewIndex(uri, Attribute[int, string]{Key: 1, Value: "gold"})
+	require.NoError(t, err)
+
+	_, err = index.SearchRecentWindow(ctx, "gold", time.Hour)
+	require.ErrorIs(t, err, ErrInvalidTimestamps)
+	require.NoError(t, index.Shutdown(ctx))
+
+	// insertion times are part of the schema
+	_, err = newIndex[int, string](cfg.New(WithURI(uri), WithInsertTimestamps()))
+	require.ErrorIs(t, err, ErrInvalidTimestamps)
+}
+
+func TestIndex_SearchRecentWindowWithClock(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	index, err := newIndex[int, string](cfg.New(
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithInsertTimestamps(),
+		WithClock(clock),
+	), Attribute[int, string]{Key: 1, Value: "gold from the old mine"})
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	var inserted int64
+	require.NoError(t, index.db.QueryRowContext(ctx, "SELECT inserted FROM fulltext_values WHERE id = 1;").Scan(&inserted))
+	require.Equal(t, now.UnixMilli(), inserted)
+
+	res, err := index.SearchRecentWindow(ctx, "gold", time.Hour)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	// advancing the clock past the window expires the old attribute, without sleeping
+	now = now.Add(time.Hour + time.Millisecond)
+
+	_, err = index.SearchRecentWindow(ctx, "gold", time.Hour)
+	require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+	require.NoError(t, index.Insert(ctx, Attribute[int, string]{Key: 2, Value: "gold from the new mine"}))
+
+	res, err = index.SearchRecentWindow(ctx, "gold", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []Attribute[int, string]{{Key: 2, Value: "gold from the new mine"}}, res)
+}
@@ -0,0 +1,45 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SafeSearch will look for the values containing all the input words, matched literally: each word is escaped as an
+// FTS5 phrase and joined with AND, so that FTS5 operators, prefixes and column filters in the input are never
+// interpreted. For example, a `gold*` word matches the `gold` token (or the `gold*` token, if `*` is configured as a
+// token character with WithTokenChars) rather than any token starting with `gold`.
+//
+// This provides an injection-proof search for untrusted input, alongside the expression-aware Search; so, unlike
+// Search, the words are not transformed by the Index's query rewriters nor validated. The results are still
+// transformed by the Index's result transformers, if any.
+//
+// This call returns an ErrInvalidQuery error if there are no (non-blank) words or if the Index's values are not of a
+// character type (string, []byte or []rune); an error if the underlying SQL query fails or if scanning for the results
+// fails; or an ErrNotFoundKeyword error if there are zero results from the query.
+func (i *Index[K, V]) SafeSearch(ctx context.Context, words ...string) ([]Attribute[K, V], error) {
+	phrases := make([]string, 0, len(words))
+
+	for _, word := range words {
+		if strings.TrimSpace(word) == "" {
+			continue
+		}
+
+		phrases = append(phrases, quotePhrase(word))
+	}
+
+	if len(phrases) == 0 {
+		return nil, fmt.Errorf("%w: no words to search for", ErrInvalidQuery)
+	}
+
+	searchTerm, ok := charValue[V](strings.Join(phrases, " "+opAnd+" "))
+	if !ok {
+		return nil, fmt.Errorf("%w: %T values do not support safe searches", ErrInvalidQuery, searchTerm)
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.searchQuery(ctx, i.db, searchTerm, SearchOpts{IncludeValue: true})
+}
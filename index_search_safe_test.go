@@ -0,0 +1,102 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_SafeSearch(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "struck gold"},
+		{Key: 2, Value: "golden hour"},
+		{Key: 3, Value: "gold* rush"},
+		{Key: 4, Value: "silver OR bronze"},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		opts  []cfg.Option[Config]
+		words []string
+		wants []Attribute[int, string]
+		err   error
+	}{
+		{
+			name:  "Success/NoPrefixQuery",
+			words: []string{"gold*"},
+			wants: []Attribute[int, string]{attrs[0], attrs[2]},
+		},
+		{
+			name:  "Success/LiteralAsterisk",
+			opts:  []cfg.Option[Config]{WithTokenChars("*")},
+			words: []string{"gold*"},
+			wants: []Attribute[int, string]{attrs[2]},
+		},
+		{
+			name:  "Success/OperatorsAreWords",
+			words: []string{"silver", "OR"},
+			wants: []Attribute[int, string]{attrs[3]},
+		},
+		{
+			name:  "Success/WordsJoinedWithAnd",
+			words: []string{"gold", "rush"},
+			wants: []Attribute[int, string]{attrs[2]},
+		},
+		{
+			name:  "Success/UnbalancedSyntax",
+			words: []string{`"gold`, "(rush"},
+			wants: []Attribute[int, string]{attrs[2]},
+		},
+		{
+			name:  "Fail/NoWords",
+			words: []string{"", "  "},
+			err:   ErrInvalidQuery,
+		},
+		{
+			name:  "Fail/NotFound",
+			words: []string{"gold", "silver"},
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](
+				cfg.New(append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...),
+				attrs...,
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SafeSearch(ctx, testcase.words...)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.ElementsMatch(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestIndex_SafeSearch_NonCharValues(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := newIndex[int, int](cfg.New(WithURI(filepath.Join(t.TempDir(), "index.db"))))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	_, err = index.SafeSearch(ctx, "1")
+	require.ErrorIs(t, err, ErrInvalidQuery)
+}
@@ -0,0 +1,93 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+const (
+	defaultSnippetTokens = 16
+	// maxSnippetTokens is the maximum number of tokens in a snippet, as supported by FTS5's snippet() function.
+	maxSnippetTokens = 64
+
+	searchWithSnippetQuery = `
+SELECT id, val, snippet(fulltext_search, 1, ?, ?, ?, ?)
+	FROM fulltext_search(?)
+	ORDER BY rank, rowid;
+`
+)
+
+// SnippetOpts defines how the snippets of a search are built, as used in SearchWithSnippet.
+type SnippetOpts struct {
+	// Open and Close are the markers placed around each matched term in the snippet (e.g. "<b>" and "</b>"). Empty
+	// markers leave the matched terms as-is.
+	Open  string
+	Close string
+	// Ellipsis is placed where the snippet truncates the value, at its start or end (e.g. "…").
+	Ellipsis string
+	// MaxTokens is the maximum number of tokens in the snippet. A value of zero or below defaults to 16, and values
+	// above 64 (the maximum supported by FTS5) are capped to 64.
+	MaxTokens int
+}
+
+// SnippetAttribute is an Attribute returned from a search, alongside a snippet of its value: the fragment of the value
+// that best matches the search, with its matched terms wrapped in markers (see SearchWithSnippet).
+type SnippetAttribute[K SQLType, V SQLType] struct {
+	Attribute[K, V]
+
+	Snippet string
+}
+
+// SearchWithSnippet will look for matches for the input value through the indexed terms, returning a collection of
+// matching SnippetAttribute sorted by relevance (best match first), which contain the key and (full) value for that
+// match, as well as a snippet of its value built by FTS5's snippet() function as configured in the input SnippetOpts.
+//
+// The snippet is built from the value as text, so this call should only be used with character type values (string,
+// []byte or []rune): the snippets of []byte values are built from their content as (UTF-8) text, and the snippets of
+// numeric values from their text representation. As with Search, the search term is checked, rewritten and validated as
+// configured in the Index; however, the Index's result transformers do not apply to these results.
+//
+// This call returns an error if the query is too short or invalid, if the underlying SQL query fails, if scanning for
+// the results fails, or an ErrNotFoundKeyword error if there are zero results from the query. If the Index is
+// configured with a maximum number of results and the search yields more than that, only the best matches are
+// returned, alongside an ErrResultTruncated error.
+func (i *Index[K, V]) SearchWithSnippet(
+	ctx context.Context, searchTerm V, opts SnippetOpts,
+) ([]SnippetAttribute[K, V], error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	searchTerm, err := i.prepareQuery(ctx, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := opts.MaxTokens
+
+	switch {
+	case tokens <= 0:
+		tokens = defaultSnippetTokens
+	case tokens > maxSnippetTokens:
+		tokens = maxSnippetTokens
+	}
+
+	rows, err := i.db.QueryContext(ctx, searchWithSnippetQuery, opts.Open, opts.Close, opts.Ellipsis, tokens, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (attr SnippetAttribute[K, V], err error) {
+		return attr, rows.Scan(&attr.Key, &attr.Value, &attr.Snippet)
+	})
+	if err != nil && !errors.Is(err, ErrResultTruncated) {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	return res, err
+}
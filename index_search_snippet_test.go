@@ -0,0 +1,73 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchWithSnippet(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "a long story about mining towns, railroads and saloons, where someone struck gold at last"},
+		{Key: 2, Value: "silver lining"},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		query string
+		opts  SnippetOpts
+		wants string
+		err   error
+	}{
+		{
+			name:  "Success/Markers",
+			query: "gold",
+			opts:  SnippetOpts{Open: "<b>", Close: "</b>", Ellipsis: "...", MaxTokens: 4},
+			wants: "<b>gold</b>",
+		},
+		{
+			name:  "Success/WholeValue",
+			query: "silver",
+			opts:  SnippetOpts{Open: "[", Close: "]"},
+			wants: "[silver] lining",
+		},
+		{
+			name:  "Fail/NoResults",
+			query: "copper",
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchWithSnippet(ctx, testcase.query, testcase.opts)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			require.Len(t, res, 1)
+			require.Equal(t, attrs[res[0].Key-1], res[0].Attribute)
+			require.Contains(t, res[0].Snippet, testcase.wants)
+
+			// a truncated value starts with the ellipsis, and is capped to the maximum number of tokens
+			if testcase.opts.MaxTokens > 0 {
+				require.True(t, strings.HasPrefix(res[0].Snippet, testcase.opts.Ellipsis))
+				require.LessOrEqual(t, len(strings.Fields(res[0].Snippet)), testcase.opts.MaxTokens)
+			}
+		})
+	}
+}
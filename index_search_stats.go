@@ -0,0 +1,61 @@
+package fts
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const searchScannedQuery = `
+SELECT count(*) FROM fulltext_search(?);
+`
+
+// SearchStats describes the cost and selectivity of a search, as reported by SearchWithOpts when its SearchOpts are
+// set with a SearchStats pointer. It helps tuning search terms, limits and pagination.
+type SearchStats struct {
+	// Scanned is the number of rows matched by the full-text query, before applying the SearchOpts' limit and offset
+	// (and the Index's maximum number of results). FTS5 doesn't expose how many rows it reads internally, so this is
+	// the closest measure of the work done by the search; counted with an additional query. It is left at zero when
+	// the search falls back to a LIKE query (see WithFallbackToLike).
+	Scanned int
+	// Returned is the number of returned results.
+	Returned int
+	// Duration is the time taken by the search, excluding the query counting the scanned rows.
+	Duration time.Duration
+}
+
+// searchStats sets the input SearchStats for a search of the input (final) search term that started at the input time
+// and returned the input results and error. The scanned rows are only counted if the search succeeded (or was
+// truncated); since there are none if it found no matches.
+func (i *Index[K, V]) searchStats(
+	ctx context.Context, q queryer, searchTerm V, start time.Time, res []Attribute[K, V], err error, stats *SearchStats,
+) error {
+	*stats = SearchStats{
+		Returned: len(res),
+		Duration: time.Since(start),
+	}
+
+	if err != nil && !errors.Is(err, ErrResultTruncated) {
+		return nil
+	}
+
+	rows, err := q.QueryContext(ctx, searchScannedQuery, searchTerm)
+	if err != nil {
+		// the search fell back to a LIKE query, which leaves no full-text matches to count
+		if i.fallbackToLike && isQuerySyntaxError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = rows.Scan(&stats.Scanned); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,71 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchStats(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "gold"},
+		{Key: 2, Value: "gold and silver"},
+		{Key: 3, Value: "gold nugget"},
+		{Key: 4, Value: "silver"},
+	}
+
+	for _, testcase := range []struct {
+		name          string
+		query         string
+		opts          SearchOpts
+		wantsScanned  int
+		wantsReturned int
+		err           error
+	}{
+		{
+			name:          "AllMatches",
+			query:         "gold",
+			wantsScanned:  3,
+			wantsReturned: 3,
+		},
+		{
+			name:          "Limited",
+			query:         "gold",
+			opts:          SearchOpts{Limit: 1, Order: OrderRank},
+			wantsScanned:  3,
+			wantsReturned: 1,
+		},
+		{
+			name:  "NotFound",
+			query: "bronze",
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			stats := &SearchStats{Scanned: -1, Returned: -1}
+			testcase.opts.Stats = stats
+
+			_, err = index.SearchWithOpts(ctx, testcase.query, testcase.opts)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, testcase.wantsScanned, stats.Scanned)
+			require.Equal(t, testcase.wantsReturned, stats.Returned)
+			require.Positive(t, stats.Duration)
+		})
+	}
+}
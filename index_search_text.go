@@ -0,0 +1,28 @@
+package fts
+
+// insertArgs returns the arguments of the Index's insert query for the input Attribute, which include the search text
+// extracted from its value if the Index is configured with a search text extractor, and the current time (as read from
+// the Index's clock) if it records insertion times.
+func (i *Index[K, V]) insertArgs(attr Attribute[K, V]) []any {
+	args := []any{attr.Key, attr.Value}
+
+	if i.extract != nil {
+		args = append(args, i.extract(attr.Value))
+	}
+
+	if i.store.timestamps {
+		args = append(args, i.clock().UnixMilli())
+	}
+
+	return args
+}
+
+// updateIfArgs returns the arguments of the Index's compare-and-swap query, which include the search text extracted
+// from the new value if the Index is configured with a search text extractor.
+func (i *Index[K, V]) updateIfArgs(key K, expected, value V) []any {
+	if i.extract == nil {
+		return []any{value, key, expected}
+	}
+
+	return []any{value, key, expected, i.extract(value)}
+}
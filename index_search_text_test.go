@@ -0,0 +1,128 @@
+package fts
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_WithSearchTextExtractor(t *testing.T) {
+	title := func(value string) string {
+		var doc struct {
+			Title string `json:"title"`
+		}
+
+		if err := json.Unmarshal([]byte(value), &doc); err != nil {
+			return ""
+		}
+
+		return doc.Title
+	}
+
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: `{"title":"Gold rush","body":"silver and copper"}`},
+		{Key: 2, Value: `{"title":"Silver lining","body":"struck gold"}`},
+		{Key: 3, Value: `{"title":"Copper wire","body":"bronze age"}`},
+	}
+
+	for _, testcase := range []struct {
+		name string
+		opts []cfg.Option[Config]
+	}{
+		{name: "Plain"},
+		{name: "Compressed", opts: []cfg.Option[Config]{WithValueCompression(GzipCodec())}},
+		{name: "KeyCollation", opts: []cfg.Option[Config]{WithKeyCollation("NOCASE")}},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](cfg.New(append(testcase.opts,
+				WithURI(filepath.Join(t.TempDir(), "index.db")),
+				WithSearchTextExtractor(title),
+			)...), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			// only the title is searchable, while the original value is returned
+			res, err := index.Search(ctx, "gold")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{attrs[0]}, res)
+
+			_, err = index.Search(ctx, "bronze")
+			require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+			swapped, err := index.UpdateIf(ctx, 3, attrs[2].Value, `{"title":"Bronze statue","body":"copper wire"}`)
+			require.NoError(t, err)
+			require.True(t, swapped)
+
+			res, err = index.Search(ctx, "bronze")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{
+				{Key: 3, Value: `{"title":"Bronze statue","body":"copper wire"}`},
+			}, res)
+
+			_, err = index.Search(ctx, "copper")
+			require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+			require.NoError(t, index.Delete(ctx, 1))
+
+			_, err = index.Search(ctx, "gold")
+			require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+			res, err = index.Search(ctx, "silver")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{attrs[1]}, res)
+		})
+	}
+}
+
+func TestIndex_WithSearchTextExtractor_Invalid(t *testing.T) {
+	extractor := WithSearchTextExtractor(func(value string) string { return value })
+
+	t.Run("Fail/ValueTypeMismatch", func(t *testing.T) {
+		_, err := newIndex[int, []byte](cfg.New(
+			WithURI(filepath.Join(t.TempDir(), "index.db")),
+			extractor,
+		))
+		require.ErrorIs(t, err, ErrInvalidExtractor)
+	})
+
+	for _, testcase := range []struct {
+		name   string
+		create []cfg.Option[Config]
+		open   []cfg.Option[Config]
+	}{
+		{
+			name:   "Fail/OpenedWithoutExtractor",
+			create: []cfg.Option[Config]{extractor},
+		},
+		{
+			name: "Fail/OpenedWithExtractor",
+			open: []cfg.Option[Config]{extractor},
+		},
+		{
+			name:   "Fail/CompressedOpenedWithExtractor",
+			create: []cfg.Option[Config]{WithValueCompression(GzipCodec())},
+			open:   []cfg.Option[Config]{WithValueCompression(GzipCodec()), extractor},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "index.db")
+
+			index, err := newIndex[int, string](cfg.New(append(testcase.create, WithURI(path))...))
+			require.NoError(t, err)
+			require.NoError(t, index.Shutdown(ctx))
+
+			_, err = newIndex[int, string](cfg.New(append(testcase.open, WithURI(path))...))
+			require.ErrorIs(t, err, ErrInvalidExtractor)
+		})
+	}
+}
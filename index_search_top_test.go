@@ -0,0 +1,204 @@
+package fts
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_SearchTop(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold in the old copper mine"},
+		{Key: 3, Value: "gold gold gold"},
+		{Key: 4, Value: "probably bronze"},
+		{Key: 5, Value: "good ol' gold plate"},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		query string
+		n     int
+		wants []int
+		err   error
+	}{
+		{
+			name:  "Success/TopOne",
+			query: "gold",
+			n:     1,
+			wants: []int{3},
+		},
+		{
+			name:  "Success/TopTwo",
+			query: "gold",
+			n:     2,
+			wants: []int{3, 5},
+		},
+		{
+			name:  "Success/NoLimit",
+			query: "gold",
+			n:     0,
+			wants: []int{3, 5, 2},
+		},
+		{
+			name:  "Fail/NoResults",
+			query: "silver",
+			n:     10,
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchTop(ctx, testcase.query, testcase.n)
+			if err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			keys := make([]int, 0, len(res))
+			for i := range res {
+				keys = append(keys, res[i].Key)
+			}
+
+			require.Equal(t, testcase.wants, keys)
+		})
+	}
+}
+
+// unrankedScoresQuery reads back all matches with their (inverted) BM25 scores, without sorting them.
+const unrankedScoresQuery = `SELECT id, val, -bm25(fulltext_search) FROM fulltext_search(?);`
+
+func newBenchmarkIndex(b *testing.B, size int) *Index[int, string] {
+	attrs := make([]Attribute[int, string], 0, size)
+	for i := 0; i < size; i++ {
+		attrs = append(attrs, Attribute[int, string]{
+			Key:   i,
+			Value: fmt.Sprintf("entry number %d with some gold and some filler text to tokenize", i),
+		})
+	}
+
+	index, err := NewIndex(filepath.Join(b.TempDir(), "index.db"), attrs...)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Cleanup(func() {
+		_ = index.Shutdown(context.Background())
+	})
+
+	return index
+}
+
+func BenchmarkIndex_SearchTop(b *testing.B) {
+	const (
+		corpusSize = 50_000
+		top        = 10
+	)
+
+	index := newBenchmarkIndex(b, corpusSize)
+	ctx := context.Background()
+
+	b.Run("SearchTop", func(b *testing.B) {
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			res, err := index.SearchTop(ctx, "gold", top)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			if len(res) != top {
+				b.Fatalf("unexpected number of results: %d", len(res))
+			}
+		}
+	})
+
+	// all matches are read back with their scores, and sorted by score before being truncated
+	b.Run("SearchAndTruncate", func(b *testing.B) {
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			rows, err := index.db.QueryContext(ctx, unrankedScoresQuery, "gold")
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			res, err := scanRows(rows, 0, func(rows *sql.Rows) (attr ScoredAttribute[int, string], err error) {
+				return attr, rows.Scan(&attr.Key, &attr.Value, &attr.Score)
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			slices.SortStableFunc(res, func(a, b ScoredAttribute[int, string]) int {
+				return cmp.Compare(b.Score, a.Score)
+			})
+
+			res = res[:top]
+
+			if len(res) != top {
+				b.Fatalf("unexpected number of results: %d", len(res))
+			}
+		}
+	})
+}
+
+func BenchmarkIndex_SearchTop_TempStore(b *testing.B) {
+	const corpusSize = 50_000
+
+	attrs := make([]Attribute[int, string], 0, corpusSize)
+	for i := 0; i < corpusSize; i++ {
+		attrs = append(attrs, Attribute[int, string]{
+			Key:   i,
+			Value: fmt.Sprintf("entry number %d with some gold and some filler text to tokenize", i),
+		})
+	}
+
+	ctx := context.Background()
+
+	for _, mode := range []string{tempStoreFile, tempStoreMemory} {
+		b.Run(mode, func(b *testing.B) {
+			index, err := newIndex[int, string](cfg.New(
+				WithURI(filepath.Join(b.TempDir(), "index.db")),
+				WithTempStore(mode),
+			), attrs...)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.Cleanup(func() {
+				_ = index.Shutdown(ctx)
+			})
+
+			b.ResetTimer()
+
+			// ranking all matches sorts the whole result set
+			for i := 0; i < b.N; i++ {
+				res, err := index.SearchTop(ctx, "gold", 0)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				if len(res) != corpusSize {
+					b.Fatalf("unexpected number of results: %d", len(res))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// searchWithTotalQuery joins the page of matches onto their total count, so that both are read in a single statement;
+// where an empty page (such as with an offset past the last match) yields a single row with the total and a NULL
+// rowid.
+const searchWithTotalQuery = `
+SELECT c.total, p.rowid, p.id, p.val FROM (SELECT count(*) AS total FROM fulltext_search(?1)) AS c
+	LEFT JOIN (SELECT rowid, id, val FROM fulltext_search(?1) LIMIT ?2 OFFSET ?3) AS p;
+`
+
+// SearchWithTotal will look for matches for the input value through the indexed terms, returning a page of matching
+// Attribute as delimited by the input limit and offset, as well as the total number of matches for the search term.
+//
+// Both the page and the total are retrieved in a single statement (joining the page onto the count of all matches), so
+// they are always consistent with each other, even under concurrent writes. The total is counted independently of the
+// page, so an offset past the last match returns an empty page with the total number of matches, and no error. A limit
+// of zero or below means no limit, and a negative offset is treated as zero.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query and the offset is zero. If the Index is configured
+// with a maximum number of results and the page is larger than that, the capped page is returned alongside an
+// ErrResultTruncated error.
+func (i *Index[K, V]) SearchWithTotal(
+	ctx context.Context, searchTerm V, limit, offset int,
+) (res []Attribute[K, V], total int, err error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if limit <= 0 {
+		limit = -1
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := i.db.QueryContext(ctx, searchWithTotalQuery, searchTerm, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	type pageRow struct {
+		attr  Attribute[K, V]
+		rowID sql.NullInt64
+	}
+
+	page, err := scanRows(rows, i.maxResults, func(rows *sql.Rows) (row pageRow, err error) {
+		// the row is scanned twice: first to check whether it holds a match, as the key and value of an empty page are
+		// NULL, which can't be scanned into any K and V type
+		if err = rows.Scan(&total, &row.rowID, new(any), new(any)); err != nil || !row.rowID.Valid {
+			return row, err
+		}
+
+		return row, rows.Scan(&total, &row.rowID, &row.attr.Key, &row.attr.Value)
+	})
+
+	if err != nil && !errors.Is(err, ErrResultTruncated) {
+		return nil, 0, err
+	}
+
+	res = make([]Attribute[K, V], 0, len(page))
+
+	for _, row := range page {
+		if row.rowID.Valid {
+			res = append(res, row.attr)
+		}
+	}
+
+	if err != nil {
+		return res, total, err
+	}
+
+	if total == 0 && offset == 0 {
+		return nil, 0, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	return res, total, nil
+}
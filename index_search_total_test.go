@@ -0,0 +1,87 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchWithTotal(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold"},
+		{Key: 3, Value: "some kind of gold copper"},
+		{Key: 4, Value: "probably bronze"},
+		{Key: 5, Value: "good ol' gold plate"},
+		{Key: 6, Value: "gol-- gol-- gold!!"},
+	}
+
+	for _, testcase := range []struct {
+		name      string
+		query     string
+		limit     int
+		offset    int
+		wantsLen  int
+		wantTotal int
+		err       error
+	}{
+		{
+			name:      "Success/FirstPage",
+			query:     "gold",
+			limit:     2,
+			wantsLen:  2,
+			wantTotal: 4,
+		},
+		{
+			name:      "Success/LastPage",
+			query:     "gold",
+			limit:     3,
+			offset:    3,
+			wantsLen:  1,
+			wantTotal: 4,
+		},
+		{
+			name:      "Success/NoLimit",
+			query:     "gold",
+			wantsLen:  4,
+			wantTotal: 4,
+		},
+		{
+			name:      "Success/OffsetPastEnd",
+			query:     "gold",
+			limit:     2,
+			offset:    10,
+			wantTotal: 4,
+		},
+		{
+			name:  "Fail/NoResults",
+			query: "silver",
+			limit: 2,
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, total, err := index.SearchWithTotal(ctx, testcase.query, testcase.limit, testcase.offset)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, res, testcase.wantsLen)
+			require.Equal(t, testcase.wantTotal, total)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// snapshotQuery reads from the database, to start the read transaction (and take its snapshot) as soon as it begins;
+// since SQLite only does so on the first read of a deferred transaction.
+const snapshotQuery = `SELECT count(*) FROM sqlite_master;`
+
+// SearchTxn performs searches within a read transaction, as created by SearchTx, so that they all observe the same
+// snapshot of the Index's data.
+type SearchTxn[K SQLType, V SQLType] struct {
+	index *Index[K, V]
+	tx    *sql.Tx
+}
+
+// Search will look for matches for the input value through the indexed terms, within the transaction's snapshot;
+// working in the same way as the Index's Search method.
+func (t *SearchTxn[K, V]) Search(ctx context.Context, searchTerm V) ([]Attribute[K, V], error) {
+	return t.index.search(ctx, t.tx, searchTerm, SearchOpts{IncludeValue: true})
+}
+
+// SearchTx calls the input function with a SearchTxn, whose searches run within a single read transaction; so that they
+// all observe the same snapshot of the Index's data, taken when SearchTx is called, even if the Index is written to in
+// the meantime. The transaction is committed once the function returns, and the function's error is returned.
+//
+// Writes that are concurrent with the transaction only proceed on file-backed databases in WAL mode, with a private
+// cache (see WithCacheMode); otherwise, they wait for the transaction to end (or fail with a busy or locked database
+// error). The function must not write to the Index itself.
+func (i *Index[K, V]) SearchTx(ctx context.Context, fn func(tx *SearchTxn[K, V]) error) error {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	var tables int
+	if err = tx.QueryRowContext(ctx, snapshotQuery).Scan(&tables); err != nil {
+		return errors.Join(err, rollback(tx))
+	}
+
+	if err = fn(&SearchTxn[K, V]{index: i, tx: tx}); err != nil {
+		return errors.Join(err, rollback(tx))
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,73 @@
+package fts
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_SearchTx(t *testing.T) {
+	ctx := context.Background()
+
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold"},
+	}
+
+	index, err := newIndex[int, string](cfg.New(
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithCacheMode(cachePrivate),
+	), attrs...)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	_, err = index.db.ExecContext(ctx, "PRAGMA journal_mode=WAL;")
+	require.NoError(t, err)
+
+	t.Run("Success/ConsistentSnapshot", func(t *testing.T) {
+		written := make(chan error)
+
+		require.NoError(t, index.SearchTx(ctx, func(tx *SearchTxn[int, string]) error {
+			first, err := tx.Search(ctx, "gold")
+			require.NoError(t, err)
+
+			go func() {
+				written <- index.Insert(ctx, Attribute[int, string]{Key: 3, Value: "golden gold"})
+			}()
+
+			require.NoError(t, <-written)
+
+			second, err := tx.Search(ctx, "gold")
+			require.NoError(t, err)
+
+			require.Equal(t, []Attribute[int, string]{attrs[1]}, first)
+			require.Equal(t, first, second)
+
+			return nil
+		}))
+
+		// the write is visible once the transaction ends
+		res, err := index.Search(ctx, "gold")
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+	})
+
+	t.Run("Fail/FunctionError", func(t *testing.T) {
+		errSearch := errors.New("search failed")
+
+		err := index.SearchTx(ctx, func(tx *SearchTxn[int, string]) error {
+			_, err := tx.Search(ctx, "silver")
+			require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+			return errSearch
+		})
+		require.ErrorIs(t, err, errSearch)
+	})
+}
@@ -0,0 +1,103 @@
+package fts
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const searchWithinQuery = `
+SELECT rowid, id, val FROM fulltext_search(?)
+	WHERE id IN (%s);
+`
+
+// SearchWithin will look for matches for the input value through the indexed terms, like Search, only returning the
+// matches whose key is in the input allowlist (such as the keys visible to a user); so that the other matches never
+// leave the database. The results are returned in insertion order.
+//
+// The allowlist is queried in chunks of up to 998 keys per statement (with an `id IN (...)` clause), within a single
+// read transaction; so that large allowlists stay within SQLite's limit of bound parameters, and all chunks are queried
+// against the same snapshot of the Index. Keys are matched by equality with the key column, using SQLite's BINARY
+// collation. As with Search, the search term is checked, rewritten and validated as configured in the Index, and the
+// results are transformed by the Index's result transformers; however, the Index's result cache is not used.
+//
+// This call returns an error if the query is too short or invalid, if the underlying SQL query fails, if scanning for
+// the results fails, or an ErrNotFoundKeyword error if there are zero results from the query (including when the
+// allowlist is empty). If the Index is configured with a maximum number of results and the search yields more than
+// that, the capped results are returned alongside an ErrResultTruncated error.
+func (i *Index[K, V]) SearchWithin(ctx context.Context, searchTerm V, allowed []K) ([]Attribute[K, V], error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	searchTerm, err := i.prepareQuery(ctx, searchTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := i.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	type match struct {
+		rowID int64
+		attr  Attribute[K, V]
+	}
+
+	matches := make([]match, 0, minAlloc)
+
+	// one bound parameter is taken by the search term
+	for _, batch := range batches(allowed, maxDeleteBatch-1) {
+		args := make([]any, 0, len(batch)+1)
+		args = append(args, searchTerm)
+
+		for idx := range batch {
+			args = append(args, batch[idx])
+		}
+
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(searchWithinQuery, placeholders(len(batch))), args...)
+		if err != nil {
+			return nil, errors.Join(err, rollback(tx))
+		}
+
+		res, err := scanRows(rows, 0, func(rows *sql.Rows) (m match, err error) {
+			return m, rows.Scan(&m.rowID, &m.attr.Key, &m.attr.Value)
+		})
+		if err != nil {
+			return nil, errors.Join(err, rollback(tx))
+		}
+
+		matches = append(matches, res...)
+	}
+
+	if err = rollback(tx); err != nil {
+		return nil, err
+	}
+
+	// chunks are sorted back into insertion order, dropping the rows matched twice by a key repeated across chunks
+	slices.SortFunc(matches, func(a, b match) int { return cmp.Compare(a.rowID, b.rowID) })
+	matches = slices.CompactFunc(matches, func(a, b match) bool { return a.rowID == b.rowID })
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	if i.maxResults > 0 && len(matches) > i.maxResults {
+		matches, err = matches[:i.maxResults], ErrResultTruncated
+	}
+
+	res := make([]Attribute[K, V], 0, len(matches))
+	for idx := range matches {
+		res = append(res, matches[idx].attr)
+	}
+
+	transformed, transformErr := i.transformResults(ctx, res)
+	if transformErr != nil {
+		return nil, transformErr
+	}
+
+	return transformed, err
+}
@@ -0,0 +1,83 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchWithin(t *testing.T) {
+	attrs := make([]Attribute[int, string], 0, 2500)
+	for key := 1; key <= 2500; key++ {
+		attrs = append(attrs, Attribute[int, string]{Key: key, Value: "struck gold"})
+	}
+
+	attrs = append(attrs, Attribute[int, string]{Key: 3000, Value: "some data"})
+
+	// allowlist spanning several chunks, in reverse order and with a repeated key
+	large := make([]int, 0, 2001)
+	ordered := make([]int, 0, 2000)
+
+	for key := 2000; key > 0; key-- {
+		large = append(large, key)
+		ordered = append(ordered, 2001-key)
+	}
+
+	large = append(large, 1500)
+
+	for _, testcase := range []struct {
+		name    string
+		allowed []int
+		wants   []int
+		err     error
+	}{
+		{
+			name:    "Success/Allowlist",
+			allowed: []int{7, 3, 3000, 4000},
+			wants:   []int{3, 7},
+		},
+		{
+			name:    "Success/Chunks",
+			allowed: large,
+			wants:   ordered,
+		},
+		{
+			name:    "Fail/NotAllowed",
+			allowed: []int{3000, 4000},
+			err:     ErrNotFoundKeyword,
+		},
+		{
+			name: "Fail/EmptyAllowlist",
+			err:  ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchWithin(ctx, "gold", testcase.allowed)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			keys := make([]int, 0, len(res))
+			for idx := range res {
+				keys = append(keys, res[idx].Key)
+			}
+
+			require.Equal(t, testcase.wants, keys)
+		})
+	}
+}
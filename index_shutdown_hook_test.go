@@ -0,0 +1,78 @@
+package fts
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_ShutdownHooks(t *testing.T) {
+	errHook := errors.New("hook failed")
+
+	for _, testcase := range []struct {
+		name   string
+		hooks  []string
+		failOn string
+		wants  []string
+		err    error
+	}{
+		{
+			name: "Success/NoHooks",
+		},
+		{
+			name:  "Success/ReverseOrder",
+			hooks: []string{"first", "second", "third"},
+			wants: []string{"third", "second", "first"},
+		},
+		{
+			name:   "Fail/HookErrorDoesNotStopOthers",
+			hooks:  []string{"first", "second", "third"},
+			failOn: "second",
+			wants:  []string{"third", "second", "first"},
+			err:    errHook,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			calls := make([]string, 0, len(testcase.hooks))
+
+			opts := []cfg.Option[Config]{WithURI(filepath.Join(t.TempDir(), "index.db"))}
+
+			for _, name := range testcase.hooks {
+				name := name
+
+				opts = append(opts, WithShutdownHook(func(context.Context) error {
+					calls = append(calls, name)
+
+					if name == testcase.failOn {
+						return errHook
+					}
+
+					return nil
+				}))
+			}
+
+			index, err := newIndex[int, string](cfg.New(opts...))
+			require.NoError(t, err)
+
+			err = index.Shutdown(ctx)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if testcase.wants == nil {
+				require.Empty(t, calls)
+
+				return
+			}
+
+			require.Equal(t, testcase.wants, calls)
+		})
+	}
+}
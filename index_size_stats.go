@@ -0,0 +1,115 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	textBytesQuery = `
+SELECT coalesce(sum(length(CAST(val AS BLOB))), 0) FROM fulltext_search;
+`
+
+	searchTextBytesQuery = `
+SELECT coalesce(sum(length(CAST(text AS BLOB))), 0) FROM fulltext_values;
+`
+
+	tokenCountQuery = `
+SELECT coalesce(sum(cnt), 0) FROM fulltext_search_vocab
+	WHERE col = 'val';
+`
+
+	tableBytesQuery = `
+SELECT name, sum(pgsize) FROM dbstat
+	WHERE name LIKE 'fulltext\_%' ESCAPE '\'
+	GROUP BY name;
+`
+)
+
+// IndexSizeStats describes the size of an Index's data, both logical (rows, text and tokens) and on disk (per table),
+// meant for capacity planning: e.g. projecting the storage needed for ten times the data from DiskBytesPerRow.
+type IndexSizeStats struct {
+	// Rows is the number of indexed rows.
+	Rows int64 `json:"rows"`
+	// TextBytes is the total size of the indexed text, in bytes.
+	TextBytes int64 `json:"text_bytes"`
+	// Tokens is the total number of indexed tokens (counting repeated tokens in the same row).
+	Tokens int64 `json:"tokens"`
+	// AvgTokens is the average number of tokens per row.
+	AvgTokens float64 `json:"avg_tokens"`
+	// TableBytes is the size of each of the Index's tables (the FTS5 shadow tables such as fulltext_search_data and
+	// fulltext_search_idx, and the fulltext_values table with external content), in bytes, as the sum of their pages.
+	TableBytes map[string]int64 `json:"table_bytes"`
+	// DiskBytes is the total size of the Index's tables, in bytes.
+	DiskBytes int64 `json:"disk_bytes"`
+}
+
+// DiskBytesPerRow returns the average size on disk of each row, or zero if there are no rows.
+func (s IndexSizeStats) DiskBytesPerRow() float64 {
+	if s.Rows == 0 {
+		return 0
+	}
+
+	return float64(s.DiskBytes) / float64(s.Rows)
+}
+
+// IndexSizeStats gathers the size statistics of the Index's data: its number of rows, the size of its indexed text and
+// its number of tokens, as well as the size of its tables as read from SQLite's dbstat virtual table.
+//
+// With a search text extractor (see WithSearchTextExtractor), the indexed text is the extracted search text. Gathering
+// the statistics reads all of the Index's data, so it is not meant to be called in a hot path.
+//
+// This call returns an error if any of the underlying SQL queries fail.
+func (i *Index[K, V]) IndexSizeStats(ctx context.Context) (IndexSizeStats, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	stats := IndexSizeStats{}
+
+	if err := i.db.QueryRowContext(ctx, countQuery).Scan(&stats.Rows); err != nil {
+		return IndexSizeStats{}, err
+	}
+
+	query := textBytesQuery
+	if i.store.searchText {
+		query = searchTextBytesQuery
+	}
+
+	if err := i.db.QueryRowContext(ctx, query).Scan(&stats.TextBytes); err != nil {
+		return IndexSizeStats{}, err
+	}
+
+	if err := i.db.QueryRowContext(ctx, tokenCountQuery).Scan(&stats.Tokens); err != nil {
+		return IndexSizeStats{}, err
+	}
+
+	if stats.Rows > 0 {
+		stats.AvgTokens = float64(stats.Tokens) / float64(stats.Rows)
+	}
+
+	rows, err := i.db.QueryContext(ctx, tableBytesQuery)
+	if err != nil {
+		return IndexSizeStats{}, err
+	}
+
+	type tableSize struct {
+		name  string
+		bytes int64
+	}
+
+	sizes, err := scanRows(rows, 0, func(rows *sql.Rows) (size tableSize, err error) {
+		return size, rows.Scan(&size.name, &size.bytes)
+	})
+	if err != nil {
+		return IndexSizeStats{}, err
+	}
+
+	stats.TableBytes = make(map[string]int64, len(sizes))
+
+	for idx := range sizes {
+		stats.TableBytes[sizes[idx].name] = sizes[idx].bytes
+		stats.DiskBytes += sizes[idx].bytes
+	}
+
+	return stats, nil
+}
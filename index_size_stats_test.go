@@ -0,0 +1,73 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_IndexSizeStats(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "struck gold"},
+		{Key: 2, Value: "gold and silver"},
+		{Key: 3, Value: "a silver lining"},
+	}
+
+	textBytes := 0
+	for idx := range attrs {
+		textBytes += len(attrs[idx].Value)
+	}
+
+	for _, testcase := range []struct {
+		name   string
+		opts   []cfg.Option[Config]
+		tables []string
+	}{
+		{
+			name:   "Default",
+			tables: []string{"fulltext_search_data", "fulltext_search_idx", "fulltext_search_content"},
+		},
+		{
+			name:   "ExternalContent",
+			opts:   []cfg.Option[Config]{WithInsertTimestamps()},
+			tables: []string{"fulltext_search_data", "fulltext_search_idx", "fulltext_values"},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](
+				cfg.New(append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...),
+				attrs...,
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			stats, err := index.IndexSizeStats(ctx)
+			require.NoError(t, err)
+
+			require.Equal(t, int64(len(attrs)), stats.Rows)
+			require.Equal(t, int64(textBytes), stats.TextBytes)
+			require.Equal(t, int64(8), stats.Tokens)
+			require.InDelta(t, 8.0/3.0, stats.AvgTokens, 0.001)
+
+			for _, table := range testcase.tables {
+				require.Positive(t, stats.TableBytes[table], table)
+			}
+
+			for table := range stats.TableBytes {
+				require.True(t, strings.HasPrefix(table, "fulltext_"), table)
+			}
+
+			require.Positive(t, stats.DiskBytes)
+			require.Positive(t, stats.DiskBytesPerRow())
+		})
+	}
+}
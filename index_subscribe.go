@@ -0,0 +1,174 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// subscriberBuffer is the number of events buffered for each subscriber, before further events are dropped.
+const subscriberBuffer = 64
+
+// ChangeOp defines the kind of mutation described by a ChangeEvent.
+type ChangeOp int
+
+const (
+	// ChangeInsert describes attributes inserted in the Index (with Insert, InsertBestEffort or InsertWithProgress).
+	ChangeInsert ChangeOp = iota
+	// ChangeDelete describes attributes deleted from the Index (with Delete or DeleteByRowID).
+	ChangeDelete
+	// ChangeUpdate describes attributes whose value was replaced in the Index (with Update or UpdateIf).
+	ChangeUpdate
+	// ChangeClear describes the removal of all attributes in the Index (with Clear), where Keys and RowIDs are empty.
+	ChangeClear
+)
+
+// ChangeEvent describes a committed mutation in the Index, as emitted to its subscribers (see Subscribe).
+type ChangeEvent[K SQLType] struct {
+	// Op is the kind of mutation.
+	Op ChangeOp
+	// Keys are the keys of the mutated attributes, as passed to the mutating call. For inserts, only the keys of the
+	// attributes that were actually inserted are set.
+	Keys []K
+	// RowIDs are the row IDs of the deleted attributes, for deletes by row ID (where Keys is empty).
+	RowIDs []int64
+	// Seq is the sequence number of the event, starting at 1 and increasing by one for each mutation committed in the
+	// Index since it was opened. A subscriber receiving an event whose Seq is not one past the previous event's has
+	// missed the events in between.
+	Seq uint64
+}
+
+// changeFeed fans out the Index's ChangeEvent to its subscribers. Its zero value is ready to use.
+type changeFeed[K SQLType] struct {
+	// commitMu serializes commits and their events, so that events are published in commit order.
+	commitMu sync.Mutex
+
+	mu     sync.Mutex
+	next   int
+	seq    uint64
+	subs   map[int]chan ChangeEvent[K]
+	closed bool
+}
+
+// subscribe registers a new subscriber, returning its channel and a function removing it (and closing its channel).
+func (f *changeFeed[K]) subscribe() (<-chan ChangeEvent[K], func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch := make(chan ChangeEvent[K], subscriberBuffer)
+
+	if f.closed {
+		close(ch)
+
+		return ch, func() {}
+	}
+
+	if f.subs == nil {
+		f.subs = make(map[int]chan ChangeEvent[K])
+	}
+
+	id := f.next
+	f.next++
+	f.subs[id] = ch
+
+	return ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if sub, ok := f.subs[id]; ok {
+			delete(f.subs, id)
+			close(sub)
+		}
+	}
+}
+
+// commit commits the input transaction and publishes the event built by the input function (unless it is nil), while
+// holding the feed's commit lock; so that concurrent writers publish their events in the order of their commits.
+//
+// The transaction must have performed its writes before calling commit, so that it already holds SQLite's write lock
+// and never waits for another writer while holding the commit lock.
+func (f *changeFeed[K]) commit(tx *sql.Tx, event func() ChangeEvent[K]) error {
+	f.commitMu.Lock()
+	defer f.commitMu.Unlock()
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if event != nil {
+		f.publish(event)
+	}
+
+	return nil
+}
+
+// publish sends the event built by the input function to every subscriber, without blocking: subscribers with a full
+// buffer miss the event, which shows as a gap in the Seq of the events they receive. The function is only called if
+// there are subscribers.
+func (f *changeFeed[K]) publish(event func() ChangeEvent[K]) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+
+	if len(f.subs) == 0 {
+		return
+	}
+
+	e := event()
+	e.Seq = f.seq
+
+	for _, ch := range f.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// close removes all subscribers, closing their channels; and closes the channels of any further subscribers right
+// away.
+func (f *changeFeed[K]) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for id, ch := range f.subs {
+		delete(f.subs, id)
+		close(ch)
+	}
+
+	f.closed = true
+}
+
+// Subscribe returns a channel that receives a ChangeEvent for each mutation committed in the Index from now on
+// (inserts, deletes, updates and clears), in commit order; as well as a function that unsubscribes from these events
+// and closes the channel. The subscription is also removed when the input context is done, or when the Index is shut
+// down. Commits are serialized with the publishing of their events, so that concurrent writers cannot publish their
+// events out of order.
+//
+// Each subscriber gets its own channel, buffering up to 64 events. Events are sent without blocking the writes, so a
+// slow subscriber whose buffer is full misses the events emitted until it catches up. Missed events show as a gap in
+// the events' Seq, which a subscriber should treat as a reason to resynchronize its state from the Index.
+func (i *Index[K, V]) Subscribe(ctx context.Context) (<-chan ChangeEvent[K], func()) {
+	ch, unsubscribe := i.changes.subscribe()
+
+	var once sync.Once
+	done := make(chan struct{})
+
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			unsubscribe()
+		})
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+		case <-done:
+		}
+	}()
+
+	return ch, cancel
+}
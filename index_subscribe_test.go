@@ -0,0 +1,191 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func receive[K SQLType](t *testing.T, events <-chan ChangeEvent[K]) ChangeEvent[K] {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "events channel closed")
+
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for an event")
+
+		return ChangeEvent[K]{}
+	}
+}
+
+func TestIndex_Subscribe(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := newIndex[int, string](cfg.New(WithURI(filepath.Join(t.TempDir(), "index.db"))))
+	require.NoError(t, err)
+
+	first, unsubscribeFirst := index.Subscribe(ctx)
+	second, unsubscribeSecond := index.Subscribe(ctx)
+
+	defer unsubscribeSecond()
+
+	require.NoError(t, index.Insert(ctx,
+		Attribute[int, string]{Key: 1, Value: "some data"},
+		Attribute[int, string]{Key: 2, Value: "struck gold"},
+	))
+	require.NoError(t, index.Delete(ctx, 1))
+
+	swapped, err := index.UpdateIf(ctx, 2, "struck gold", "struck silver")
+	require.NoError(t, err)
+	require.True(t, swapped)
+
+	wants := []ChangeEvent[int]{
+		{Op: ChangeInsert, Keys: []int{1, 2}, Seq: 1},
+		{Op: ChangeDelete, Keys: []int{1}, Seq: 2},
+		{Op: ChangeUpdate, Keys: []int{2}, Seq: 3},
+	}
+
+	// each subscriber receives all events, in order
+	for _, events := range []<-chan ChangeEvent[int]{first, second} {
+		for _, want := range wants {
+			require.Equal(t, want, receive(t, events))
+		}
+	}
+
+	// an unsubscribed channel is closed and receives no further events
+	unsubscribeFirst()
+
+	require.NoError(t, index.Delete(ctx, 2))
+
+	_, ok := <-first
+	require.False(t, ok)
+	require.Equal(t, ChangeEvent[int]{Op: ChangeDelete, Keys: []int{2}, Seq: 4}, receive(t, second))
+
+	// shutting down the Index closes the remaining channels
+	require.NoError(t, index.Shutdown(ctx))
+
+	_, ok = <-second
+	require.False(t, ok)
+}
+
+func TestIndex_Subscribe_ContextDone(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := newIndex[int, string](cfg.New(WithURI(filepath.Join(t.TempDir(), "index.db"))))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	subCtx, cancel := context.WithCancel(ctx)
+	events, unsubscribe := index.Subscribe(subCtx)
+
+	defer unsubscribe()
+
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the events channel to close")
+	}
+}
+
+func TestIndex_Subscribe_SlowConsumer(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := newIndex[int, string](cfg.New(WithURI(filepath.Join(t.TempDir(), "index.db"))))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	events, unsubscribe := index.Subscribe(ctx)
+
+	defer unsubscribe()
+
+	// writes never block on a subscriber that does not consume its events
+	for i := 0; i < subscriberBuffer+10; i++ {
+		require.NoError(t, index.Insert(ctx, Attribute[int, string]{Key: i, Value: "some data"}))
+	}
+
+	require.Len(t, events, subscriberBuffer)
+	require.Equal(t, ChangeEvent[int]{Op: ChangeInsert, Keys: []int{0}, Seq: 1}, receive(t, events))
+
+	// the missed events show as a gap in the sequence numbers
+	for n := 1; n < subscriberBuffer; n++ {
+		receive(t, events)
+	}
+
+	require.NoError(t, index.Insert(ctx, Attribute[int, string]{Key: subscriberBuffer + 10, Value: "some data"}))
+
+	event := receive(t, events)
+	require.Equal(t, uint64(subscriberBuffer+11), event.Seq)
+}
+
+func TestIndex_Subscribe_ConcurrentWriters(t *testing.T) {
+	const numWorkers = 32
+
+	ctx := context.Background()
+
+	index, err := newIndex[int, string](cfg.New(WithURI(filepath.Join(t.TempDir(), "index.db"))))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	events, unsubscribe := index.Subscribe(ctx)
+
+	defer unsubscribe()
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, numWorkers)
+
+	for n := 0; n < numWorkers; n++ {
+		wg.Add(1)
+
+		go func(key int) {
+			defer wg.Done()
+
+			errs <- index.Insert(ctx, Attribute[int, string]{Key: key, Value: "gold"})
+		}(n)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	// events are received in commit order, which is the order of the inserted rows
+	keys := make([]int, 0, numWorkers)
+
+	for n := 0; n < numWorkers; n++ {
+		event := receive(t, events)
+		require.Equal(t, uint64(n+1), event.Seq)
+
+		keys = append(keys, event.Keys...)
+	}
+
+	res, err := index.Search(ctx, "gold")
+	require.NoError(t, err)
+	require.Len(t, res, numWorkers)
+
+	for idx := range res {
+		require.Equal(t, keys[idx], res[idx].Key)
+	}
+}
@@ -0,0 +1,56 @@
+package fts
+
+import "context"
+
+const termsQuery = `
+SELECT DISTINCT term FROM fulltext_search_vocab
+	WHERE col = 'val'
+	AND term >= ?1
+	AND substr(term, 1, length(?1)) = ?1
+	ORDER BY term
+	LIMIT ?2;
+`
+
+// Terms lists the distinct tokens present in the indexed values, in alphabetical order, by querying the FTS5 vocabulary
+// table.
+//
+// The results can be filtered by the input prefix, where an empty prefix matches all terms. The number of returned
+// terms is capped by the input limit, where a limit of zero or below returns all (matching) terms. Since the terms are
+// returned as they are stored by the tokenizer, the prefix should also be in its tokenized form (e.g. lower-case, with
+// the default tokenizer).
+//
+// This call returns an error if the underlying SQL query fails, or if scanning for the results fails. An empty Index
+// yields an empty slice of terms.
+func (i *Index[K, V]) Terms(ctx context.Context, prefix string, limit int) ([]string, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if limit <= 0 {
+		limit = -1
+	}
+
+	rows, err := i.db.QueryContext(ctx, termsQuery, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	terms := make([]string, 0, minAlloc)
+
+	for rows.Next() {
+		var term string
+
+		if err = rows.Scan(&term); err != nil {
+			return nil, err
+		}
+
+		terms = append(terms, term)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return terms, nil
+}
@@ -0,0 +1,59 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Terms(t *testing.T) {
+	attrs := []Attribute[string, string]{
+		{Key: "one", Value: "struck gold"},
+		{Key: "two", Value: "Golden plate"},
+		{Key: "three", Value: "some gold and some copper"},
+	}
+
+	for _, testcase := range []struct {
+		name   string
+		prefix string
+		limit  int
+		wants  []string
+	}{
+		{
+			name:  "Success/AllTerms",
+			wants: []string{"and", "copper", "gold", "golden", "plate", "some", "struck"},
+		},
+		{
+			name:   "Success/WithPrefix",
+			prefix: "gol",
+			wants:  []string{"gold", "golden"},
+		},
+		{
+			name:  "Success/WithLimit",
+			limit: 2,
+			wants: []string{"and", "copper"},
+		},
+		{
+			name:   "Success/NoMatches",
+			prefix: "silver",
+			wants:  []string{},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			terms, err := index.Terms(ctx, testcase.prefix, testcase.limit)
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, terms)
+		})
+	}
+}
@@ -3,10 +3,14 @@ package fts
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
 )
 
 func TestIndex_SearchStrings(t *testing.T) {
@@ -325,3 +329,165 @@ func TestIndex_SearchSQLTypes(t *testing.T) {
 		})
 	}
 }
+
+// recordingExecer records the statements executed over the wrapped execer.
+type recordingExecer struct {
+	execer
+
+	statements []string
+}
+
+func (e *recordingExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if query == deleteMatchQuery {
+		e.statements = append(e.statements, "match")
+	} else {
+		e.statements = append(e.statements, fmt.Sprintf("in(%d)", strings.Count(query, "?")))
+	}
+
+	return e.execer.ExecContext(ctx, query, args...)
+}
+
+func TestIndex_DeleteBatches(t *testing.T) {
+	const numAttrs = 2500
+
+	all := make([]int, 0, numAttrs)
+	for i := 0; i < numAttrs; i++ {
+		all = append(all, i)
+	}
+
+	for _, testcase := range []struct {
+		name       string
+		opts       []cfg.Option[Config]
+		keys       []int
+		statements []string
+	}{
+		{
+			name:       "Default/ManyKeys",
+			keys:       all,
+			statements: []string{"in(999)", "in(999)", "in(502)"},
+		},
+		{
+			name:       "Default/FewKeys",
+			keys:       []int{3, 7},
+			statements: []string{"match", "match"},
+		},
+		{
+			name:       "Default/MissingKey",
+			keys:       []int{3, numAttrs + 1},
+			statements: []string{"match", "match", "in(1)"},
+		},
+		{
+			name:       "KeyCollation",
+			opts:       []cfg.Option[Config]{WithKeyCollation("NOCASE")},
+			keys:       []int{3, 7},
+			statements: []string{"in(2)"},
+		},
+		{
+			name:       "ExternalContent/ManyKeys",
+			opts:       []cfg.Option[Config]{WithInsertTimestamps()},
+			keys:       all,
+			statements: []string{"in(999)", "in(999)", "in(502)"},
+		},
+		{
+			name:       "ExternalContent/FewKeys",
+			opts:       []cfg.Option[Config]{WithInsertTimestamps()},
+			keys:       []int{3, 7},
+			statements: []string{"in(2)"},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			attrs := make([]Attribute[int, string], 0, numAttrs+1)
+			for i := 0; i < numAttrs; i++ {
+				attrs = append(attrs, Attribute[int, string]{Key: i, Value: fmt.Sprintf("gold entry %d", i)})
+			}
+
+			attrs = append(attrs, Attribute[int, string]{Key: numAttrs, Value: "the last gold entry"})
+
+			index, err := newIndex[int, string](
+				cfg.New(append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...),
+				attrs...,
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			tx, err := index.db.BeginTx(ctx, nil)
+			require.NoError(t, err)
+
+			rec := &recordingExecer{execer: tx}
+			require.NoError(t, index.deleteKeys(ctx, rec, testcase.keys))
+			require.NoError(t, tx.Commit())
+			require.Equal(t, testcase.statements, rec.statements)
+
+			deleted := 0
+			for _, key := range testcase.keys {
+				if key < numAttrs {
+					deleted++
+				}
+			}
+
+			count, err := index.Count(ctx)
+			require.NoError(t, err)
+			require.Equal(t, int64(numAttrs+1-deleted), count)
+
+			res, err := index.Search(ctx, "last")
+			require.NoError(t, err)
+			require.Equal(t, []Attribute[int, string]{{Key: numAttrs, Value: "the last gold entry"}}, res)
+		})
+	}
+}
+
+func TestIndex_DeleteExternalContentKeyIndex(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := newIndex[int, string](cfg.New(
+		WithURI(filepath.Join(t.TempDir(), "index.db")), WithInsertTimestamps(),
+	), Attribute[int, string]{Key: 1, Value: "gold"})
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	rows, err := index.db.QueryContext(ctx, "EXPLAIN QUERY PLAN "+fmt.Sprintf(index.deleteQuery, placeholders(2)), 1, 2)
+	require.NoError(t, err)
+
+	plan, err := scanRows(rows, 0, func(rows *sql.Rows) (detail string, err error) {
+		var id, parent, unused int
+
+		return detail, rows.Scan(&id, &parent, &unused, &detail)
+	})
+	require.NoError(t, err)
+	require.Contains(t, strings.Join(plan, "\n"), "fulltext_values_id")
+}
+
+func TestNewIndexWithOptions(t *testing.T) {
+	ctx := context.Background()
+	uri := filepath.Join(t.TempDir(), "index.db")
+
+	index, err := NewIndexWithOptions(
+		[]Attribute[int, string]{{Key: 1, Value: "struck gold"}},
+		WithURI(uri),
+		WithWriteQueue(4),
+		WithMaxResults(10),
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, uri, index.uri)
+	require.Equal(t, 10, index.maxResults)
+	require.NotNil(t, index.writes)
+
+	swapped, err := index.UpdateIf(ctx, 1, "struck gold", "struck silver")
+	require.NoError(t, err)
+	require.True(t, swapped)
+
+	res, err := index.Search(ctx, "silver")
+	require.NoError(t, err)
+	require.Equal(t, []Attribute[int, string]{{Key: 1, Value: "struck silver"}}, res)
+
+	require.NoError(t, index.Shutdown(ctx))
+}
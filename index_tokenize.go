@@ -0,0 +1,71 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const (
+	createTokenizeTableQuery = `
+CREATE VIRTUAL TABLE IF NOT EXISTS temp.fulltext_tokenize 
+	USING FTS5(val%s);
+`
+
+	createTokenizeVocabTableQuery = `
+CREATE VIRTUAL TABLE IF NOT EXISTS temp.fulltext_tokenize_vocab 
+	USING fts5vocab(temp, fulltext_tokenize, instance);
+`
+
+	clearTokenizeTableQuery  = `DELETE FROM temp.fulltext_tokenize;`
+	insertTokenizeTableQuery = `INSERT INTO temp.fulltext_tokenize (val) VALUES (?);`
+
+	tokensQuery = `
+SELECT term FROM temp.fulltext_tokenize_vocab
+	ORDER BY offset;
+`
+)
+
+// Tokenize returns the tokens that the Index's tokenizer (as configured with WithTokenizer, or with WithPorterStemmer,
+// WithTokenChars and WithSeparators) splits the input value into, in order; as they would be indexed and matched. This
+// helps with debugging why a value does (or doesn't) match a search term.
+//
+// The value is tokenized by inserting it in a temporary FTS5 table with the same tokenizer, on a dedicated connection,
+// and reading its tokens through an fts5vocab table; so it never touches the Index's data.
+//
+// This call returns an error if the underlying SQL queries fail, or if scanning for the tokens fails.
+func (i *Index[K, V]) Tokenize(ctx context.Context, value string) ([]string, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	// temporary tables are only visible to the connection that creates them
+	conn, err := i.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	for _, query := range []string{
+		fmt.Sprintf(createTokenizeTableQuery, i.tokenizer.spec()),
+		createTokenizeVocabTableQuery,
+		clearTokenizeTableQuery,
+	} {
+		if _, err = conn.ExecContext(ctx, query); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err = conn.ExecContext(ctx, insertTokenizeTableQuery, value); err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, tokensQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRows(rows, 0, func(rows *sql.Rows) (token string, err error) {
+		return token, rows.Scan(&token)
+	})
+}
@@ -0,0 +1,68 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_Tokenize(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		opts  []cfg.Option[Config]
+		value string
+		wants []string
+	}{
+		{
+			name:  "Default",
+			value: "Struck GOLD-plate, running",
+			wants: []string{"struck", "gold", "plate", "running"},
+		},
+		{
+			name:  "Porter",
+			opts:  []cfg.Option[Config]{WithPorterStemmer()},
+			value: "Struck GOLD-plate, running",
+			wants: []string{"struck", "gold", "plate", "run"},
+		},
+		{
+			name:  "TokenChars",
+			opts:  []cfg.Option[Config]{WithTokenChars("-")},
+			value: "Struck GOLD-plate, running",
+			wants: []string{"struck", "gold-plate", "running"},
+		},
+		{
+			name:  "Empty",
+			value: "",
+			wants: []string{},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[int, string](
+				cfg.New(append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...),
+				Attribute[int, string]{Key: 1, Value: "some data"},
+			)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			// tokenizing twice on the same connection reuses the temporary tables
+			for n := 0; n < 2; n++ {
+				tokens, err := index.Tokenize(ctx, testcase.value)
+				require.NoError(t, err)
+				require.Equal(t, testcase.wants, tokens)
+			}
+
+			// the Index's data is unchanged
+			res, err := index.Search(ctx, "data")
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+		})
+	}
+}
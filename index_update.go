@@ -0,0 +1,66 @@
+package fts
+
+import (
+	"context"
+	"errors"
+)
+
+// Update replaces the values of the attributes matching the input Attribute's keys with their new values. For each
+// attribute, the rows matching its key are deleted (as in Delete) and its new value is inserted (as in Insert), so a
+// key without a matching attribute is simply inserted. If the same key is set more than once, the last value is kept.
+//
+// All attributes are updated within a single database transaction, so that searches never observe a key deleted but
+// not yet re-inserted. The updated attributes are stored as new rows: they are sorted after the existing ones, their
+// insertion time is the time of the update, and any boost or metadata previously stored for them is not kept (unlike
+// with UpdateIf). Attributes with a NULL value are rejected or skipped, as in Insert; a skipped attribute is left
+// unchanged in the Index.
+//
+// If the context is canceled while the transaction is open, it is rolled back and the context's error is returned; so
+// that none of the attributes are updated. If the Index is configured with a write queue, the call is enqueued and
+// blocks until it is processed.
+func (i *Index[K, V]) Update(ctx context.Context, attrs ...Attribute[K, V]) error {
+	return i.write(ctx, func(ctx context.Context) error {
+		return i.update(ctx, attrs...)
+	})
+}
+
+func (i *Index[K, V]) update(ctx context.Context, attrs ...Attribute[K, V]) error {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]K, 0, len(attrs))
+
+	for idx := range attrs {
+		if err = ctx.Err(); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		skip, err := i.checkNullValue(attrs[idx])
+		if err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		if skip {
+			continue
+		}
+
+		if err = i.deleteKeys(ctx, tx, []K{attrs[idx].Key}); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		if _, err = tx.ExecContext(ctx, i.insertQuery, i.insertArgs(attrs[idx])...); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		keys = append(keys, attrs[idx].Key)
+	}
+
+	return i.changes.commit(tx, func() ChangeEvent[K] {
+		return ChangeEvent[K]{Op: ChangeUpdate, Keys: keys}
+	})
+}
@@ -0,0 +1,60 @@
+package fts
+
+import (
+	"context"
+	"errors"
+)
+
+const updateIfQuery = `
+UPDATE fulltext_search
+	SET val = ?1
+	WHERE id = ?2 AND val = ?3;
+`
+
+// UpdateIf replaces the value of the attributes matching the input key with the input value, only if their current
+// value is equal to the expected one (a compare-and-swap). It returns true if the swap happened, or false if no
+// attribute with that key holds the expected value (in which case the Index is left unchanged).
+//
+// Keys are matched in the same way as in Delete: by equality with the key column, using the Index's key collation if
+// configured with one (see WithKeyCollation); so that keys holding FTS5 query syntax are matched literally. The
+// comparison and the update are performed in a single SQL statement, so they are atomic with regard to other writes in
+// the Index.
+//
+// If the Index is configured with a write queue, the call is enqueued and blocks until it is processed.
+func (i *Index[K, V]) UpdateIf(ctx context.Context, key K, expected, value V) (swapped bool, err error) {
+	err = i.write(ctx, func(ctx context.Context) error {
+		i.mu.RLock()
+		defer i.mu.RUnlock()
+
+		tx, err := i.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, i.updateIfQuery, i.updateIfArgs(key, expected, value)...)
+		if err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		if n == 0 {
+			return rollback(tx)
+		}
+
+		if err = i.changes.commit(tx, func() ChangeEvent[K] {
+			return ChangeEvent[K]{Op: ChangeUpdate, Keys: []K{key}}
+		}); err != nil {
+			return err
+		}
+
+		swapped = true
+
+		return nil
+	})
+
+	return swapped, err
+}
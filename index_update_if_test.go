@@ -0,0 +1,121 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_UpdateIf(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold"},
+	}
+
+	for _, testcase := range []struct {
+		name     string
+		key      int
+		expected string
+		value    string
+		swapped  bool
+		wants    []Attribute[int, string]
+	}{
+		{
+			name:     "Success/Swapped",
+			key:      2,
+			expected: "struck gold",
+			value:    "struck copper",
+			swapped:  true,
+			wants: []Attribute[int, string]{
+				{Key: 1, Value: "some data"},
+				{Key: 2, Value: "struck copper"},
+			},
+		},
+		{
+			name:     "Success/MismatchedExpected",
+			key:      2,
+			expected: "struck silver",
+			value:    "struck copper",
+			wants:    attrs,
+		},
+		{
+			name:     "Success/MissingKey",
+			key:      3,
+			expected: "struck gold",
+			value:    "struck copper",
+			wants:    attrs,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			swapped, err := index.UpdateIf(ctx, testcase.key, testcase.expected, testcase.value)
+			require.NoError(t, err)
+			require.Equal(t, testcase.swapped, swapped)
+
+			res, err := index.Search(ctx, "data OR struck")
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestIndex_UpdateIfQuerySyntaxKeys(t *testing.T) {
+	attrs := []Attribute[string, string]{
+		{Key: "a-b", Value: "struck gold"},
+		{Key: "a", Value: "struck gold"},
+		{Key: "x OR y", Value: "struck gold"},
+		{Key: "x", Value: "struck gold"},
+		{Key: `say "hi"`, Value: "struck gold"},
+	}
+
+	for _, testcase := range []struct {
+		name string
+		opts []cfg.Option[Config]
+	}{
+		{
+			name: "Default",
+		},
+		{
+			name: "ExternalContent",
+			opts: []cfg.Option[Config]{WithInsertTimestamps()},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := newIndex[string, string](cfg.New(
+				append(testcase.opts, WithURI(filepath.Join(t.TempDir(), "index.db")))...,
+			), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			for _, key := range []string{"a-b", "x OR y", `say "hi"`} {
+				swapped, err := index.UpdateIf(ctx, key, "struck gold", "struck copper")
+				require.NoError(t, err)
+				require.True(t, swapped, key)
+			}
+
+			res, err := index.Search(ctx, "copper")
+			require.NoError(t, err)
+			require.ElementsMatch(t, []Attribute[string, string]{
+				{Key: "a-b", Value: "struck copper"},
+				{Key: "x OR y", Value: "struck copper"},
+				{Key: `say "hi"`, Value: "struck copper"},
+			}, res)
+		})
+	}
+}
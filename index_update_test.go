@@ -0,0 +1,86 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Update(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "some data"},
+		{Key: 2, Value: "struck gold"},
+		{Key: 2, Value: "struck silver"},
+	}
+
+	for _, testcase := range []struct {
+		name    string
+		updates []Attribute[int, string]
+		wants   []Attribute[int, string]
+	}{
+		{
+			name:    "Success/ExistingKey",
+			updates: []Attribute[int, string]{{Key: 1, Value: "some copper"}},
+			wants: []Attribute[int, string]{
+				{Key: 2, Value: "struck gold"},
+				{Key: 2, Value: "struck silver"},
+				{Key: 1, Value: "some copper"},
+			},
+		},
+		{
+			name:    "Success/DuplicateKey",
+			updates: []Attribute[int, string]{{Key: 2, Value: "struck copper"}},
+			wants: []Attribute[int, string]{
+				{Key: 1, Value: "some data"},
+				{Key: 2, Value: "struck copper"},
+			},
+		},
+		{
+			name:    "Success/MissingKey",
+			updates: []Attribute[int, string]{{Key: 3, Value: "copper wire"}},
+			wants: []Attribute[int, string]{
+				{Key: 1, Value: "some data"},
+				{Key: 2, Value: "struck gold"},
+				{Key: 2, Value: "struck silver"},
+				{Key: 3, Value: "copper wire"},
+			},
+		},
+		{
+			name: "Success/SameKeyTwice",
+			updates: []Attribute[int, string]{
+				{Key: 1, Value: "data copper"},
+				{Key: 1, Value: "data wire"},
+			},
+			wants: []Attribute[int, string]{
+				{Key: 2, Value: "struck gold"},
+				{Key: 2, Value: "struck silver"},
+				{Key: 1, Value: "data wire"},
+			},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			events, unsubscribe := index.Subscribe(ctx)
+			defer unsubscribe()
+
+			require.NoError(t, index.Update(ctx, testcase.updates...))
+
+			event := <-events
+			require.Equal(t, ChangeUpdate, event.Op)
+
+			res, err := index.Search(ctx, "data OR struck OR copper OR wire")
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	autoVacuumNone        = "NONE"
+	autoVacuumFull        = "FULL"
+	autoVacuumIncremental = "INCREMENTAL"
+
+	incrementalVacuumQuery = `PRAGMA incremental_vacuum(%d);`
+)
+
+// IncrementalVacuum reclaims up to the input number of free pages from the Index's database file, or all of them if
+// pages is zero or below (see the incremental_vacuum pragma). Unlike a full VACUUM, this only moves and truncates free
+// pages at the end of the file, so it is a cheap way of reclaiming space periodically in an Index with many deletes.
+//
+// This is a no-op unless the database's auto_vacuum mode is INCREMENTAL (see WithAutoVacuum). If the Index is
+// configured with a write queue, the call is enqueued and blocks until it is processed.
+func (i *Index[K, V]) IncrementalVacuum(ctx context.Context, pages int) error {
+	if pages < 0 {
+		pages = 0
+	}
+
+	return i.write(ctx, func(ctx context.Context) error {
+		i.mu.RLock()
+		defer i.mu.RUnlock()
+
+		_, err := i.db.ExecContext(ctx, fmt.Sprintf(incrementalVacuumQuery, pages))
+
+		return err
+	})
+}
+
+// isAutoVacuumMode returns the input auto_vacuum mode in upper-case, and whether it is a valid mode.
+func isAutoVacuumMode(mode string) (string, bool) {
+	mode = strings.ToUpper(mode)
+
+	switch mode {
+	case autoVacuumNone, autoVacuumFull, autoVacuumIncremental:
+		return mode, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,112 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_VacuumOnShutdown(t *testing.T) {
+	const numAttrs = 5000
+
+	ctx := context.Background()
+	uri := filepath.Join(t.TempDir(), "index.db")
+
+	index, err := newIndex[int, string](cfg.New[Config](
+		WithURI(uri),
+		WithVacuumOnShutdown(),
+	))
+	require.NoError(t, err)
+
+	attrs := make([]Attribute[int, string], 0, numAttrs)
+	keys := make([]int, 0, numAttrs)
+
+	for i := 0; i < numAttrs; i++ {
+		attrs = append(attrs, Attribute[int, string]{
+			Key:   i,
+			Value: fmt.Sprintf("entry %d %s", i, strings.Repeat("filler text ", 20)),
+		})
+		keys = append(keys, i)
+	}
+
+	require.NoError(t, index.Insert(ctx, attrs...))
+	require.NoError(t, index.Delete(ctx, keys...))
+
+	before, err := os.Stat(uri)
+	require.NoError(t, err)
+
+	require.NoError(t, index.Shutdown(ctx))
+
+	after, err := os.Stat(uri)
+	require.NoError(t, err)
+	require.Less(t, after.Size(), before.Size())
+}
+
+func TestIndex_VacuumOnShutdownInMemory(t *testing.T) {
+	index, err := newIndex[int, string](cfg.New[Config](WithVacuumOnShutdown()))
+	require.NoError(t, err)
+	require.NoError(t, index.Shutdown(context.Background()))
+}
+
+func TestIndex_IncrementalVacuum(t *testing.T) {
+	const numAttrs = 2000
+
+	ctx := context.Background()
+	uri := filepath.Join(t.TempDir(), "index.db")
+
+	index, err := newIndex[int, string](cfg.New[Config](
+		WithURI(uri),
+		WithAutoVacuum("incremental"),
+	))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	var mode int
+	require.NoError(t, index.db.QueryRowContext(ctx, "PRAGMA auto_vacuum;").Scan(&mode))
+	require.Equal(t, 2, mode)
+
+	attrs := make([]Attribute[int, string], 0, numAttrs)
+	keys := make([]int, 0, numAttrs)
+
+	for i := 0; i < numAttrs; i++ {
+		attrs = append(attrs, Attribute[int, string]{
+			Key:   i,
+			Value: fmt.Sprintf("entry %d %s", i, strings.Repeat("filler text ", 20)),
+		})
+		keys = append(keys, i)
+	}
+
+	require.NoError(t, index.Insert(ctx, attrs...))
+	require.NoError(t, index.Delete(ctx, keys...))
+
+	freePages := func() (n int) {
+		require.NoError(t, index.db.QueryRowContext(ctx, "PRAGMA freelist_count;").Scan(&n))
+
+		return n
+	}
+
+	before, err := os.Stat(uri)
+	require.NoError(t, err)
+
+	free := freePages()
+	require.Greater(t, free, 1)
+
+	require.NoError(t, index.IncrementalVacuum(ctx, 1))
+	require.Equal(t, free-1, freePages())
+
+	require.NoError(t, index.IncrementalVacuum(ctx, 0))
+	require.Zero(t, freePages())
+
+	after, err := os.Stat(uri)
+	require.NoError(t, err)
+	require.Less(t, after.Size(), before.Size())
+}
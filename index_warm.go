@@ -0,0 +1,27 @@
+package fts
+
+import "context"
+
+const warmTermQuery = `
+SELECT count(*) FROM fulltext_search(?);
+`
+
+// WarmTerm loads the full-text index pages for the input search term into SQLite's page cache, so that subsequent
+// searches for that term do not pay for reading them from disk. It runs a count of the term's matches, which reads the
+// term's posting lists without materializing any results.
+//
+// The page cache is held by the database connections, so this is most effective with the (default) shared-cache mode,
+// where all connections in the process share it; and it is lost when the Index is shut down (or rotated). The gain
+// depends on how costly it is to read those pages: when the database file is already in the OS page cache, the
+// search's latency is dominated by ranking its matches, which warming does not avoid.
+//
+// This call returns an error if the underlying SQL query fails (e.g. with an invalid search term). A term with no
+// matches is not an error.
+func (i *Index[K, V]) WarmTerm(ctx context.Context, searchTerm V) error {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var count int
+
+	return i.db.QueryRowContext(ctx, warmTermQuery, searchTerm).Scan(&count)
+}
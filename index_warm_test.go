@@ -0,0 +1,107 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_WarmTerm(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		query string
+		fails bool
+	}{
+		{
+			name:  "Success/Match",
+			query: "gold",
+		},
+		{
+			name:  "Success/NoMatch",
+			query: "silver",
+		},
+		{
+			name:  "Fail/InvalidQuery",
+			query: `"unterminated`,
+			fails: true,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), Attribute[int, string]{Key: 1, Value: "struck gold"})
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			err = index.WarmTerm(ctx, testcase.query)
+			if testcase.fails {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func BenchmarkIndex_WarmTerm(b *testing.B) {
+	const (
+		corpusSize = 50_000
+		top        = 10
+		term       = "gold"
+	)
+
+	ctx := context.Background()
+
+	// the benchmark index is closed so that its (shared) page cache is released
+	corpus := newBenchmarkIndex(b, corpusSize)
+	if err := corpus.Shutdown(ctx); err != nil {
+		b.Fatal(err)
+	}
+
+	path := corpus.uri
+
+	for _, bench := range []struct {
+		name string
+		warm bool
+	}{
+		{name: "Cold"},
+		{name: "Warmed", warm: true},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+
+				// a fresh Index starts with an empty page cache
+				index, err := NewIndex[int, string](path)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				if bench.warm {
+					if err = index.WarmTerm(ctx, term); err != nil {
+						b.Fatal(err)
+					}
+				}
+
+				b.StartTimer()
+
+				if _, err = index.SearchTop(ctx, term, top); err != nil {
+					b.Fatal(err)
+				}
+
+				b.StopTimer()
+
+				if err = index.Shutdown(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+const weightedTermQuery = `
+SELECT rowid, id, val, bm25(fulltext_search) FROM fulltext_search(?);
+`
+
+type weightedMatch[K SQLType, V SQLType] struct {
+	rowID int64
+	attr  Attribute[K, V]
+	score float64
+}
+
+// SearchWeightedTerms looks for matches of any of the input terms (a soft boolean OR), returning a collection of
+// matching Attribute ranked by the sum of their weighted per-term relevance scores (best match first).
+//
+// Each term is searched on its own, as an FTS5 expression, and each match gets a score for that term equal to its
+// (inverted) BM25 score, multiplied by the term's weight. The final score of a match is the sum of its scores across
+// all terms. This means that a document matching more, or heavier, terms ranks higher; and that a single heavy term
+// can outrank several light ones. Terms with a weight of zero are ignored, while negative weights demote the
+// documents matching them. Documents with the same score are sorted by insertion order.
+//
+// This call returns an error if any of the underlying SQL queries fail, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the queries. If the Index is configured with a maximum
+// number of results and the search yields more than that, the capped results are returned alongside an
+// ErrResultTruncated error.
+func (i *Index[K, V]) SearchWeightedTerms(ctx context.Context, terms map[string]float64) ([]Attribute[K, V], error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	words := make([]string, 0, len(terms))
+	for term, weight := range terms {
+		if weight != 0 {
+			words = append(words, term)
+		}
+	}
+
+	slices.Sort(words)
+
+	matches := make(map[int64]*weightedMatch[K, V], minAlloc)
+
+	for _, term := range words {
+		rows, err := i.db.QueryContext(ctx, weightedTermQuery, term)
+		if err != nil {
+			return nil, err
+		}
+
+		termMatches, err := scanRows(rows, 0, func(rows *sql.Rows) (m weightedMatch[K, V], err error) {
+			return m, rows.Scan(&m.rowID, &m.attr.Key, &m.attr.Value, &m.score)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for idx := range termMatches {
+			score := -termMatches[idx].score * terms[term]
+
+			if m, ok := matches[termMatches[idx].rowID]; ok {
+				m.score += score
+
+				continue
+			}
+
+			termMatches[idx].score = score
+			matches[termMatches[idx].rowID] = &termMatches[idx]
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, words)
+	}
+
+	ranked := make([]*weightedMatch[K, V], 0, len(matches))
+	for _, m := range matches {
+		ranked = append(ranked, m)
+	}
+
+	sort.Slice(ranked, func(a, b int) bool {
+		if ranked[a].score != ranked[b].score {
+			return ranked[a].score > ranked[b].score
+		}
+
+		return ranked[a].rowID < ranked[b].rowID
+	})
+
+	res := make([]Attribute[K, V], 0, len(ranked))
+	for idx := range ranked {
+		if i.maxResults > 0 && len(res) == i.maxResults {
+			return res, ErrResultTruncated
+		}
+
+		res = append(res, ranked[idx].attr)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,73 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchWeightedTerms(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "copper and bronze in the old mine"},
+		{Key: 2, Value: "struck gold in the mine"},
+		{Key: 3, Value: "some data"},
+		{Key: 4, Value: "copper plated bronze"},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		terms map[string]float64
+		wants []int
+		err   error
+	}{
+		{
+			name:  "Success/HeavyTermOutranksLightTerms",
+			terms: map[string]float64{"copper": 0.1, "bronze": 0.1, "gold": 10},
+			wants: []int{2, 4, 1},
+		},
+		{
+			name:  "Success/MoreMatchedTermsRankHigher",
+			terms: map[string]float64{"copper": 1, "bronze": 1, "mine": 1},
+			wants: []int{1, 4, 2},
+		},
+		{
+			name:  "Success/ZeroWeightIsIgnored",
+			terms: map[string]float64{"gold": 0, "data": 1},
+			wants: []int{3},
+		},
+		{
+			name:  "Fail/NoResults",
+			terms: map[string]float64{"silver": 1},
+			err:   ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.SearchWeightedTerms(ctx, testcase.terms)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			keys := make([]int, 0, len(res))
+			for i := range res {
+				keys = append(keys, res[i].Key)
+			}
+
+			require.Equal(t, testcase.wants, keys)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package fts
+
+import "context"
+
+type writeOp struct {
+	ctx  context.Context
+	fn   func(ctx context.Context) error
+	errs chan error
+}
+
+func (i *Index[K, V]) startWriteQueue(depth int) {
+	i.writes = make(chan writeOp, depth)
+	i.writesDone = make(chan struct{})
+
+	go func() {
+		defer close(i.writesDone)
+
+		for op := range i.writes {
+			op.errs <- op.fn(op.ctx)
+		}
+	}()
+}
+
+// write executes the input function, either directly or through the write queue if the Index is configured with one.
+//
+// When the write queue is enabled, this call blocks until there is room in the queue (or the context is done), and
+// then until the queued function is executed, returning its error.
+//
+// The Index's result cache, if any, is invalidated once the write is done.
+func (i *Index[K, V]) write(ctx context.Context, fn func(ctx context.Context) error) error {
+	if i.cache != nil {
+		defer i.cache.invalidate()
+	}
+
+	if i.writes == nil {
+		return fn(ctx)
+	}
+
+	i.writesMu.RLock()
+	defer i.writesMu.RUnlock()
+
+	if i.closed {
+		return ErrClosedIndex
+	}
+
+	op := writeOp{
+		ctx:  ctx,
+		fn:   fn,
+		errs: make(chan error, 1),
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case i.writes <- op:
+	}
+
+	return <-op.errs
+}
+
+// drainWrites closes the write queue, if the Index is configured with one, and waits for all pending writes to be
+// processed.
+func (i *Index[K, V]) drainWrites(ctx context.Context) error {
+	if i.writes == nil {
+		return nil
+	}
+
+	i.writesMu.Lock()
+
+	if !i.closed {
+		i.closed = true
+		close(i.writes)
+	}
+
+	i.writesMu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-i.writesDone:
+		return nil
+	}
+}
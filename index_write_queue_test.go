@@ -0,0 +1,68 @@
+package fts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestIndex_WriteQueue(t *testing.T) {
+	const (
+		numWriters  = 100
+		attrsPerRun = 10
+	)
+
+	ctx := context.Background()
+
+	index, err := newIndex[int, string](cfg.New[Config](
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithWriteQueue(8),
+	))
+	require.NoError(t, err)
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, numWriters)
+	)
+
+	for w := 0; w < numWriters; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			attrs := make([]Attribute[int, string], 0, attrsPerRun)
+			for n := 0; n < attrsPerRun; n++ {
+				attrs = append(attrs, Attribute[int, string]{
+					Key:   w*attrsPerRun + n,
+					Value: fmt.Sprintf("writer %d entry %d gold", w, n),
+				})
+			}
+
+			errs <- index.Insert(ctx, attrs...)
+		}(w)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	var count int
+	require.NoError(t, index.db.QueryRowContext(ctx, "SELECT count(*) FROM fulltext_search").Scan(&count))
+	require.Equal(t, numWriters*attrsPerRun, count)
+
+	res, err := index.Search(ctx, "gold")
+	require.NoError(t, err)
+	require.Len(t, res, numWriters*attrsPerRun)
+
+	require.NoError(t, index.Shutdown(ctx))
+	require.ErrorIs(t, index.Insert(ctx, Attribute[int, string]{Key: 0, Value: "late"}), ErrClosedIndex)
+}
@@ -30,6 +30,14 @@ type Indexer[K SQLType, V SQLType] interface {
 	// ErrNotFoundKeyword error if there are zero results from the query.
 	Search(ctx context.Context, searchTerm V) (res []Attribute[K, V], err error)
 
+	// SearchPaginated will look for matches for the input value through the indexed terms, like Search, returning at
+	// most limit results after skipping the first offset ones. A limit of zero or below means no limit.
+	//
+	// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+	// ErrNotFoundKeyword error if there are zero results from the query and the offset is zero. An offset past the last
+	// match returns an empty result with no error.
+	SearchPaginated(ctx context.Context, searchTerm V, limit, offset int) (res []Attribute[K, V], err error)
+
 	// Insert indexes new attributes in the Indexer, via the input Attribute's key and value content.
 	//
 	// A database transaction is performed in order to ensure that the query is executed as quickly as possible; in case
@@ -42,6 +50,16 @@ type Indexer[K SQLType, V SQLType] interface {
 	// multiple items are provided as input.
 	Delete(ctx context.Context, keys ...K) error
 
+	// Update replaces the values of the attributes matching the input Attribute's keys with their new values, within a
+	// single database transaction. Keys without a matching attribute are inserted.
+	Update(ctx context.Context, attrs ...Attribute[K, V]) error
+
+	// Count returns the number of indexed rows in the Indexer, or zero if it is empty.
+	Count(ctx context.Context) (int64, error)
+
+	// Clear removes all attributes in the Indexer, within a single database transaction, keeping it open and usable.
+	Clear(ctx context.Context) error
+
 	// Shutdown gracefully closes the Indexer.
 	Shutdown(ctx context.Context) error
 }
@@ -57,13 +75,13 @@ func New[K SQLType, V SQLType](attributes []Attribute[K, V], opts ...cfg.Option[
 		err     error
 	)
 
-	indexer, err = NewIndex[K, V](config.uri, attributes...)
+	indexer, err = newIndex[K, V](config, attributes...)
 	if err != nil {
 		return NoOp[K, V](), err
 	}
 
 	if config.logHandler != nil {
-		indexer = IndexerWithLogs(indexer, config.logHandler)
+		indexer = IndexerWithLogs(indexer, config.logHandler, opts...)
 	}
 
 	if config.metrics != nil {
@@ -71,7 +89,7 @@ func New[K SQLType, V SQLType](attributes []Attribute[K, V], opts ...cfg.Option[
 	}
 
 	if config.tracer != nil {
-		indexer = IndexerWithTrace(indexer, config.tracer)
+		indexer = IndexerWithTrace(indexer, config.tracer, opts...)
 	}
 
 	return indexer, nil
@@ -1,7 +1,14 @@
 package fts
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/zalgonoise/cfg"
 	"go.opentelemetry.io/otel/trace"
@@ -9,11 +16,53 @@ import (
 
 // Config defines optional settings in an Indexer
 type Config struct {
-	uri string
+	uri         string
+	memoryName  string
+	rawDSN      string
+	cacheMode   string
+	pragmas     []string
+	initPragmas []string
 
-	logHandler slog.Handler
-	metrics    Metrics
-	tracer     trace.Tracer
+	maxOpenConns int
+
+	writeQueueDepth  int
+	vacuumOnShutdown bool
+	skipNullValues   bool
+	maxTxnDuration   time.Duration
+	clock            func() time.Time
+	keyCollation     string
+	porterStemmer    bool
+	tokenizerName    string
+	tokenizerArgs    []string
+	tokenChars       string
+	separators       string
+	maxResults       int
+	queryRewriters   []QueryRewriter
+	validateQueries  bool
+	fallbackToLike   bool
+	minQueryLength   int
+	resultCacheSize  int
+
+	resultTransformers []any
+
+	recoverOnCorruption bool
+	lazyOpen            bool
+	codec               Codec
+	searchTextExtractor any
+	insertTimestamps    bool
+	documentBoosts      bool
+	metadataColumns     []string
+	infixSearch         bool
+
+	shutdownHooks []func(ctx context.Context) error
+
+	logHandler         slog.Handler
+	slowQueryThreshold time.Duration
+	byteEncoding       ByteEncoding
+	metrics            Metrics
+	tracer             trace.Tracer
+
+	traceShutdown func(ctx context.Context) error
 }
 
 // WithURI sets a path URI when connecting to the SQLite database, as a means to persist the database in the filesystem.
@@ -27,6 +76,682 @@ func WithURI(uri string) cfg.Option[Config] {
 	})
 }
 
+// WithURIFromEnv sets the path URI when connecting to the SQLite database from the value of the environment variable
+// with the input key. The variable is read when the configuration is built (e.g. when calling New).
+//
+// If the variable is unset or empty, the URI is left unchanged, which defaults to an in-memory database. An empty key
+// is a no-op.
+func WithURIFromEnv(key string) cfg.Option[Config] {
+	if key == "" {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		if uri := os.Getenv(key); uri != "" {
+			config.uri = uri
+		}
+
+		return config
+	})
+}
+
+// WithMemoryName names the Index's in-memory database, which is then opened as `file:<name>?mode=memory`; so that
+// in-memory indexes with different names are isolated from each other, while indexes with the same name share the same
+// database (in shared-cache mode). Without a name, all in-memory indexes in the process share the same database when
+// using the (default) shared-cache mode.
+//
+// This option has no effect on file-backed databases (see WithURI). An empty name is a no-op.
+func WithMemoryName(name string) cfg.Option[Config] {
+	if name == "" {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.memoryName = name
+
+		return config
+	})
+}
+
+// WithRawDSN sets the data source name used to connect to the SQLite database verbatim, instead of building it from the
+// configured URI, memory name, cache mode and pragmas; as an escape hatch to set the modernc.org/sqlite driver's own
+// URI parameters, such as `_pragma=`, `_time_format=` or `vfs=` (e.g. `file:index.db?_pragma=busy_timeout(5000)`).
+//
+// The file part of the DSN (without its "file:" scheme and its query parameters) is used as the Index's URI, taking
+// precedence over WithURI: it is validated and created like any other path, and used when rotating or recovering the
+// database. A DSN referring to ":memory:", or with a "mode=memory" parameter, is treated as in-memory.
+//
+// An empty DSN is a no-op.
+func WithRawDSN(dsn string) cfg.Option[Config] {
+	if dsn == "" {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.rawDSN = dsn
+
+		return config
+	})
+}
+
+// WithCacheMode sets the SQLite cache mode used when connecting to the database: either "shared" (the default) or
+// "private".
+//
+// In shared-cache mode, all connections to the same database within the process share a single cache; which also
+// means that all in-memory indexes in the process share the same data. SQLite discourages shared-cache mode for most
+// uses, so "private" is recommended for new indexes. Since each connection to a private in-memory database has its own
+// database, in-memory indexes in private-cache mode use a single connection.
+//
+// Any other mode is a no-op.
+func WithCacheMode(mode string) cfg.Option[Config] {
+	if mode != cacheShared && mode != cachePrivate {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.cacheMode = mode
+
+		return config
+	})
+}
+
+// WithAutoCheckpoint sets the number of pages that the write-ahead log of a WAL-mode database can grow to before
+// SQLite automatically checkpoints it (by issuing a wal_autocheckpoint pragma on every connection). SQLite's default is
+// 1000 pages.
+//
+// A lower threshold keeps the WAL file small and reads fast, at the cost of more frequent checkpoints (and write
+// latency spikes); while a higher threshold batches more writes in each checkpoint, at the cost of a larger WAL file.
+// A value of zero disables automatic checkpoints, in which case the WAL is only checkpointed when Checkpoint is
+// called (or when the database is closed).
+//
+// This option has no effect on databases that are not in WAL mode. A negative value is a no-op.
+func WithAutoCheckpoint(pages int) cfg.Option[Config] {
+	if pages < 0 {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.pragmas = append(config.pragmas, fmt.Sprintf("wal_autocheckpoint(%d)", pages))
+
+		return config
+	})
+}
+
+// WithTempStore sets where SQLite keeps its temporary tables and indices, such as the b-trees used when sorting
+// results (by issuing a temp_store pragma on every connection): "DEFAULT" (the compile-time default, usually a file),
+// "FILE" or "MEMORY". Keeping them in memory speeds up searches sorted by rank (see SearchTop and SearchWithOpts) on
+// large result sets, at the cost of memory.
+//
+// Any other mode is a no-op.
+func WithTempStore(mode string) cfg.Option[Config] {
+	mode = strings.ToUpper(mode)
+
+	switch mode {
+	case tempStoreDefault, tempStoreFile, tempStoreMemory:
+	default:
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.pragmas = append(config.pragmas, fmt.Sprintf("temp_store(%s)", mode))
+
+		return config
+	})
+}
+
+// WithForeignKeys enables or disables the enforcement of foreign key constraints (by issuing a foreign_keys pragma on
+// every connection), which SQLite disables by default. This is useful when the Index's database is shared with other
+// tables that declare foreign keys.
+func WithForeignKeys(enabled bool) cfg.Option[Config] {
+	return cfg.Register[Config](func(config Config) Config {
+		config.pragmas = append(config.pragmas, fmt.Sprintf("foreign_keys(%t)", enabled))
+
+		return config
+	})
+}
+
+// initPragmaNames lists the database-level pragmas that can be set with WithInitialPragmas.
+var initPragmaNames = map[string]struct{}{
+	"application_id": {},
+	"auto_vacuum":    {},
+	"encoding":       {},
+	"journal_mode":   {},
+	"page_size":      {},
+	"user_version":   {},
+}
+
+// initPragmaValue matches the values accepted by WithInitialPragmas: a (possibly negative) number, or a keyword.
+var initPragmaValue = regexp.MustCompile(`^(-?[0-9]+|[A-Za-z0-9_-]+)$`)
+
+// WithInitialPragmas sets database-level pragmas (issued as `PRAGMA <key>=<value>`) once, when the Index's database is
+// initialized; before its tables are created. This suits one-time settings, like page_size or auto_vacuum, that only
+// take effect on a new database; as opposed to the pragmas set on every connection (e.g. with WithAutoCheckpoint).
+//
+// The pragmas are set in the order of their keys. Only the application_id, auto_vacuum, encoding, journal_mode,
+// page_size and user_version pragmas are supported, with numeric or keyword values (e.g. `4096` or `FULL`); other
+// entries are ignored. An empty (or fully ignored) map is a no-op.
+func WithInitialPragmas(pragmas map[string]string) cfg.Option[Config] {
+	settings := make([]string, 0, len(pragmas))
+
+	for key, value := range pragmas {
+		key = strings.ToLower(key)
+
+		if _, ok := initPragmaNames[key]; !ok || !initPragmaValue.MatchString(value) {
+			continue
+		}
+
+		settings = append(settings, key+"="+value)
+	}
+
+	if len(settings) == 0 {
+		return cfg.NoOp[Config]{}
+	}
+
+	slices.Sort(settings)
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.initPragmas = append(config.initPragmas, settings...)
+
+		return config
+	})
+}
+
+// WithAutoVacuum sets the auto_vacuum mode of the Index's database, when it is initialized: "NONE" (SQLite's default),
+// "FULL", which truncates the database file on every commit that frees pages; or "INCREMENTAL", which keeps the freed
+// pages until they are reclaimed with the Index's IncrementalVacuum method.
+//
+// The mode is set before the database's tables are created, so it takes effect right away on new databases. Changing
+// the mode of an existing database requires a VACUUM to take effect (e.g. with WithVacuumOnShutdown). Any other mode
+// is a no-op.
+func WithAutoVacuum(mode string) cfg.Option[Config] {
+	mode, ok := isAutoVacuumMode(mode)
+	if !ok {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.initPragmas = append(config.initPragmas, "auto_vacuum="+mode)
+
+		return config
+	})
+}
+
+// WithMaxOpenConns caps the number of open connections in the Index's database connection pool to the input n. By
+// default, the pool is unbounded (except for private-cache in-memory databases, which always use a single connection).
+//
+// A value of zero or below is a no-op.
+func WithMaxOpenConns(n int) cfg.Option[Config] {
+	if n <= 0 {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.maxOpenConns = n
+
+		return config
+	})
+}
+
+// WithWriteQueue funnels all writes (inserts and deletes) through a single background goroutine, consuming a buffered
+// channel with the input depth.
+//
+// This ensures that the SQLite database only sees a single, serialized writer, even when the Index is written to
+// concurrently, from many goroutines. Callers experience back-pressure when the queue is full, instead of busy or
+// locked database errors. A depth of zero or below is ignored.
+func WithWriteQueue(depth int) cfg.Option[Config] {
+	if depth <= 0 {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.writeQueueDepth = depth
+
+		return config
+	})
+}
+
+// WithSkipNullValues makes the Index skip the attributes with a NULL value (an invalid sql.Null* type, such as
+// sql.NullString{Valid: false}) when inserting, as a no-op; instead of rejecting them with an ErrNullValue error, which
+// is the default. NULL values can't be tokenized, so they would never match a search.
+//
+// Skipped attributes are not part of the ChangeEvent of the insert (see Subscribe), and have a row ID of zero in the
+// results of InsertReturning.
+func WithSkipNullValues() cfg.Option[Config] {
+	return cfg.Register[Config](func(config Config) Config {
+		config.skipNullValues = true
+
+		return config
+	})
+}
+
+// WithMaxTxnDuration bounds how long a single write transaction of Insert (and InsertReturning) is kept open, by
+// committing it and starting a new one once it has been open for the input duration; so that large inserts don't hold
+// the database's write lock (and, in rollback-journal mode, block readers) for too long.
+//
+// This trades the atomicity of large inserts for shorter locks: an error or a canceled context only rolls back the
+// attributes in the current transaction, while the ones in the previously committed transactions are kept.
+//
+// A duration of zero or below is a no-op.
+func WithMaxTxnDuration(d time.Duration) cfg.Option[Config] {
+	if d <= 0 {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.maxTxnDuration = d
+
+		return config
+	})
+}
+
+// WithClock configures the Index to read the current time from the input function, instead of time.Now; such as a
+// fake clock, so that time-dependent behavior (the insertion times recorded with WithInsertTimestamps, the windows of
+// SearchRecentWindow, and the transaction durations bounded with WithMaxTxnDuration) is deterministic in tests.
+//
+// A nil function is a no-op.
+func WithClock(fn func() time.Time) cfg.Option[Config] {
+	if fn == nil {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.clock = fn
+
+		return config
+	})
+}
+
+// WithVacuumOnShutdown runs a VACUUM command on the SQLite database when the Index is shut down, reclaiming the space
+// left behind by deleted entries. This is a no-op for in-memory databases.
+//
+// VACUUM rebuilds the entire database file, which can be slow on large databases; this is why it is an opt-in setting.
+// The call honors the context passed to Shutdown.
+func WithVacuumOnShutdown() cfg.Option[Config] {
+	return cfg.Register[Config](func(config Config) Config {
+		config.vacuumOnShutdown = true
+
+		return config
+	})
+}
+
+// WithValueCompression stores the values in the Index compressed with the input Codec, decompressing them when they
+// are read; while still indexing their original text for full-text search. This reduces the size of file-backed
+// databases with large, compressible values (e.g. GzipCodec).
+//
+// The values are compressed in their text form, and returned as text; so this option should only be used with character
+// type values (string, []byte or []rune). Opening a database created with a different compression setting returns an
+// ErrInvalidCompression error.
+//
+// A nil Codec, or one with an invalid name, is a no-op.
+func WithValueCompression(codec Codec) cfg.Option[Config] {
+	if codec == nil || !collationName.MatchString(codec.Name()) {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.codec = codec
+
+		return config
+	})
+}
+
+// WithSearchTextExtractor configures the Index to index the text returned by the input function for each value, instead
+// of the value itself; while still storing (and returning) the original value. This allows searching only a part of
+// structured values, such as certain fields of a JSON document.
+//
+// The function's input type must match the Index's value type, otherwise creating the Index returns an
+// ErrInvalidExtractor error; as does opening a database created with a different setting. Since the indexed text
+// differs from the stored value, highlights and snippets (e.g. in SearchWithOpts, SearchWithContext and
+// SearchWithSnippet) are not supported with this option.
+//
+// A nil function is a no-op.
+func WithSearchTextExtractor[V SQLType](fn func(V) string) cfg.Option[Config] {
+	if fn == nil {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.searchTextExtractor = fn
+
+		return config
+	})
+}
+
+// WithInsertTimestamps configures the Index to record the time each value is inserted, allowing searches to be limited
+// to recent data (see SearchRecentWindow). Updated values (e.g. with UpdateIf) keep their original insertion time.
+//
+// Opening a database created with a different setting returns an ErrInvalidTimestamps error.
+func WithInsertTimestamps() cfg.Option[Config] {
+	return cfg.Register[Config](func(config Config) Config {
+		config.insertTimestamps = true
+
+		return config
+	})
+}
+
+// WithLazyOpen configures the Index to open and initialize its database (creating its file and tables, if needed) on
+// its first operation, rather than when it is created. This speeds up the startup of applications that may never use
+// the Index, such as CLI tools, which then avoid creating an unused database file.
+//
+// The database is initialized only once, even with concurrent operations; and if that fails, the error (such as an
+// ErrCorruptDatabase error) is returned by every operation on the Index, instead of by its constructor. This option
+// has no effect on in-memory indexes, which are always initialized when created.
+func WithLazyOpen() cfg.Option[Config] {
+	return cfg.Register[Config](func(config Config) Config {
+		config.lazyOpen = true
+
+		return config
+	})
+}
+
+// WithDocumentBoosts configures the Index to record a ranking boost for each value, as set with InsertBoosted; so that
+// certain attributes (e.g. pinned or sponsored ones) rank higher in searches ordered by relevance, regardless of their
+// BM25 score. Values inserted with Insert have no boost.
+//
+// Opening a database created with a different setting returns an ErrInvalidBoosts error.
+func WithDocumentBoosts() cfg.Option[Config] {
+	return cfg.Register[Config](func(config Config) Config {
+		config.documentBoosts = true
+
+		return config
+	})
+}
+
+// WithMetadataColumns configures the Index to store a set of metadata columns with the input names alongside each
+// value (such as an author, a URL or a timestamp), as set with InsertWithMetadata and returned by SearchWithMetadata.
+// Metadata is stored in a side table, and is never tokenized nor matched by searches; though search results can be
+// sorted by a metadata column (see OrderByMetadata). Values inserted with Insert have no metadata.
+//
+// Opening a database created with different metadata columns returns an ErrInvalidMetadata error.
+//
+// Column names must start with a letter or an underscore, followed by letters, digits or underscores. No names, an
+// invalid name or a repeated name are a no-op.
+func WithMetadataColumns(names ...string) cfg.Option[Config] {
+	if len(names) == 0 {
+		return cfg.NoOp[Config]{}
+	}
+
+	seen := make(map[string]struct{}, len(names))
+
+	for _, name := range names {
+		if _, ok := seen[name]; ok || !metadataColumnName.MatchString(name) {
+			return cfg.NoOp[Config]{}
+		}
+
+		seen[name] = struct{}{}
+	}
+
+	columns := make([]string, len(names))
+	copy(columns, names)
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.metadataColumns = columns
+
+		return config
+	})
+}
+
+// WithInfixSearch configures the Index to also index its values with FTS5's trigram tokenizer, in a separate table; so
+// that SearchInfix can match substrings anywhere within a word (e.g. "old" matching "gold"), which the default
+// tokenizer can't, as it only matches whole words or their prefixes. The trigram index is kept in sync with the values
+// on every write.
+//
+// The trigram index holds an entry for every sequence of three characters in the indexed text, so it is typically
+// several times larger than the default full-text index, and writes are slower; which is why it is opt-in.
+//
+// Opening a database created with a different setting returns an ErrInvalidInfix error.
+func WithInfixSearch() cfg.Option[Config] {
+	return cfg.Register[Config](func(config Config) Config {
+		config.infixSearch = true
+
+		return config
+	})
+}
+
+// WithRecoverOnCorruption configures the Index to recover from a corrupt database file when it is opened, by moving the
+// file aside (with a ".corrupt-<unix timestamp>" suffix) and starting with a fresh, empty database; logging a warning
+// with the configured log handler, or the default slog.Logger.
+//
+// Without this option, opening a corrupt database file returns an ErrCorruptDatabase error.
+func WithRecoverOnCorruption() cfg.Option[Config] {
+	return cfg.Register[Config](func(config Config) Config {
+		config.recoverOnCorruption = true
+
+		return config
+	})
+}
+
+// WithKeyCollation sets the collation used when matching keys by equality, such as when deleting entries from the
+// Index. The input name can be one of SQLite's built-in collations (BINARY, NOCASE or RTRIM), or a collation registered
+// with RegisterCollation before the Index is created.
+//
+// When set, deletes compare keys with `id COLLATE <name> IN (...)`, instead of using the BINARY collation. An invalid
+// collation name is ignored.
+func WithKeyCollation(name string) cfg.Option[Config] {
+	if !collationName.MatchString(name) {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.keyCollation = name
+
+		return config
+	})
+}
+
+// WithPorterStemmer wraps the unicode61 tokenizer with FTS5's porter stemmer, which reduces English words to their stem
+// (e.g. "running" and "runs" to "run"); so that searches match the other inflections of their terms.
+//
+// This setting only applies when the FTS5 table is created; existing (persisted) tables keep their tokenizer.
+func WithPorterStemmer() cfg.Option[Config] {
+	return cfg.Register[Config](func(config Config) Config {
+		config.porterStemmer = true
+
+		return config
+	})
+}
+
+// WithTokenizer sets the FTS5 tokenizer of the Index by its name and arguments, such as "porter" with "unicode61" and
+// "remove_diacritics", "2" (for stemming and diacritic folding); "ascii"; or "trigram" (for substring matching). It
+// overrides WithPorterStemmer, WithTokenChars and WithSeparators.
+//
+// The tokenizer is set when the FTS5 table is created; so when opening an existing (persisted) table with this option,
+// an ErrInvalidTokenizer error is returned if the table was created with a different tokenizer, rather than silently
+// using it.
+//
+// Names other than the built-in tokenizers (unicode61, ascii, porter and trigram) are a no-op.
+func WithTokenizer(name string, args ...string) cfg.Option[Config] {
+	if !slices.Contains(tokenizers, name) {
+		return cfg.NoOp[Config]{}
+	}
+
+	args = slices.Clone(args)
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.tokenizerName = name
+		config.tokenizerArgs = args
+
+		return config
+	})
+}
+
+// WithTokenChars sets the characters that the unicode61 tokenizer treats as part of a token, such as '-' or '_', so
+// that values like "gold-plate" are indexed as a single token.
+//
+// This setting only applies when the FTS5 table is created; existing (persisted) tables keep their tokenizer. An
+// empty string is ignored.
+func WithTokenChars(chars string) cfg.Option[Config] {
+	if chars == "" {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.tokenChars = chars
+
+		return config
+	})
+}
+
+// WithSeparators sets the characters that the unicode61 tokenizer treats as token separators, in addition to its
+// defaults, so that values are split into tokens on those characters.
+//
+// This setting only applies when the FTS5 table is created; existing (persisted) tables keep their tokenizer. An
+// empty string is ignored.
+func WithSeparators(chars string) cfg.Option[Config] {
+	if chars == "" {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.separators = chars
+
+		return config
+	})
+}
+
+// WithMaxResults caps the number of rows that any search in the Index materializes, regardless of how broad the query
+// is, as a safety setting against queries matching (nearly) everything, like `a*`.
+//
+// Unlike a search limit, this is a global setting that applies to every search. When a search yields more rows than
+// the input n, the first n results are returned alongside an ErrResultTruncated error. A value of zero or below is
+// ignored.
+func WithMaxResults(n int) cfg.Option[Config] {
+	if n <= 0 {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.maxResults = n
+
+		return config
+	})
+}
+
+// WithQueryRewriter adds the input QueryRewriter to the Index's chain of query rewriters, which transform the search
+// term before it is sent to FTS5 (e.g. to expand synonyms, remove stop-words or normalize the query). Rewriters are
+// applied in the order they are registered, each one receiving the output of the previous one.
+//
+// Rewriters are applied to character type search terms (string, []byte or []rune) in Search, SearchTop and
+// SearchWithOpts. A nil rewriter is a no-op.
+func WithQueryRewriter(rewriter QueryRewriter) cfg.Option[Config] {
+	if rewriter == nil {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.queryRewriters = append(config.queryRewriters, rewriter)
+
+		return config
+	})
+}
+
+// WithQueryValidation makes the Index validate the syntax of character type search terms (string, []byte or []rune)
+// with ValidateQuery before sending them to FTS5, in Search, SearchTop and SearchWithOpts (after any query rewriters);
+// returning a descriptive ErrInvalidQuery error for malformed expressions, instead of SQLite's parse error.
+func WithQueryValidation() cfg.Option[Config] {
+	return cfg.Register[Config](func(config Config) Config {
+		config.validateQueries = true
+
+		return config
+	})
+}
+
+// WithFallbackToLike makes the Index fall back to a substring LIKE query when FTS5 fails to parse a character type
+// search term (string, []byte or []rune), in Search, SearchTop and SearchWithOpts; instead of returning SQLite's parse
+// error. The fallback query strips the FTS5 operators from the search term (quotes, '*', '^', grouping, column filters,
+// and the AND, OR, NOT and NEAR operators), and matches the values containing the remaining text, case-insensitively
+// for ASCII characters.
+//
+// A LIKE query is much slower than a full-text search, as it scans the whole table, and it is not tokenized: the
+// remaining text must appear verbatim in the value, and results cannot be ranked nor highlighted (OrderRank sorts them
+// in insertion order, and highlighting is ignored). If the Index is also configured with WithQueryValidation, malformed
+// search terms fall back to the LIKE query instead of being rejected.
+func WithFallbackToLike() cfg.Option[Config] {
+	return cfg.Register[Config](func(config Config) Config {
+		config.fallbackToLike = true
+
+		return config
+	})
+}
+
+// WithMinQueryLength makes the Index reject character type search terms (string, []byte or []rune) shorter than the
+// input n runes, ignoring leading and trailing whitespace, with an ErrQueryTooShort error; before they are rewritten
+// and sent to FTS5. This is a cheap guard against accidentally broad (and slow) queries, in Search, SearchTop and
+// SearchWithOpts.
+//
+// A value of zero or below is a no-op.
+func WithMinQueryLength(n int) cfg.Option[Config] {
+	if n <= 0 {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.minQueryLength = n
+
+		return config
+	})
+}
+
+// WithResultCache caches the results of up to the input number of searches in the Index, evicting the least recently
+// used ones when full. Results are keyed by the search term after it is rewritten (see WithQueryRewriter) and by the
+// search options; so differently-written searches that normalize to the same query (e.g. `GOLD` and `gold`, with a
+// case-folding rewriter) share the same cache entry. Result transformers (see WithResultTransformer) are applied to the
+// cached results on every search.
+//
+// The whole cache is invalidated on every write to the Index. Its usage is returned by the Index's CacheStats method,
+// and its hit ratio is exposed as a gauge by Metrics implementing a SetCacheHitRatio(float64) method (such as the
+// metrics package's), when set with WithMetrics. A size of zero or below is a no-op.
+func WithResultCache(size int) cfg.Option[Config] {
+	if size <= 0 {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.resultCacheSize = size
+
+		return config
+	})
+}
+
+// WithResultTransformer adds the input function to the Index's chain of result transformers, which post-process the
+// results of every search before they are returned (e.g. to trim or decorate values, or to join them with external
+// data). Transformers are applied in the order they are registered, each one receiving the output of the previous one.
+//
+// Transformers are applied in Search, SearchTop and SearchWithOpts, within the Index; so their work is covered by the
+// logs, metrics and traces of a decorated Indexer. The function's key and value types must match the Index's, otherwise
+// creating the Index returns an ErrInvalidTransformer error. A nil function is a no-op.
+func WithResultTransformer[K SQLType, V SQLType](
+	fn func(ctx context.Context, res []Attribute[K, V]) ([]Attribute[K, V], error),
+) cfg.Option[Config] {
+	if fn == nil {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.resultTransformers = append(config.resultTransformers, ResultTransformer[K, V](fn))
+
+		return config
+	})
+}
+
+// WithShutdownHook registers the input function to be called when the Index is shut down, e.g. to release auxiliary
+// resources or stop background goroutines that depend on it. This option can be used multiple times; hooks are called
+// in reverse order of registration (last in, first out), before the Index's database is closed. Errors returned by the
+// hooks are joined with the Shutdown call's error.
+//
+// A nil function is a no-op.
+func WithShutdownHook(fn func(ctx context.Context) error) cfg.Option[Config] {
+	if fn == nil {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.shutdownHooks = append(config.shutdownHooks, fn)
+
+		return config
+	})
+}
+
 // WithLogger decorates the Indexer with the input slog.Logger.
 func WithLogger(logger *slog.Logger) cfg.Option[Config] {
 	return cfg.Register[Config](func(config Config) Config {
@@ -45,6 +770,44 @@ func WithLogHandler(handler slog.Handler) cfg.Option[Config] {
 	})
 }
 
+// WithSlowQueryThreshold configures the logged Indexer to register a Warn-level event, with the search term and its
+// duration, for every search that takes longer than the input threshold.
+//
+// This option only takes effect alongside WithLogger or WithLogHandler, or when passed to IndexerWithLogs. A zero or
+// negative threshold is a no-op.
+func WithSlowQueryThreshold(threshold time.Duration) cfg.Option[Config] {
+	if threshold <= 0 {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.slowQueryThreshold = threshold
+
+		return config
+	})
+}
+
+// WithByteEncoding sets how []byte keys and values are rendered as text: as raw text, or encoded as base64 or hex; so
+// that binary values don't come out garbled in JSON APIs. It applies to the results marshaled with MarshalResults, and
+// to the search terms logged by the logged Indexer (alongside WithLogger or WithLogHandler, or when passed to
+// IndexerWithLogs). By default, results are marshaled as raw text, and search terms are logged as the log handler
+// renders them.
+//
+// A ByteEncoding other than ByteEncodingRaw, ByteEncodingBase64 or ByteEncodingHex is a no-op.
+func WithByteEncoding(enc ByteEncoding) cfg.Option[Config] {
+	switch enc {
+	case ByteEncodingRaw, ByteEncodingBase64, ByteEncodingHex:
+	default:
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.byteEncoding = enc
+
+		return config
+	})
+}
+
 // WithMetrics decorates the Index with the input Metrics instance.
 func WithMetrics(metrics Metrics) cfg.Option[Config] {
 	return cfg.Register[Config](func(config Config) Config {
@@ -62,3 +825,20 @@ func WithTrace(tracer trace.Tracer) cfg.Option[Config] {
 		return config
 	})
 }
+
+// WithTraceShutdown registers the input shutdown function (such as the tracing.ShutdownFunc returned by tracing.Init)
+// in the traced Indexer, so that it is called when the Indexer is shut down. This flushes any spans still buffered by
+// the tracer provider's span processors before the process exits.
+//
+// This option only takes effect alongside WithTrace, or when passed to IndexerWithTrace. A nil function is a no-op.
+func WithTraceShutdown(fn func(ctx context.Context) error) cfg.Option[Config] {
+	if fn == nil {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.traceShutdown = fn
+
+		return config
+	})
+}
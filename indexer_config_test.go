@@ -0,0 +1,61 @@
+package fts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithURIFromEnv(t *testing.T) {
+	const envKey = "FTS_TEST_DATABASE_URI"
+
+	for _, testcase := range []struct {
+		name     string
+		set      bool
+		inMemory bool
+	}{
+		{
+			name: "Success/FromEnv",
+			set:  true,
+		},
+		{
+			name:     "Success/UnsetFallsBackToInMemory",
+			inMemory: true,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "index.db")
+
+			if testcase.set {
+				t.Setenv(envKey, path)
+			} else {
+				t.Setenv(envKey, "")
+			}
+
+			indexer, err := New[int, string](
+				[]Attribute[int, string]{{Key: 1, Value: "some data"}},
+				WithURIFromEnv(envKey),
+			)
+			require.NoError(t, err)
+
+			index, ok := indexer.(*Index[int, string])
+			require.True(t, ok)
+			require.Equal(t, testcase.inMemory, index.inMemory)
+
+			require.NoError(t, indexer.Shutdown(ctx))
+
+			_, err = os.Stat(path)
+			if testcase.inMemory {
+				require.ErrorIs(t, err, os.ErrNotExist)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package fts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// JSONIndexer is an Indexer for JSON documents, where each document is stored under a string key as its (stringified)
+// JSON value, and can be looked-up by the text in it.
+//
+// It embeds the underlying Indexer, so all of its methods remain available, and adds InsertJSON and SearchJSON to
+// marshal and unmarshal the documents on the way in and out of the index. Note that the whole JSON text is indexed,
+// so field names are also matched by search terms.
+type JSONIndexer struct {
+	Indexer[string, string]
+}
+
+// NewJSONIndexer wraps the input Indexer as a JSONIndexer.
+func NewJSONIndexer(indexer Indexer[string, string]) JSONIndexer {
+	return JSONIndexer{Indexer: indexer}
+}
+
+// InsertJSON marshals the input document as JSON and indexes it under the input key.
+//
+// This call returns an error if the document cannot be marshaled, or if inserting it in the Indexer fails.
+func (i JSONIndexer) InsertJSON(ctx context.Context, key string, doc any) error {
+	value, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return i.Insert(ctx, Attribute[string, string]{Key: key, Value: string(value)})
+}
+
+// SearchJSON will look for matches for the input term through the indexed documents, unmarshalling the matched values
+// into out, which must be a pointer to a slice (e.g. *[]T or *[]json.RawMessage). The matches are decoded in the order
+// they are returned by the Indexer's Search method, as if they were the elements of a single JSON array.
+//
+// This call returns an error if the search fails (including an ErrNotFoundKeyword error if there are zero results),
+// or if the matched values cannot be unmarshaled into out.
+func (i JSONIndexer) SearchJSON(ctx context.Context, term string, out any) error {
+	res, err := i.Search(ctx, term)
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, minAlloc*len(res)))
+
+	buf.WriteByte('[')
+
+	for idx := range res {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteString(res[idx].Value)
+	}
+
+	buf.WriteByte(']')
+
+	return json.Unmarshal(buf.Bytes(), out)
+}
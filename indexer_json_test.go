@@ -0,0 +1,75 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testDocument struct {
+	Title string   `json:"title"`
+	Tags  []string `json:"tags"`
+	Stars int      `json:"stars"`
+}
+
+func TestJSONIndexer(t *testing.T) {
+	docs := map[string]testDocument{
+		"a": {Title: "struck gold in the old copper mine", Tags: []string{"mining"}, Stars: 5},
+		"b": {Title: "some data", Tags: []string{"misc"}, Stars: 1},
+		"c": {Title: "probably bronze", Tags: []string{"metals", "alloys"}, Stars: 3},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		term  string
+		wants []testDocument
+		err   error
+	}{
+		{
+			name:  "Success/MatchTitle",
+			term:  "gold",
+			wants: []testDocument{docs["a"]},
+		},
+		{
+			name:  "Success/MatchTag",
+			term:  "alloys",
+			wants: []testDocument{docs["c"]},
+		},
+		{
+			name: "Fail/NoResults",
+			term: "silver",
+			err:  ErrNotFoundKeyword,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			indexer, err := New[string, string](nil, WithURI(filepath.Join(t.TempDir(), "index.db")))
+			require.NoError(t, err)
+
+			index := NewJSONIndexer(indexer)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			for _, key := range []string{"a", "b", "c"} {
+				require.NoError(t, index.InsertJSON(ctx, key, docs[key]))
+			}
+
+			var res []testDocument
+
+			err = index.SearchJSON(ctx, testcase.term, &res)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
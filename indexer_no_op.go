@@ -14,6 +14,13 @@ type noOpIndexer[K SQLType, V SQLType] struct{}
 // This is a no-op call and the returned values are always both nil.
 func (i noOpIndexer[K, V]) Search(context.Context, V) ([]Attribute[K, V], error) { return nil, nil }
 
+// SearchPaginated implements the Indexer interface.
+//
+// This is a no-op call and the returned values are always both nil.
+func (i noOpIndexer[K, V]) SearchPaginated(context.Context, V, int, int) ([]Attribute[K, V], error) {
+	return nil, nil
+}
+
 // Insert implements the Indexer interface.
 //
 // This is a no-op call and the returned error is always nil.
@@ -24,6 +31,21 @@ func (i noOpIndexer[K, V]) Insert(context.Context, ...Attribute[K, V]) error { r
 // This is a no-op call and the returned error is always nil.
 func (i noOpIndexer[K, V]) Delete(context.Context, ...K) error { return nil }
 
+// Update implements the Indexer interface.
+//
+// This is a no-op call and the returned error is always nil.
+func (i noOpIndexer[K, V]) Update(context.Context, ...Attribute[K, V]) error { return nil }
+
+// Count implements the Indexer interface.
+//
+// This is a no-op call that always returns zero, and the returned error is always nil.
+func (i noOpIndexer[K, V]) Count(context.Context) (int64, error) { return 0, nil }
+
+// Clear implements the Indexer interface.
+//
+// This is a no-op call and the returned error is always nil.
+func (i noOpIndexer[K, V]) Clear(context.Context) error { return nil }
+
 // Shutdown implements the Indexer interface.
 //
 // This is a no-op call and the returned error is always nil.
@@ -0,0 +1,296 @@
+package fts
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultProbeInterval    = 30 * time.Second
+)
+
+// expectedErrors lists the errors that describe the outcome of a call rather than a failing Indexer (such as a search
+// without matches, or an invalid query), which a circuit breaker does not count as failures.
+var expectedErrors = []error{
+	ErrNotFoundKeyword,
+	ErrResultTruncated,
+	ErrInvalidQuery,
+	ErrQueryTooShort,
+	ErrRateLimited,
+	ErrNullValue,
+	ErrZeroAttributes,
+	context.Canceled,
+	context.DeadlineExceeded,
+}
+
+// CircuitBreakerOpts defines the settings of a circuit breaker, as used in IndexerWithCircuitBreaker.
+type CircuitBreakerOpts struct {
+	// FailureThreshold is the number of consecutive failed calls that opens the circuit. A value of zero or below
+	// defaults to 5.
+	FailureThreshold int
+	// ProbeInterval is the time that the circuit stays open before a call is let through to probe the Indexer. A value
+	// of zero or below defaults to 30 seconds.
+	ProbeInterval time.Duration
+}
+
+// circuitBreaker tracks the consecutive failures of an Indexer, opening the circuit when they reach the threshold.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	threshold int
+	interval  time.Duration
+
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// allow returns true if a call can go through to the Indexer: if the circuit is closed, or if it has been open for the
+// probe interval and no other probe is in flight. It also returns whether the call is that probe, which the caller
+// passes on to record.
+func (b *circuitBreaker) allow() (allowed, probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.failures < b.threshold:
+		return true, false
+	case b.probing || time.Since(b.openedAt) < b.interval:
+		return false, false
+	default:
+		b.probing = true
+
+		return true, true
+	}
+}
+
+// record registers the outcome of a call, closing the circuit on success and counting (or reopening it) on failure. An
+// expected error (see isFailure) is neither: it leaves the failure count unchanged. If the call is the probe (as
+// returned by allow), the probe is released, so that another one can go through if the circuit is still open.
+func (b *circuitBreaker) record(err error, probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	switch {
+	case err == nil:
+		b.failures = 0
+	case !isFailure(err):
+	default:
+		b.failures++
+
+		if b.failures >= b.threshold {
+			b.openedAt = time.Now()
+		}
+	}
+}
+
+// isFailure returns true if the input error signals a failing Indexer, rather than an expected outcome of the call.
+func isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	for _, expected := range expectedErrors {
+		if errors.Is(err, expected) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type circuitBreakerIndexer[K SQLType, V SQLType] struct {
+	indexer Indexer[K, V]
+	breaker *circuitBreaker
+}
+
+// Search implements the Indexer interface.
+//
+// This implementation fails fast with an ErrCircuitOpen error while the circuit is open, and otherwise calls the
+// underlying Indexer's Search method, recording its outcome.
+//
+// This call will look for matches for the input value through the indexed terms, returning a collection of matching
+// Attribute, which will contain both key and (full) value for that match.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query.
+func (i circuitBreakerIndexer[K, V]) Search(ctx context.Context, searchTerm V) (res []Attribute[K, V], err error) {
+	allowed, probe := i.breaker.allow()
+	if !allowed {
+		return nil, ErrCircuitOpen
+	}
+
+	res, err = i.indexer.Search(ctx, searchTerm)
+	i.breaker.record(err, probe)
+
+	return res, err
+}
+
+// SearchPaginated implements the Indexer interface.
+//
+// This implementation fails fast with an ErrCircuitOpen error while the circuit is open, and otherwise calls the
+// underlying Indexer's SearchPaginated method, recording its outcome.
+//
+// This call will look for matches for the input value through the indexed terms, returning at most limit matching
+// Attribute after skipping the first offset ones.
+func (i circuitBreakerIndexer[K, V]) SearchPaginated(
+	ctx context.Context, searchTerm V, limit, offset int,
+) (res []Attribute[K, V], err error) {
+	allowed, probe := i.breaker.allow()
+	if !allowed {
+		return nil, ErrCircuitOpen
+	}
+
+	res, err = i.indexer.SearchPaginated(ctx, searchTerm, limit, offset)
+	i.breaker.record(err, probe)
+
+	return res, err
+}
+
+// Insert implements the Indexer interface.
+//
+// This implementation fails fast with an ErrCircuitOpen error while the circuit is open, and otherwise calls the
+// underlying Indexer's Insert method, recording its outcome.
+//
+// This call indexes new attributes in the Indexer, via the input Attribute's key and value content.
+//
+// A database transaction is performed in order to ensure that the query is executed as quickly as possible; in case
+// multiple items are provided as input. This is especially useful for the initial load sequence.
+func (i circuitBreakerIndexer[K, V]) Insert(ctx context.Context, attrs ...Attribute[K, V]) error {
+	allowed, probe := i.breaker.allow()
+	if !allowed {
+		return ErrCircuitOpen
+	}
+
+	err := i.indexer.Insert(ctx, attrs...)
+	i.breaker.record(err, probe)
+
+	return err
+}
+
+// Delete implements the Indexer interface.
+//
+// This implementation fails fast with an ErrCircuitOpen error while the circuit is open, and otherwise calls the
+// underlying Indexer's Delete method, recording its outcome.
+//
+// This call removes attributes in the Indexer, which match input K-type keys.
+//
+// A database transaction is performed in order to ensure that the query is executed as quickly as possible; in case
+// multiple items are provided as input.
+func (i circuitBreakerIndexer[K, V]) Delete(ctx context.Context, keys ...K) error {
+	allowed, probe := i.breaker.allow()
+	if !allowed {
+		return ErrCircuitOpen
+	}
+
+	err := i.indexer.Delete(ctx, keys...)
+	i.breaker.record(err, probe)
+
+	return err
+}
+
+// Update implements the Indexer interface.
+//
+// This implementation fails fast with an ErrCircuitOpen error while the circuit is open, and otherwise calls the
+// underlying Indexer's Update method, recording its outcome.
+//
+// This call replaces the values of the attributes in the Indexer matching the input Attribute's keys, within a single
+// database transaction.
+func (i circuitBreakerIndexer[K, V]) Update(ctx context.Context, attrs ...Attribute[K, V]) error {
+	allowed, probe := i.breaker.allow()
+	if !allowed {
+		return ErrCircuitOpen
+	}
+
+	err := i.indexer.Update(ctx, attrs...)
+	i.breaker.record(err, probe)
+
+	return err
+}
+
+// Count implements the Indexer interface.
+//
+// This implementation fails fast with an ErrCircuitOpen error while the circuit is open, and otherwise calls the
+// underlying Indexer's Count method, recording its outcome.
+//
+// This call returns the number of indexed rows in the Indexer.
+func (i circuitBreakerIndexer[K, V]) Count(ctx context.Context) (count int64, err error) {
+	allowed, probe := i.breaker.allow()
+	if !allowed {
+		return 0, ErrCircuitOpen
+	}
+
+	count, err = i.indexer.Count(ctx)
+	i.breaker.record(err, probe)
+
+	return count, err
+}
+
+// Clear implements the Indexer interface.
+//
+// This implementation fails fast with an ErrCircuitOpen error while the circuit is open, and otherwise calls the
+// underlying Indexer's Clear method, recording its outcome.
+//
+// This call removes all attributes in the Indexer, within a single database transaction.
+func (i circuitBreakerIndexer[K, V]) Clear(ctx context.Context) error {
+	allowed, probe := i.breaker.allow()
+	if !allowed {
+		return ErrCircuitOpen
+	}
+
+	err := i.indexer.Clear(ctx)
+	i.breaker.record(err, probe)
+
+	return err
+}
+
+// Shutdown implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Shutdown method, regardless of the state of the circuit.
+//
+// This call gracefully closes the Indexer.
+func (i circuitBreakerIndexer[K, V]) Shutdown(ctx context.Context) error {
+	return i.indexer.Shutdown(ctx)
+}
+
+// IndexerWithCircuitBreaker decorates the input Indexer with a circuit breaker, which opens after the configured number
+// of consecutive failed calls (any call but Shutdown), so that a failing database (e.g. with a full disk, or a corrupt
+// file) isn't hammered with calls piling up errors. While the circuit is open, calls fail fast with an ErrCircuitOpen
+// error; and once the probe interval elapses, a single call is let through to probe the Indexer, closing the circuit if
+// it succeeds or keeping it open for another interval if it fails.
+//
+// Errors describing the outcome of a call rather than a failing Indexer, such as ErrNotFoundKeyword, ErrInvalidQuery,
+// ErrRateLimited or a canceled context, are counted neither as failures nor as successes: they leave the count of
+// consecutive failures unchanged, and a probe ending with one of them keeps the circuit open.
+//
+// If the Indexer is nil, a no-op Indexer is returned.
+//
+// This Indexer will not add any new functionality besides guarding the calls to the Indexer with a circuit breaker.
+func IndexerWithCircuitBreaker[K SQLType, V SQLType](indexer Indexer[K, V], opts CircuitBreakerOpts) Indexer[K, V] {
+	if indexer == nil {
+		return NoOp[K, V]()
+	}
+
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = defaultFailureThreshold
+	}
+
+	if opts.ProbeInterval <= 0 {
+		opts.ProbeInterval = defaultProbeInterval
+	}
+
+	return circuitBreakerIndexer[K, V]{
+		indexer: indexer,
+		breaker: &circuitBreaker{
+			threshold: opts.FailureThreshold,
+			interval:  opts.ProbeInterval,
+		},
+	}
+}
@@ -0,0 +1,177 @@
+package fts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// failingIndexer is an Indexer whose searches fail with its err, counting the calls that reach it.
+type failingIndexer[K SQLType, V SQLType] struct {
+	Indexer[K, V]
+
+	err   *error
+	calls *int
+}
+
+func (i failingIndexer[K, V]) Search(context.Context, V) ([]Attribute[K, V], error) {
+	*i.calls++
+
+	return nil, *i.err
+}
+
+func TestIndexerWithCircuitBreaker(t *testing.T) {
+	const (
+		threshold = 3
+		interval  = 20 * time.Millisecond
+	)
+
+	errDatabase := errors.New("database or disk is full")
+
+	newIndexer := func() (Indexer[int, string], *error, *int) {
+		var (
+			err   error
+			calls int
+		)
+
+		return IndexerWithCircuitBreaker[int, string](
+			failingIndexer[int, string]{Indexer: NoOp[int, string](), err: &err, calls: &calls},
+			CircuitBreakerOpts{FailureThreshold: threshold, ProbeInterval: interval},
+		), &err, &calls
+	}
+
+	t.Run("Success/OpensAndRecovers", func(t *testing.T) {
+		ctx := context.Background()
+		indexer, err, calls := newIndexer()
+
+		*err = errDatabase
+
+		for i := 0; i < threshold; i++ {
+			_, searchErr := indexer.Search(ctx, "gold")
+			require.ErrorIs(t, searchErr, errDatabase)
+		}
+
+		// the circuit is open: calls fail fast, without reaching the Indexer
+		_, searchErr := indexer.Search(ctx, "gold")
+		require.ErrorIs(t, searchErr, ErrCircuitOpen)
+		require.Equal(t, threshold, *calls)
+
+		// a failed probe keeps the circuit open
+		time.Sleep(interval)
+
+		_, searchErr = indexer.Search(ctx, "gold")
+		require.ErrorIs(t, searchErr, errDatabase)
+
+		_, searchErr = indexer.Search(ctx, "gold")
+		require.ErrorIs(t, searchErr, ErrCircuitOpen)
+		require.Equal(t, threshold+1, *calls)
+
+		// a successful probe closes the circuit
+		*err = nil
+
+		time.Sleep(interval)
+
+		for i := 0; i < threshold; i++ {
+			_, searchErr = indexer.Search(ctx, "gold")
+			require.NoError(t, searchErr)
+		}
+
+		require.Equal(t, 2*threshold+1, *calls)
+	})
+
+	t.Run("Success/NotFoundIsNotAFailure", func(t *testing.T) {
+		ctx := context.Background()
+		indexer, err, calls := newIndexer()
+
+		*err = ErrNotFoundKeyword
+
+		for i := 0; i < 2*threshold; i++ {
+			_, searchErr := indexer.Search(ctx, "gold")
+			require.ErrorIs(t, searchErr, ErrNotFoundKeyword)
+		}
+
+		require.Equal(t, 2*threshold, *calls)
+	})
+
+	t.Run("Success/SuccessResetsFailures", func(t *testing.T) {
+		ctx := context.Background()
+		indexer, err, calls := newIndexer()
+
+		for i := 0; i < 2*threshold; i++ {
+			*err = errDatabase
+			if i%threshold == threshold-1 {
+				*err = nil
+			}
+
+			_, _ = indexer.Search(ctx, "gold")
+		}
+
+		require.Equal(t, 2*threshold, *calls)
+	})
+
+	t.Run("Success/ExpectedErrorsKeepFailures", func(t *testing.T) {
+		ctx := context.Background()
+		indexer, err, calls := newIndexer()
+
+		*err = errDatabase
+
+		for i := 0; i < threshold-1; i++ {
+			_, _ = indexer.Search(ctx, "gold")
+		}
+
+		// a canceled call neither resets nor increases the count of consecutive failures
+		*err = context.Canceled
+
+		_, searchErr := indexer.Search(ctx, "gold")
+		require.ErrorIs(t, searchErr, context.Canceled)
+
+		*err = errDatabase
+
+		_, searchErr = indexer.Search(ctx, "gold")
+		require.ErrorIs(t, searchErr, errDatabase)
+
+		_, searchErr = indexer.Search(ctx, "gold")
+		require.ErrorIs(t, searchErr, ErrCircuitOpen)
+		require.Equal(t, threshold+1, *calls)
+	})
+}
+
+func TestCircuitBreaker_ProbeOwnership(t *testing.T) {
+	const interval = 20 * time.Millisecond
+
+	errDatabase := errors.New("database or disk is full")
+	breaker := &circuitBreaker{threshold: 1, interval: interval}
+
+	allowed, probe := breaker.allow()
+	require.True(t, allowed)
+	require.False(t, probe)
+
+	breaker.record(errDatabase, probe)
+
+	time.Sleep(interval)
+
+	allowed, probe = breaker.allow()
+	require.True(t, allowed)
+	require.True(t, probe)
+
+	// a call that is not the probe does not release it
+	breaker.record(errDatabase, false)
+
+	allowed, _ = breaker.allow()
+	require.False(t, allowed)
+
+	// the probe's own outcome releases it, letting another probe through after the interval
+	breaker.record(errDatabase, true)
+
+	allowed, _ = breaker.allow()
+	require.False(t, allowed)
+
+	time.Sleep(interval)
+
+	allowed, probe = breaker.allow()
+	require.True(t, allowed)
+	require.True(t, probe)
+}
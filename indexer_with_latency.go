@@ -0,0 +1,291 @@
+package fts
+
+import (
+	"context"
+	"math/bits"
+	"sync"
+	"time"
+)
+
+const (
+	// latencySubBuckets is the number of linear sub-buckets within each power-of-two range of the latency histogram,
+	// which bounds the relative error of the reported percentiles to 1/latencySubBuckets (6.25%).
+	latencySubBuckets    = 16
+	latencySubBucketBits = 4
+	latencyBuckets       = (64-latencySubBucketBits)*latencySubBuckets + 2*latencySubBuckets
+)
+
+// LatencyStats describes the search latencies recorded by a latency sampler within a window.
+type LatencyStats struct {
+	// Count is the number of searches in the window.
+	Count int
+	// P50, P95 and P99 are the latency percentiles in the window, within a 6.25% relative error.
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+	// Max is the highest latency in the window.
+	Max time.Duration
+}
+
+// latencyHistogram is a log-linear (HDR-style) histogram of latencies: values are grouped in power-of-two ranges, each
+// split in latencySubBuckets linear sub-buckets; so it uses a fixed amount of memory regardless of the number of
+// recorded values.
+type latencyHistogram struct {
+	counts [latencyBuckets]uint64
+	total  uint64
+	max    time.Duration
+}
+
+func latencyBucket(v uint64) int {
+	magnitude := bits.Len64(v) - latencySubBucketBits - 1
+	if magnitude <= 0 {
+		return int(v)
+	}
+
+	return magnitude*latencySubBuckets + int(v>>magnitude)
+}
+
+func latencyBucketValue(idx int) uint64 {
+	if idx < 2*latencySubBuckets {
+		return uint64(idx)
+	}
+
+	magnitude := idx/latencySubBuckets - 1
+
+	return uint64(idx-magnitude*latencySubBuckets) << magnitude
+}
+
+func (h *latencyHistogram) record(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+
+	h.counts[latencyBucket(uint64(d))]++
+	h.total++
+
+	if d > h.max {
+		h.max = d
+	}
+}
+
+func (h *latencyHistogram) percentile(p float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+
+	rank := uint64(p * float64(h.total))
+	if rank == 0 {
+		rank = 1
+	}
+
+	var seen uint64
+
+	for idx := range h.counts {
+		seen += h.counts[idx]
+
+		if seen >= rank {
+			return min(time.Duration(latencyBucketValue(idx)), h.max)
+		}
+	}
+
+	return h.max
+}
+
+func (h *latencyHistogram) stats() LatencyStats {
+	return LatencyStats{
+		Count: int(h.total),
+		P50:   h.percentile(0.50),
+		P95:   h.percentile(0.95),
+		P99:   h.percentile(0.99),
+		Max:   h.max,
+	}
+}
+
+type latencySampler struct {
+	mu        sync.Mutex
+	histogram *latencyHistogram
+	fn        func(LatencyStats)
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func (s *latencySampler) record(d time.Duration) {
+	s.mu.Lock()
+	s.histogram.record(d)
+	s.mu.Unlock()
+}
+
+func (s *latencySampler) report() {
+	s.mu.Lock()
+	histogram := s.histogram
+	s.histogram = &latencyHistogram{}
+	s.mu.Unlock()
+
+	s.fn(histogram.stats())
+}
+
+func (s *latencySampler) run(window time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.report()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+type latencyIndexer[K SQLType, V SQLType] struct {
+	indexer Indexer[K, V]
+	sampler *latencySampler
+	once    *sync.Once
+}
+
+// Search implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Search method, recording its latency in the current window.
+//
+// This call will look for matches for the input value through the indexed terms, returning a collection of matching
+// Attribute, which will contain both key and (full) value for that match.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query.
+func (i latencyIndexer[K, V]) Search(ctx context.Context, searchTerm V) ([]Attribute[K, V], error) {
+	start := time.Now()
+
+	res, err := i.indexer.Search(ctx, searchTerm)
+
+	i.sampler.record(time.Since(start))
+
+	return res, err
+}
+
+// SearchPaginated implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's SearchPaginated method, recording its latency in the current
+// window, as with Search.
+//
+// This call will look for matches for the input value through the indexed terms, returning at most limit matching
+// Attribute after skipping the first offset ones.
+func (i latencyIndexer[K, V]) SearchPaginated(
+	ctx context.Context, searchTerm V, limit, offset int,
+) ([]Attribute[K, V], error) {
+	start := time.Now()
+
+	res, err := i.indexer.SearchPaginated(ctx, searchTerm, limit, offset)
+
+	i.sampler.record(time.Since(start))
+
+	return res, err
+}
+
+// Insert implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Insert method.
+//
+// This call indexes new attributes in the Indexer, via the input Attribute's key and value content.
+func (i latencyIndexer[K, V]) Insert(ctx context.Context, attrs ...Attribute[K, V]) error {
+	return i.indexer.Insert(ctx, attrs...)
+}
+
+// Delete implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Delete method.
+//
+// This call removes attributes in the Indexer, which match input K-type keys.
+func (i latencyIndexer[K, V]) Delete(ctx context.Context, keys ...K) error {
+	return i.indexer.Delete(ctx, keys...)
+}
+
+// Update implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Update method.
+//
+// This call replaces the values of the attributes in the Indexer matching the input Attribute's keys.
+func (i latencyIndexer[K, V]) Update(ctx context.Context, attrs ...Attribute[K, V]) error {
+	return i.indexer.Update(ctx, attrs...)
+}
+
+// Count implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Count method.
+//
+// This call returns the number of indexed rows in the Indexer.
+func (i latencyIndexer[K, V]) Count(ctx context.Context) (int64, error) {
+	return i.indexer.Count(ctx)
+}
+
+// Clear implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Clear method.
+//
+// This call removes all attributes in the Indexer.
+func (i latencyIndexer[K, V]) Clear(ctx context.Context) error {
+	return i.indexer.Clear(ctx)
+}
+
+// Shutdown implements the Indexer interface.
+//
+// This implementation stops the latency sampler, reporting the latencies of the last (partial) window if it recorded
+// any, and then calls the underlying Indexer's Shutdown method.
+//
+// This call gracefully closes the Indexer.
+func (i latencyIndexer[K, V]) Shutdown(ctx context.Context) error {
+	i.once.Do(func() {
+		close(i.sampler.done)
+		i.sampler.wg.Wait()
+
+		i.sampler.mu.Lock()
+		pending := i.sampler.histogram.total > 0
+		i.sampler.mu.Unlock()
+
+		if pending {
+			i.sampler.report()
+		}
+	})
+
+	return i.indexer.Shutdown(ctx)
+}
+
+// IndexerWithLatencySampler decorates the input Indexer with a lightweight search latency sampler, as an alternative
+// to a full metrics setup.
+//
+// The latency of each search is recorded in a fixed-size, log-linear histogram; and on every window the input function
+// is called with the LatencyStats of that window (even if no searches were made), before the histogram is reset. The
+// function is called from a separate goroutine, so it should not block for long.
+//
+// If the Indexer is nil, a no-op Indexer is returned. If the window is zero or below, or the function is nil, the
+// input Indexer is returned as-is.
+func IndexerWithLatencySampler[K SQLType, V SQLType](
+	indexer Indexer[K, V], window time.Duration, fn func(LatencyStats),
+) Indexer[K, V] {
+	if indexer == nil {
+		return NoOp[K, V]()
+	}
+
+	if window <= 0 || fn == nil {
+		return indexer
+	}
+
+	sampler := &latencySampler{
+		histogram: &latencyHistogram{},
+		fn:        fn,
+		done:      make(chan struct{}),
+	}
+
+	sampler.wg.Add(1)
+
+	go sampler.run(window)
+
+	return latencyIndexer[K, V]{
+		indexer: indexer,
+		sampler: sampler,
+		once:    &sync.Once{},
+	}
+}
@@ -0,0 +1,115 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyHistogram(t *testing.T) {
+	for _, testcase := range []struct {
+		name      string
+		latencies []time.Duration
+		wants     LatencyStats
+	}{
+		{
+			name: "Success/Empty",
+		},
+		{
+			name: "Success/Uniform",
+			latencies: func() []time.Duration {
+				latencies := make([]time.Duration, 0, 1000)
+				for i := 1; i <= 1000; i++ {
+					latencies = append(latencies, time.Duration(i)*time.Microsecond)
+				}
+
+				return latencies
+			}(),
+			wants: LatencyStats{
+				Count: 1000,
+				P50:   500 * time.Microsecond,
+				P95:   950 * time.Microsecond,
+				P99:   990 * time.Microsecond,
+				Max:   1000 * time.Microsecond,
+			},
+		},
+		{
+			name: "Success/Outliers",
+			latencies: func() []time.Duration {
+				latencies := make([]time.Duration, 0, 100)
+				for i := 0; i < 98; i++ {
+					latencies = append(latencies, time.Millisecond)
+				}
+
+				return append(latencies, 200*time.Millisecond, 250*time.Millisecond)
+			}(),
+			wants: LatencyStats{
+				Count: 100,
+				P50:   time.Millisecond,
+				P95:   time.Millisecond,
+				P99:   200 * time.Millisecond,
+				Max:   250 * time.Millisecond,
+			},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			h := &latencyHistogram{}
+
+			for _, latency := range testcase.latencies {
+				h.record(latency)
+			}
+
+			stats := h.stats()
+
+			require.Equal(t, testcase.wants.Count, stats.Count)
+			require.Equal(t, testcase.wants.Max, stats.Max)
+
+			for _, p := range []struct{ want, got time.Duration }{
+				{testcase.wants.P50, stats.P50},
+				{testcase.wants.P95, stats.P95},
+				{testcase.wants.P99, stats.P99},
+			} {
+				require.InEpsilon(t, max(float64(p.want), 1), max(float64(p.got), 1), 1.0/latencySubBuckets)
+			}
+		})
+	}
+}
+
+func TestIndexerWithLatencySampler(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		mu    sync.Mutex
+		count int
+	)
+
+	index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), Attribute[int, string]{Key: 1, Value: "some data"})
+	require.NoError(t, err)
+
+	indexer := IndexerWithLatencySampler[int, string](index, time.Hour, func(stats LatencyStats) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		count += stats.Count
+
+		require.Positive(t, stats.P50)
+		require.LessOrEqual(t, stats.P50, stats.P99)
+		require.LessOrEqual(t, stats.P99, stats.Max)
+	})
+
+	for i := 0; i < 10; i++ {
+		_, err = indexer.Search(ctx, "data")
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, indexer.Shutdown(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Equal(t, 10, count)
+}
@@ -4,17 +4,24 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"time"
+
+	"github.com/zalgonoise/cfg"
 )
 
 type loggedIndexer[K SQLType, V SQLType] struct {
 	indexer Indexer[K, V]
 	logger  *slog.Logger
+
+	slowQueryThreshold time.Duration
+	byteEncoding       ByteEncoding
 }
 
 // Search implements the Indexer interface.
 //
 // This implementation calls the underlying Indexer's Search method, registering log entries before the
-// call and if it raises an error with a Warn-level event.
+// call and if it raises an error with a Warn-level event. If a slow query threshold is configured (see
+// WithSlowQueryThreshold), searches that take longer than it are also logged with a Warn-level event.
 //
 // This call will look for matches for the input value through the indexed terms, returning a collection of matching
 // Attribute, which will contain both key and (full) value for that match.
@@ -22,9 +29,61 @@ type loggedIndexer[K SQLType, V SQLType] struct {
 // This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
 // ErrNotFoundKeyword error if there are zero results from the query.
 func (i loggedIndexer[K, V]) Search(ctx context.Context, searchTerm V) ([]Attribute[K, V], error) {
-	i.logger.InfoContext(ctx, "finding matches for search term", slog.Any("search_term", searchTerm))
+	term := slog.Any("search_term", i.byteEncoding.encode(searchTerm))
+
+	i.logger.InfoContext(ctx, "finding matches for search term", term)
+
+	start := time.Now()
 
 	res, err := i.indexer.Search(ctx, searchTerm)
+
+	if dur := time.Since(start); i.slowQueryThreshold > 0 && dur > i.slowQueryThreshold {
+		i.logger.WarnContext(ctx, "slow search",
+			term,
+			slog.Duration("duration", dur),
+			slog.Duration("threshold", i.slowQueryThreshold),
+		)
+	}
+
+	if err != nil {
+		i.logger.WarnContext(ctx, "error when finding matches", slog.String("error", err.Error()))
+	}
+
+	return res, err
+}
+
+// SearchPaginated implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's SearchPaginated method, registering log entries (with the limit
+// and offset of the search) before the call and if it raises an error with a Warn-level event. If a slow query
+// threshold is configured (see WithSlowQueryThreshold), searches that take longer than it are also logged with a
+// Warn-level event.
+//
+// This call will look for matches for the input value through the indexed terms, returning at most limit matching
+// Attribute after skipping the first offset ones.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query and the offset is zero.
+func (i loggedIndexer[K, V]) SearchPaginated(
+	ctx context.Context, searchTerm V, limit, offset int,
+) ([]Attribute[K, V], error) {
+	page := []any{
+		slog.Any("search_term", i.byteEncoding.encode(searchTerm)), slog.Int("limit", limit), slog.Int("offset", offset),
+	}
+
+	i.logger.InfoContext(ctx, "finding a page of matches for search term", page...)
+
+	start := time.Now()
+
+	res, err := i.indexer.SearchPaginated(ctx, searchTerm, limit, offset)
+
+	if dur := time.Since(start); i.slowQueryThreshold > 0 && dur > i.slowQueryThreshold {
+		i.logger.WarnContext(ctx, "slow search", append(page,
+			slog.Duration("duration", dur),
+			slog.Duration("threshold", i.slowQueryThreshold),
+		)...)
+	}
+
 	if err != nil {
 		i.logger.WarnContext(ctx, "error when finding matches", slog.String("error", err.Error()))
 	}
@@ -74,6 +133,62 @@ func (i loggedIndexer[K, V]) Delete(ctx context.Context, keys ...K) error {
 	return nil
 }
 
+// Update implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Update method, registering log entries before the
+// call and if it raises an error with a Warn-level event.
+//
+// This call replaces the values of the attributes in the Indexer matching the input Attribute's keys, within a single
+// database transaction.
+func (i loggedIndexer[K, V]) Update(ctx context.Context, attrs ...Attribute[K, V]) error {
+	i.logger.InfoContext(ctx, "updating attributes", slog.Int("num_attributes", len(attrs)))
+
+	if err := i.indexer.Update(ctx, attrs...); err != nil {
+		i.logger.WarnContext(ctx, "failed to update attributes", slog.String("error", err.Error()))
+
+		return err
+	}
+
+	return nil
+}
+
+// Count implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Count method, registering a log entry with the count, or a
+// Warn-level event if it raises an error.
+//
+// This call returns the number of indexed rows in the Indexer.
+func (i loggedIndexer[K, V]) Count(ctx context.Context) (int64, error) {
+	count, err := i.indexer.Count(ctx)
+	if err != nil {
+		i.logger.WarnContext(ctx, "failed to count indexed rows", slog.String("error", err.Error()))
+
+		return count, err
+	}
+
+	i.logger.InfoContext(ctx, "counted indexed rows", slog.Int64("count", count))
+
+	return count, nil
+}
+
+// Clear implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Clear method, registering log entries before the
+// call and if it raises an error with a Warn-level event.
+//
+// This call removes all attributes in the Indexer, within a single database transaction.
+func (i loggedIndexer[K, V]) Clear(ctx context.Context) error {
+	i.logger.InfoContext(ctx, "clearing Indexer")
+
+	if err := i.indexer.Clear(ctx); err != nil {
+		i.logger.WarnContext(ctx, "failed to clear Indexer", slog.String("error", err.Error()))
+
+		return err
+	}
+
+	return nil
+}
+
 // Shutdown implements the Indexer interface.
 //
 // This implementation calls the underlying Indexer's Shutdown method, registering log entries before the
@@ -94,28 +209,45 @@ func (i loggedIndexer[K, V]) Shutdown(ctx context.Context) error {
 
 // IndexerWithLogs decorates the input Indexer with a slog.Logger using the input slog.Handler.
 //
-// If the Indexer is nil, a no-op Indexer is returned. If the input slog.Handler is nil, a default
-// text handler is created as a safe default. If the input Indexer is already a logged Indexer; then
-// its logger's handler is replaced with this handler (input or default one).
+// The logged Indexer is configured with the WithSlowQueryThreshold and WithByteEncoding options, if provided; other
+// options are ignored.
+//
+// If the input slog.Handler is nil, a default text handler is created as a safe default. If the Indexer is nil, a
+// warning is logged with this handler, and a no-op Indexer is returned. If the input Indexer is already a logged
+// Indexer; then its logger's handler is replaced with this handler (input or default one), and its configuration with
+// the input options' (if any).
 //
 // This Indexer will not add any new functionality besides decorating the Indexer with log events.
-func IndexerWithLogs[K SQLType, V SQLType](indexer Indexer[K, V], handler slog.Handler) Indexer[K, V] {
+func IndexerWithLogs[K SQLType, V SQLType](
+	indexer Indexer[K, V], handler slog.Handler, opts ...cfg.Option[Config],
+) Indexer[K, V] {
+	if handler == nil {
+		handler = slog.NewTextHandler(os.Stderr, nil)
+	}
+
 	if indexer == nil {
+		slog.New(handler).Warn("falling back to a no-op Indexer", slog.String("reason", "nil Indexer"))
+
 		return NoOp[K, V]()
 	}
 
-	if handler == nil {
-		handler = slog.NewTextHandler(os.Stderr, nil)
-	}
+	config := cfg.New[Config](opts...)
 
 	if withLogs, ok := (indexer).(loggedIndexer[K, V]); ok {
 		withLogs.logger = slog.New(handler)
 
+		if len(opts) > 0 {
+			withLogs.slowQueryThreshold = config.slowQueryThreshold
+			withLogs.byteEncoding = config.byteEncoding
+		}
+
 		return withLogs
 	}
 
 	return loggedIndexer[K, V]{
-		indexer: indexer,
-		logger:  slog.New(handler),
+		indexer:            indexer,
+		logger:             slog.New(handler),
+		slowQueryThreshold: config.slowQueryThreshold,
+		byteEncoding:       config.byteEncoding,
 	}
 }
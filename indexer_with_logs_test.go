@@ -0,0 +1,83 @@
+package fts
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type slowIndexer[K SQLType, V SQLType] struct {
+	Indexer[K, V]
+
+	delay time.Duration
+}
+
+func (i slowIndexer[K, V]) Search(context.Context, V) ([]Attribute[K, V], error) {
+	time.Sleep(i.delay)
+
+	return nil, nil
+}
+
+func TestLoggedIndexer_SlowQueryThreshold(t *testing.T) {
+	for _, testcase := range []struct {
+		name      string
+		delay     time.Duration
+		threshold time.Duration
+		warns     bool
+	}{
+		{
+			name:      "AboveThreshold",
+			delay:     20 * time.Millisecond,
+			threshold: 5 * time.Millisecond,
+			warns:     true,
+		},
+		{
+			name:      "BelowThreshold",
+			threshold: time.Second,
+		},
+		{
+			name:  "NoThreshold",
+			delay: 20 * time.Millisecond,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			indexer := IndexerWithLogs[int, string](
+				slowIndexer[int, string]{Indexer: NoOp[int, string](), delay: testcase.delay},
+				slog.NewTextHandler(buf, nil),
+				WithSlowQueryThreshold(testcase.threshold),
+			)
+
+			_, err := indexer.Search(context.Background(), "gold")
+			require.NoError(t, err)
+
+			logs := buf.String()
+
+			if !testcase.warns {
+				require.NotContains(t, logs, "slow search")
+
+				return
+			}
+
+			require.Contains(t, logs, "level=WARN msg=\"slow search\"")
+			require.Contains(t, logs, "search_term=gold")
+			require.Contains(t, logs, "threshold=5ms")
+		})
+	}
+}
+
+func TestIndexerWithLogs_NilIndexer(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	indexer := IndexerWithLogs[int, string](nil, slog.NewTextHandler(buf, nil))
+	require.Equal(t, NoOp[int, string](), indexer)
+
+	logs := buf.String()
+	require.Contains(t, logs, "level=WARN msg=\"falling back to a no-op Indexer\"")
+	require.Contains(t, logs, "reason=\"nil Indexer\"")
+}
@@ -22,6 +22,32 @@ type Metrics interface {
 	ObserveDeleteLatency(ctx context.Context, dur time.Duration)
 }
 
+// noOpFallbackMetrics is implemented by Metrics that count the times a decorator falls back to a no-op Indexer, such as
+// the metrics package's Prometheus Metrics.
+type noOpFallbackMetrics interface {
+	IncNoOpFallbackTotal()
+}
+
+// searchPageMetrics is implemented by Metrics that observe the limit and offset of paginated searches, such as the
+// metrics package's Prometheus Metrics.
+type searchPageMetrics interface {
+	ObserveSearchPage(limit, offset int)
+}
+
+// updateMetrics is implemented by Metrics that count and observe the latency of update requests, such as the metrics
+// package's Prometheus Metrics.
+type updateMetrics interface {
+	IncUpdatesTotal()
+	IncUpdatesFailed()
+	ObserveUpdateLatency(ctx context.Context, dur time.Duration)
+}
+
+// clearMetrics is implemented by Metrics that count clear requests, such as the metrics package's Prometheus Metrics.
+type clearMetrics interface {
+	IncClearsTotal()
+	IncClearsFailed()
+}
+
 type metricsIndexer[K SQLType, V SQLType] struct {
 	indexer Indexer[K, V]
 	metrics Metrics
@@ -51,6 +77,37 @@ func (i metricsIndexer[K, V]) Search(ctx context.Context, searchTerm V) (res []A
 	return res, err
 }
 
+// SearchPaginated implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's SearchPaginated method, registering the same counter and latency
+// observation metrics as Search; as well as the limit and offset of the search, for Metrics implementing an
+// ObserveSearchPage method (such as the metrics package's).
+//
+// This call will look for matches for the input value through the indexed terms, returning at most limit matching
+// Attribute after skipping the first offset ones.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query and the offset is zero.
+func (i metricsIndexer[K, V]) SearchPaginated(
+	ctx context.Context, searchTerm V, limit, offset int,
+) (res []Attribute[K, V], err error) {
+	start := time.Now()
+	i.metrics.IncSearchesTotal()
+
+	if page, ok := i.metrics.(searchPageMetrics); ok {
+		page.ObserveSearchPage(limit, offset)
+	}
+
+	res, err = i.indexer.SearchPaginated(ctx, searchTerm, limit, offset)
+	if err != nil {
+		i.metrics.IncSearchesFailed()
+	}
+
+	i.metrics.ObserveSearchLatency(ctx, time.Since(start))
+
+	return res, err
+}
+
 // Insert implements the Indexer interface.
 //
 // This implementation calls the underlying Indexer's Insert method, registering counter and latency observation
@@ -97,6 +154,64 @@ func (i metricsIndexer[K, V]) Delete(ctx context.Context, keys ...K) error {
 	return err
 }
 
+// Update implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Update method, registering counter and latency observation
+// metrics about this call for Metrics implementing the IncUpdatesTotal, IncUpdatesFailed and ObserveUpdateLatency
+// methods (such as the metrics package's).
+//
+// This call replaces the values of the attributes in the Indexer matching the input Attribute's keys, within a single
+// database transaction.
+func (i metricsIndexer[K, V]) Update(ctx context.Context, attrs ...Attribute[K, V]) error {
+	m, ok := i.metrics.(updateMetrics)
+	if !ok {
+		return i.indexer.Update(ctx, attrs...)
+	}
+
+	start := time.Now()
+	m.IncUpdatesTotal()
+
+	err := i.indexer.Update(ctx, attrs...)
+	if err != nil {
+		m.IncUpdatesFailed()
+	}
+
+	m.ObserveUpdateLatency(ctx, time.Since(start))
+
+	return err
+}
+
+// Count implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Count method, without registering any metrics.
+//
+// This call returns the number of indexed rows in the Indexer.
+func (i metricsIndexer[K, V]) Count(ctx context.Context) (int64, error) {
+	return i.indexer.Count(ctx)
+}
+
+// Clear implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Clear method, registering counter metrics about this call for
+// Metrics implementing the IncClearsTotal and IncClearsFailed methods (such as the metrics package's).
+//
+// This call removes all attributes in the Indexer, within a single database transaction.
+func (i metricsIndexer[K, V]) Clear(ctx context.Context) error {
+	m, ok := i.metrics.(clearMetrics)
+	if !ok {
+		return i.indexer.Clear(ctx)
+	}
+
+	m.IncClearsTotal()
+
+	err := i.indexer.Clear(ctx)
+	if err != nil {
+		m.IncClearsFailed()
+	}
+
+	return err
+}
+
 // Shutdown implements the Indexer interface.
 //
 // This implementation checks if the Metrics implementation contains either a Shutdown or a Close method, calling it if
@@ -122,13 +237,18 @@ func (i metricsIndexer[K, V]) Shutdown(ctx context.Context) error {
 
 // IndexerWithMetrics decorates the input Indexer with a Metrics interface.
 //
-// If the Indexer is nil, a no-op Indexer is returned. If the input Metrics is nil, a default
-// Prometheus metrics handler is created as a safe default, on port 8080. If the input Indexer is already an
-// Indexer with Metrics; then its Metrics is replaced with this one (input or default one).
+// If the Indexer is nil, a no-op Indexer is returned; which is counted by Metrics implementing an IncNoOpFallbackTotal
+// method (such as the metrics package's). If the input Metrics is nil, a default Prometheus metrics handler is created
+// as a safe default, on port 8080; if its port cannot be bound, the input Indexer is returned without metrics. If the
+// input Indexer is already an Indexer with Metrics; then its Metrics is replaced with this one (input or default one).
 //
 // This Indexer will not add any new functionality besides decorating the Indexer with metrics registry.
 func IndexerWithMetrics[K SQLType, V SQLType](indexer Indexer[K, V], m Metrics) Indexer[K, V] {
 	if indexer == nil {
+		if fallback, ok := m.(noOpFallbackMetrics); ok {
+			fallback.IncNoOpFallbackTotal()
+		}
+
 		return NoOp[K, V]()
 	}
 
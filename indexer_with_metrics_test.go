@@ -0,0 +1,160 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/fts/metrics"
+)
+
+func TestIndexerWithMetrics_MultipleIndexes(t *testing.T) {
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+	indexers := make([]Indexer[int, string], 0, 2)
+
+	for _, name := range []string{"first", "second"} {
+		m, err := metrics.New(0, metrics.WithIndexName(name), metrics.WithRegistry(reg))
+		require.NoError(t, err)
+
+		indexer, err := New(
+			[]Attribute[int, string]{{Key: 1, Value: "some data"}},
+			WithURI(filepath.Join(t.TempDir(), "index.db")),
+			WithMetrics(m),
+		)
+		require.NoError(t, err)
+
+		indexers = append(indexers, indexer)
+	}
+
+	for _, indexer := range indexers {
+		_, err := indexer.Search(ctx, "data")
+		require.NoError(t, err)
+
+		require.NoError(t, indexer.Shutdown(ctx))
+	}
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	var searches float64
+
+	for _, family := range families {
+		if family.GetName() == "searches_received_total" {
+			require.Len(t, family.GetMetric(), 2)
+
+			for _, metric := range family.GetMetric() {
+				searches += metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	require.Equal(t, float64(2), searches)
+}
+
+func TestIndexerWithMetrics_NilIndexer(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	m, err := metrics.New(0, metrics.WithRegistry(reg))
+	require.NoError(t, err)
+
+	indexer := IndexerWithMetrics[int, string](nil, m)
+	require.Equal(t, NoOp[int, string](), indexer)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	var fallbacks float64
+
+	for _, family := range families {
+		if family.GetName() == "noop_fallback_total" {
+			for _, metric := range family.GetMetric() {
+				fallbacks += metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	require.Equal(t, float64(1), fallbacks)
+}
+
+// searchMetrics only implements the methods in the Metrics interface, counting the search requests.
+type searchMetrics struct {
+	searches int
+}
+
+func (m *searchMetrics) IncSearchesTotal()                                   { m.searches++ }
+func (m *searchMetrics) IncSearchesFailed()                                  {}
+func (m *searchMetrics) ObserveSearchLatency(context.Context, time.Duration) {}
+func (m *searchMetrics) IncInsertsTotal()                                    {}
+func (m *searchMetrics) IncInsertsFailed()                                   {}
+func (m *searchMetrics) ObserveInsertLatency(context.Context, time.Duration) {}
+func (m *searchMetrics) IncDeletesTotal()                                    {}
+func (m *searchMetrics) IncDeletesFailed()                                   {}
+func (m *searchMetrics) ObserveDeleteLatency(context.Context, time.Duration) {}
+func (m *searchMetrics) IncClearsTotal()                                     {}
+func (m *searchMetrics) IncClearsFailed()                                    {}
+
+func TestIndexerWithMetrics_OptionalMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Prometheus", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+
+		m, err := metrics.New(0, metrics.WithRegistry(reg))
+		require.NoError(t, err)
+
+		indexer, err := New(
+			[]Attribute[int, string]{{Key: 1, Value: "some data"}},
+			WithURI(filepath.Join(t.TempDir(), "index.db")),
+			WithMetrics(m),
+		)
+		require.NoError(t, err)
+
+		require.NoError(t, indexer.Update(ctx, Attribute[int, string]{Key: 1, Value: "other data"}))
+		require.NoError(t, indexer.Clear(ctx))
+		require.NoError(t, indexer.Shutdown(ctx))
+
+		families, err := reg.Gather()
+		require.NoError(t, err)
+
+		counters := make(map[string]float64)
+
+		for _, family := range families {
+			for _, metric := range family.GetMetric() {
+				counters[family.GetName()] += metric.GetCounter().GetValue()
+			}
+		}
+
+		require.Equal(t, float64(1), counters["updates_received_total"])
+		require.Equal(t, float64(1), counters["clears_received_total"])
+	})
+
+	t.Run("WithoutOptionalMetrics", func(t *testing.T) {
+		m := &searchMetrics{}
+
+		indexer, err := New(
+			[]Attribute[int, string]{{Key: 1, Value: "some data"}},
+			WithURI(filepath.Join(t.TempDir(), "index.db")),
+			WithMetrics(m),
+		)
+		require.NoError(t, err)
+
+		require.NoError(t, indexer.Update(ctx, Attribute[int, string]{Key: 1, Value: "other data"}))
+
+		res, err := indexer.Search(ctx, "other")
+		require.NoError(t, err)
+		require.Equal(t, []Attribute[int, string]{{Key: 1, Value: "other data"}}, res)
+		require.Equal(t, 1, m.searches)
+
+		require.NoError(t, indexer.Clear(ctx))
+
+		count, err := indexer.Count(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		require.NoError(t, indexer.Shutdown(ctx))
+	})
+}
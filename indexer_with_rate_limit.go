@@ -0,0 +1,238 @@
+package fts
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+type rateLimitedIndexer[K SQLType, V SQLType] struct {
+	indexer Indexer[K, V]
+
+	searches *rate.Limiter
+	writes   *rate.Limiter
+	noWait   bool
+}
+
+// Search implements the Indexer interface.
+//
+// This implementation takes a token from the search rate limiter before calling the underlying Indexer's Search
+// method, either waiting for it (until the context is done) or, when configured not to wait, failing with an
+// ErrRateLimited error if none is available.
+//
+// This call will look for matches for the input value through the indexed terms, returning a collection of matching
+// Attribute, which will contain both key and (full) value for that match.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query.
+func (i rateLimitedIndexer[K, V]) Search(ctx context.Context, searchTerm V) (res []Attribute[K, V], err error) {
+	if err = i.take(ctx, i.searches, i.noWait); err != nil {
+		return nil, err
+	}
+
+	return i.indexer.Search(ctx, searchTerm)
+}
+
+// SearchPaginated implements the Indexer interface.
+//
+// This implementation takes a token from the search rate limiter before calling the underlying Indexer's
+// SearchPaginated method, as with Search.
+//
+// This call will look for matches for the input value through the indexed terms, returning at most limit matching
+// Attribute after skipping the first offset ones.
+func (i rateLimitedIndexer[K, V]) SearchPaginated(
+	ctx context.Context, searchTerm V, limit, offset int,
+) (res []Attribute[K, V], err error) {
+	if err = i.take(ctx, i.searches, i.noWait); err != nil {
+		return nil, err
+	}
+
+	return i.indexer.SearchPaginated(ctx, searchTerm, limit, offset)
+}
+
+// Insert implements the Indexer interface.
+//
+// This implementation waits for a token from the write rate limiter (if set, and until the context is done) before
+// calling the underlying Indexer's Insert method.
+//
+// This call indexes new attributes in the Indexer, via the input Attribute's key and value content.
+//
+// A database transaction is performed in order to ensure that the query is executed as quickly as possible; in case
+// multiple items are provided as input. This is especially useful for the initial load sequence.
+func (i rateLimitedIndexer[K, V]) Insert(ctx context.Context, attrs ...Attribute[K, V]) error {
+	if err := i.take(ctx, i.writes, false); err != nil {
+		return err
+	}
+
+	return i.indexer.Insert(ctx, attrs...)
+}
+
+// Delete implements the Indexer interface.
+//
+// This implementation waits for a token from the write rate limiter (if set, and until the context is done) before
+// calling the underlying Indexer's Delete method.
+//
+// This call removes attributes in the Indexer, which match input K-type keys.
+//
+// A database transaction is performed in order to ensure that the query is executed as quickly as possible; in case
+// multiple items are provided as input.
+func (i rateLimitedIndexer[K, V]) Delete(ctx context.Context, keys ...K) error {
+	if err := i.take(ctx, i.writes, false); err != nil {
+		return err
+	}
+
+	return i.indexer.Delete(ctx, keys...)
+}
+
+// Update implements the Indexer interface.
+//
+// This implementation waits for a token from the write rate limiter (if set, and until the context is done) before
+// calling the underlying Indexer's Update method.
+//
+// This call replaces the values of the attributes in the Indexer matching the input Attribute's keys, within a single
+// database transaction.
+func (i rateLimitedIndexer[K, V]) Update(ctx context.Context, attrs ...Attribute[K, V]) error {
+	if err := i.take(ctx, i.writes, false); err != nil {
+		return err
+	}
+
+	return i.indexer.Update(ctx, attrs...)
+}
+
+// Count implements the Indexer interface.
+//
+// This implementation takes a token from the search rate limiter before calling the underlying Indexer's Count
+// method, as with Search.
+//
+// This call returns the number of indexed rows in the Indexer.
+func (i rateLimitedIndexer[K, V]) Count(ctx context.Context) (int64, error) {
+	if err := i.take(ctx, i.searches, i.noWait); err != nil {
+		return 0, err
+	}
+
+	return i.indexer.Count(ctx)
+}
+
+// Clear implements the Indexer interface.
+//
+// This implementation waits for a token from the write rate limiter (if set, and until the context is done) before
+// calling the underlying Indexer's Clear method.
+//
+// This call removes all attributes in the Indexer, within a single database transaction.
+func (i rateLimitedIndexer[K, V]) Clear(ctx context.Context) error {
+	if err := i.take(ctx, i.writes, false); err != nil {
+		return err
+	}
+
+	return i.indexer.Clear(ctx)
+}
+
+// Shutdown implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Shutdown method, without rate limiting.
+//
+// This call gracefully closes the Indexer.
+func (i rateLimitedIndexer[K, V]) Shutdown(ctx context.Context) error {
+	return i.indexer.Shutdown(ctx)
+}
+
+func (i rateLimitedIndexer[K, V]) take(ctx context.Context, limiter *rate.Limiter, noWait bool) error {
+	switch {
+	case limiter == nil:
+		return nil
+	case noWait:
+		if !limiter.Allow() {
+			return ErrRateLimited
+		}
+
+		return nil
+	default:
+		return limiter.Wait(ctx)
+	}
+}
+
+func newLimiter(rps float64, burst int) *rate.Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return rate.NewLimiter(rate.Limit(rps), burst)
+}
+
+func withSearchRateLimit[K SQLType, V SQLType](
+	indexer Indexer[K, V], rps float64, burst int, noWait bool,
+) Indexer[K, V] {
+	if indexer == nil {
+		return NoOp[K, V]()
+	}
+
+	if rps <= 0 {
+		return indexer
+	}
+
+	if limited, ok := (indexer).(rateLimitedIndexer[K, V]); ok {
+		limited.searches = newLimiter(rps, burst)
+		limited.noWait = noWait
+
+		return limited
+	}
+
+	return rateLimitedIndexer[K, V]{
+		indexer:  indexer,
+		searches: newLimiter(rps, burst),
+		noWait:   noWait,
+	}
+}
+
+// IndexerWithRateLimit decorates the input Indexer with a search rate limiter, allowing up to rps searches per second
+// with bursts of up to burst searches (at least one). Searches exceeding the limit wait for their turn, and fail with
+// the context's error if it is done before then (or if its deadline would be exceeded while waiting).
+//
+// If the Indexer is nil, a no-op Indexer is returned. If rps is zero or below, the input Indexer is returned as-is. If
+// the input Indexer is already rate-limited, its search rate limiter is replaced with this one.
+//
+// This Indexer will not add any new functionality besides limiting the rate of searches in the Indexer.
+func IndexerWithRateLimit[K SQLType, V SQLType](indexer Indexer[K, V], rps float64, burst int) Indexer[K, V] {
+	return withSearchRateLimit(indexer, rps, burst, false)
+}
+
+// IndexerWithRateLimitNoWait decorates the input Indexer with a non-blocking search rate limiter, allowing up to rps
+// searches per second with bursts of up to burst searches (at least one). Searches exceeding the limit fail immediately
+// with an ErrRateLimited error.
+//
+// If the Indexer is nil, a no-op Indexer is returned. If rps is zero or below, the input Indexer is returned as-is. If
+// the input Indexer is already rate-limited, its search rate limiter is replaced with this one.
+//
+// This Indexer will not add any new functionality besides limiting the rate of searches in the Indexer.
+func IndexerWithRateLimitNoWait[K SQLType, V SQLType](indexer Indexer[K, V], rps float64, burst int) Indexer[K, V] {
+	return withSearchRateLimit(indexer, rps, burst, true)
+}
+
+// IndexerWithWriteRateLimit decorates the input Indexer with a write rate limiter, allowing up to rps Insert and Delete
+// calls per second with bursts of up to burst calls (at least one), independently of any search rate limiter. Writes
+// exceeding the limit wait for their turn, and fail with the context's error if it is done before then.
+//
+// If the Indexer is nil, a no-op Indexer is returned. If rps is zero or below, the input Indexer is returned as-is. If
+// the input Indexer is already rate-limited, this write rate limiter is added to (or replaces the one in) it.
+//
+// This Indexer will not add any new functionality besides limiting the rate of writes in the Indexer.
+func IndexerWithWriteRateLimit[K SQLType, V SQLType](indexer Indexer[K, V], rps float64, burst int) Indexer[K, V] {
+	if indexer == nil {
+		return NoOp[K, V]()
+	}
+
+	if rps <= 0 {
+		return indexer
+	}
+
+	if limited, ok := (indexer).(rateLimitedIndexer[K, V]); ok {
+		limited.writes = newLimiter(rps, burst)
+
+		return limited
+	}
+
+	return rateLimitedIndexer[K, V]{
+		indexer: indexer,
+		writes:  newLimiter(rps, burst),
+	}
+}
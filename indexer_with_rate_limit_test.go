@@ -0,0 +1,117 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexerWithRateLimit(t *testing.T) {
+	const (
+		rps   = 20
+		burst = 2
+		calls = 6
+	)
+
+	// calls beyond the burst are spaced 1/rps apart
+	minElapsed := time.Duration(calls-burst) * time.Second / rps
+
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "struck gold"},
+	}
+
+	newIndexer := func(t *testing.T) Indexer[int, string] {
+		index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+		require.NoError(t, err)
+
+		return index
+	}
+
+	t.Run("Success/SearchesWait", func(t *testing.T) {
+		ctx := context.Background()
+		indexer := IndexerWithRateLimit(newIndexer(t), rps, burst)
+
+		defer func() {
+			require.NoError(t, indexer.Shutdown(ctx))
+		}()
+
+		start := time.Now()
+
+		for i := 0; i < calls; i++ {
+			_, err := indexer.Search(ctx, "gold")
+			require.NoError(t, err)
+		}
+
+		require.GreaterOrEqual(t, time.Since(start), minElapsed*9/10)
+	})
+
+	t.Run("Success/WritesWait", func(t *testing.T) {
+		ctx := context.Background()
+		indexer := IndexerWithWriteRateLimit(IndexerWithRateLimitNoWait(newIndexer(t), rps, burst), rps, burst)
+
+		defer func() {
+			require.NoError(t, indexer.Shutdown(ctx))
+		}()
+
+		start := time.Now()
+
+		for i := 0; i < calls; i++ {
+			require.NoError(t, indexer.Insert(ctx, Attribute[int, string]{Key: i + 2, Value: "gold"}))
+		}
+
+		require.GreaterOrEqual(t, time.Since(start), minElapsed*9/10)
+
+		// writes do not consume tokens from the search rate limiter
+		for i := 0; i < burst; i++ {
+			_, err := indexer.Search(ctx, "gold")
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("Fail/NoWait", func(t *testing.T) {
+		ctx := context.Background()
+		indexer := IndexerWithRateLimitNoWait(newIndexer(t), rps, burst)
+
+		defer func() {
+			require.NoError(t, indexer.Shutdown(ctx))
+		}()
+
+		for i := 0; i < burst; i++ {
+			_, err := indexer.Search(ctx, "gold")
+			require.NoError(t, err)
+		}
+
+		_, err := indexer.Search(ctx, "gold")
+		require.ErrorIs(t, err, ErrRateLimited)
+	})
+
+	t.Run("Fail/CanceledContext", func(t *testing.T) {
+		indexer := IndexerWithRateLimit(newIndexer(t), rps, 1)
+
+		defer func() {
+			require.NoError(t, indexer.Shutdown(context.Background()))
+		}()
+
+		_, err := indexer.Search(context.Background(), "gold")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = indexer.Search(ctx, "gold")
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Success/Disabled", func(t *testing.T) {
+		index := newIndexer(t)
+
+		defer func() {
+			require.NoError(t, index.Shutdown(context.Background()))
+		}()
+
+		require.Equal(t, index, IndexerWithRateLimit(index, 0, burst))
+	})
+}
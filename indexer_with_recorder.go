@@ -0,0 +1,235 @@
+package fts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// Recording is a Search call captured by an Indexer decorated with IndexerWithRecorder, written as a JSON line: its
+// search term, and either its results or its error message.
+type Recording[K SQLType, V SQLType] struct {
+	Term    V                 `json:"term"`
+	Results []Attribute[K, V] `json:"results,omitempty"`
+	Err     string            `json:"error,omitempty"`
+}
+
+func newRecording[K SQLType, V SQLType](searchTerm V, res []Attribute[K, V], err error) Recording[K, V] {
+	rec := Recording[K, V]{Term: searchTerm, Results: res}
+	if err != nil {
+		rec.Err = err.Error()
+	}
+
+	return rec
+}
+
+type recorderIndexer[K SQLType, V SQLType] struct {
+	indexer Indexer[K, V]
+
+	mu  *sync.Mutex
+	enc *json.Encoder
+}
+
+// Search implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Search method, writing its search term and its results (or
+// error) as a Recording to the configured writer.
+//
+// This call will look for matches for the input value through the indexed terms, returning a collection of matching
+// Attribute, which will contain both key and (full) value for that match.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query.
+func (i recorderIndexer[K, V]) Search(ctx context.Context, searchTerm V) ([]Attribute[K, V], error) {
+	res, err := i.indexer.Search(ctx, searchTerm)
+
+	i.mu.Lock()
+	// failing to record a search must not fail the search itself
+	_ = i.enc.Encode(newRecording(searchTerm, res, err))
+	i.mu.Unlock()
+
+	return res, err
+}
+
+// SearchPaginated implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's SearchPaginated method, without recording it: a ReplayHarness
+// only replays Search calls.
+//
+// This call will look for matches for the input value through the indexed terms, returning at most limit matching
+// Attribute after skipping the first offset ones.
+func (i recorderIndexer[K, V]) SearchPaginated(
+	ctx context.Context, searchTerm V, limit, offset int,
+) ([]Attribute[K, V], error) {
+	return i.indexer.SearchPaginated(ctx, searchTerm, limit, offset)
+}
+
+// Insert implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Insert method.
+//
+// This call indexes new attributes in the Indexer, via the input Attribute's key and value content.
+func (i recorderIndexer[K, V]) Insert(ctx context.Context, attrs ...Attribute[K, V]) error {
+	return i.indexer.Insert(ctx, attrs...)
+}
+
+// Delete implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Delete method.
+//
+// This call removes attributes in the Indexer, which match input K-type keys.
+func (i recorderIndexer[K, V]) Delete(ctx context.Context, keys ...K) error {
+	return i.indexer.Delete(ctx, keys...)
+}
+
+// Update implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Update method.
+//
+// This call replaces the values of the attributes in the Indexer matching the input Attribute's keys.
+func (i recorderIndexer[K, V]) Update(ctx context.Context, attrs ...Attribute[K, V]) error {
+	return i.indexer.Update(ctx, attrs...)
+}
+
+// Count implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Count method.
+//
+// This call returns the number of indexed rows in the Indexer.
+func (i recorderIndexer[K, V]) Count(ctx context.Context) (int64, error) {
+	return i.indexer.Count(ctx)
+}
+
+// Clear implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Clear method.
+//
+// This call removes all attributes in the Indexer.
+func (i recorderIndexer[K, V]) Clear(ctx context.Context) error {
+	return i.indexer.Clear(ctx)
+}
+
+// Shutdown implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Shutdown method.
+//
+// This call gracefully closes the Indexer.
+func (i recorderIndexer[K, V]) Shutdown(ctx context.Context) error {
+	return i.indexer.Shutdown(ctx)
+}
+
+// IndexerWithRecorder decorates the input Indexer so that each Search call is recorded to the input writer, as a
+// Recording serialized as a JSON line; capturing real search traffic for regression tests (see ReplayHarness).
+//
+// Writes to the writer are serialized, and errors writing to it are ignored so that they do not affect the searches.
+//
+// If the Indexer is nil, a no-op Indexer is returned. If the writer is nil, the input Indexer is returned as-is.
+func IndexerWithRecorder[K SQLType, V SQLType](indexer Indexer[K, V], w io.Writer) Indexer[K, V] {
+	if indexer == nil {
+		return NoOp[K, V]()
+	}
+
+	if w == nil {
+		return indexer
+	}
+
+	return recorderIndexer[K, V]{
+		indexer: indexer,
+		mu:      &sync.Mutex{},
+		enc:     json.NewEncoder(w),
+	}
+}
+
+// ReplayDiff describes a recorded Search call whose replay differs from its Recording.
+type ReplayDiff[K SQLType, V SQLType] struct {
+	// Call is the (1-based) position of the search in the recording.
+	Call int
+	// Recorded is the Recording of the search, as read from the recording.
+	Recorded Recording[K, V]
+	// Replayed is the Recording of the search, as replayed.
+	Replayed Recording[K, V]
+}
+
+// ReplayHarness re-runs the searches recorded with IndexerWithRecorder against an Indexer (such as a fresh index,
+// loaded with the same data), reporting the searches whose results or errors differ from their Recording.
+type ReplayHarness[K SQLType, V SQLType] struct {
+	indexer Indexer[K, V]
+}
+
+// NewReplayHarness creates a ReplayHarness replaying searches against the input Indexer.
+func NewReplayHarness[K SQLType, V SQLType](indexer Indexer[K, V]) ReplayHarness[K, V] {
+	return ReplayHarness[K, V]{indexer: indexer}
+}
+
+// Replay reads the Recording JSON lines from the input reader and re-runs each search against the ReplayHarness'
+// Indexer, returning a ReplayDiff for each search whose results or error message differ from the recorded ones. The
+// results are compared ignoring their order (as a multiset), like ftstest.AssertResults; so that a change in how the
+// results are sorted is not reported as a diff. No diffs means the replay reproduced the recorded traffic.
+//
+// This call returns an ErrZeroIndexer error if the ReplayHarness has no Indexer, or an error if a Recording cannot be
+// decoded from the reader, alongside the diffs found up to that point.
+func (h ReplayHarness[K, V]) Replay(ctx context.Context, r io.Reader) ([]ReplayDiff[K, V], error) {
+	if h.indexer == nil {
+		return nil, ErrZeroIndexer
+	}
+
+	var (
+		diffs []ReplayDiff[K, V]
+		dec   = json.NewDecoder(r)
+	)
+
+	for call := 1; ; call++ {
+		var recorded Recording[K, V]
+
+		if err := dec.Decode(&recorded); err != nil {
+			if errors.Is(err, io.EOF) {
+				return diffs, nil
+			}
+
+			return diffs, fmt.Errorf("decoding recorded call %d: %w", call, err)
+		}
+
+		res, err := h.indexer.Search(ctx, recorded.Term)
+		replayed := newRecording(recorded.Term, res, err)
+
+		if replayed.Err != recorded.Err || !sameResults(replayed.Results, recorded.Results) {
+			diffs = append(diffs, ReplayDiff[K, V]{
+				Call:     call,
+				Recorded: recorded,
+				Replayed: replayed,
+			})
+		}
+	}
+}
+
+// sameResults returns true if both input results hold the same Attribute, as many times each, in any order; where no
+// results (as omitted in a Recording) and empty results are the same.
+func sameResults[K SQLType, V SQLType](a, b []Attribute[K, V]) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	matched := make([]bool, len(b))
+
+	for idx := range a {
+		found := false
+
+		for j := range b {
+			if !matched[j] && reflect.DeepEqual(a[idx], b[j]) {
+				matched[j], found = true, true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
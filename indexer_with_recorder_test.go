@@ -0,0 +1,93 @@
+package fts
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexerWithRecorder(t *testing.T) {
+	ctx := context.Background()
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "struck gold"},
+		{Key: 2, Value: "gold and silver"},
+		{Key: 3, Value: "a silver lining"},
+	}
+	terms := []string{"gold", "silver", "gold AND silver", "bronze"}
+
+	buf := &bytes.Buffer{}
+
+	recorded, err := New(attrs, WithMemoryName("recorded"))
+	require.NoError(t, err)
+
+	recorded = IndexerWithRecorder(recorded, buf)
+
+	for _, term := range terms {
+		_, _ = recorded.Search(ctx, term)
+	}
+
+	require.NoError(t, recorded.Shutdown(ctx))
+
+	recording := buf.String()
+	require.Len(t, strings.Split(strings.TrimSpace(recording), "\n"), len(terms))
+
+	t.Run("Success/IdenticalResults", func(t *testing.T) {
+		replayed, err := New(attrs, WithMemoryName("replayed"))
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, replayed.Shutdown(ctx))
+		}()
+
+		diffs, err := NewReplayHarness(replayed).Replay(ctx, strings.NewReader(recording))
+		require.NoError(t, err)
+		require.Empty(t, diffs)
+	})
+
+	t.Run("Success/DifferentResults", func(t *testing.T) {
+		replayed, err := New(attrs[:2], WithMemoryName("replayed-partial"))
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, replayed.Shutdown(ctx))
+		}()
+
+		diffs, err := NewReplayHarness(replayed).Replay(ctx, strings.NewReader(recording))
+		require.NoError(t, err)
+		require.Len(t, diffs, 1)
+		require.Equal(t, 2, diffs[0].Call)
+		require.Equal(t, "silver", diffs[0].Recorded.Term)
+		require.Len(t, diffs[0].Recorded.Results, 2)
+		require.Len(t, diffs[0].Replayed.Results, 1)
+	})
+
+	t.Run("Success/ReorderedResults", func(t *testing.T) {
+		reversed := []Attribute[int, string]{attrs[2], attrs[1], attrs[0]}
+
+		replayed, err := New(reversed, WithMemoryName("replayed-reversed"))
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, replayed.Shutdown(ctx))
+		}()
+
+		diffs, err := NewReplayHarness(replayed).Replay(ctx, strings.NewReader(recording))
+		require.NoError(t, err)
+		require.Empty(t, diffs)
+	})
+
+	t.Run("Fail/NoIndexer", func(t *testing.T) {
+		diffs, err := ReplayHarness[int, string]{}.Replay(ctx, strings.NewReader(recording))
+		require.ErrorIs(t, err, ErrZeroIndexer)
+		require.Empty(t, diffs)
+	})
+
+	t.Run("Fail/InvalidRecording", func(t *testing.T) {
+		diffs, err := NewReplayHarness(NoOp[int, string]()).Replay(ctx, strings.NewReader("not json"))
+		require.Error(t, err)
+		require.Empty(t, diffs)
+	})
+}
@@ -2,16 +2,19 @@ package fts
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/zalgonoise/cfg"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type tracedIndexer[K SQLType, V SQLType] struct {
-	indexer Indexer[K, V]
-	tracer  trace.Tracer
+	indexer  Indexer[K, V]
+	tracer   trace.Tracer
+	shutdown func(ctx context.Context) error
 }
 
 // Search implements the Indexer interface.
@@ -44,6 +47,42 @@ func (i tracedIndexer[K, V]) Search(ctx context.Context, searchTerm V) ([]Attrib
 	return res, err
 }
 
+// SearchPaginated implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's SearchPaginated method, registering spans (with the limit and
+// offset of the search) that last for this call's lifetime.
+//
+// This call will look for matches for the input value through the indexed terms, returning at most limit matching
+// Attribute after skipping the first offset ones.
+//
+// This call returns an error if the underlying SQL query fails, if scanning for the results fails, or an
+// ErrNotFoundKeyword error if there are zero results from the query and the offset is zero.
+func (i tracedIndexer[K, V]) SearchPaginated(
+	ctx context.Context, searchTerm V, limit, offset int,
+) ([]Attribute[K, V], error) {
+	ctx, span := i.tracer.Start(ctx, "search_paginated",
+		trace.WithAttributes(
+			attribute.String("search_term", fmt.Sprintf("%v", searchTerm)),
+			attribute.Int("limit", limit),
+			attribute.Int("offset", offset),
+		),
+	)
+
+	defer span.End()
+
+	res, err := i.indexer.SearchPaginated(ctx, searchTerm, limit, offset)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+
+		return res, err
+	}
+
+	span.SetAttributes(attribute.Int("num_results", len(res)))
+
+	return res, err
+}
+
 // Insert implements the Indexer interface.
 //
 // This implementation calls the underlying Indexer's Insert method, registering spans that last for this call's
@@ -94,23 +133,101 @@ func (i tracedIndexer[K, V]) Delete(ctx context.Context, keys ...K) error {
 	return err
 }
 
+// Update implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Update method, registering spans that last for this call's
+// lifetime.
+//
+// This call replaces the values of the attributes in the Indexer matching the input Attribute's keys, within a single
+// database transaction.
+func (i tracedIndexer[K, V]) Update(ctx context.Context, attrs ...Attribute[K, V]) error {
+	ctx, span := i.tracer.Start(ctx, "update",
+		trace.WithAttributes(attribute.Int("num_attributes", len(attrs))),
+	)
+
+	defer span.End()
+
+	err := i.indexer.Update(ctx, attrs...)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+	}
+
+	return err
+}
+
+// Count implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Count method, registering spans (with the count) that last for
+// this call's lifetime.
+//
+// This call returns the number of indexed rows in the Indexer.
+func (i tracedIndexer[K, V]) Count(ctx context.Context) (int64, error) {
+	ctx, span := i.tracer.Start(ctx, "count")
+
+	defer span.End()
+
+	count, err := i.indexer.Count(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+
+		return count, err
+	}
+
+	span.SetAttributes(attribute.Int64("count", count))
+
+	return count, nil
+}
+
+// Clear implements the Indexer interface.
+//
+// This implementation calls the underlying Indexer's Clear method, registering spans that last for this call's
+// lifetime.
+//
+// This call removes all attributes in the Indexer, within a single database transaction.
+func (i tracedIndexer[K, V]) Clear(ctx context.Context) error {
+	ctx, span := i.tracer.Start(ctx, "clear")
+
+	defer span.End()
+
+	err := i.indexer.Clear(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+	}
+
+	return err
+}
+
 // Shutdown implements the Indexer interface.
 //
-// This implementation calls the underlying Indexer's Shutdown method.
+// This implementation calls the underlying Indexer's Shutdown method, and then the tracer's shutdown function if one is
+// set (see WithTraceShutdown), flushing any buffered spans.
 //
 // This call gracefully closes the Indexer.
 func (i tracedIndexer[K, V]) Shutdown(ctx context.Context) error {
-	return i.indexer.Shutdown(ctx)
+	err := i.indexer.Shutdown(ctx)
+
+	if i.shutdown != nil {
+		return errors.Join(err, i.shutdown(ctx))
+	}
+
+	return err
 }
 
 // IndexerWithTrace decorates the input Indexer with a trace.Tracer interface.
 //
-// If the Indexer is nil, a no-op Indexer is returned. If the input Metrics is nil, a default
-// Prometheus metrics handler is created as a safe default. If the input Indexer is already an Indexer with Metrics;
-// then its Metrics is replaced with this one (input or default one).
+// The traced Indexer is configured with the WithTraceShutdown option, if provided; other options are ignored.
+//
+// If the Indexer is nil, a no-op Indexer is returned. If the input trace.Tracer is nil, a no-op tracer is used as a
+// safe default. If the input Indexer is already a traced Indexer; then its tracer is replaced with this one (input or
+// default one), and its shutdown function with the input options' (if any).
 //
-// This Indexer will not add any new functionality besides decorating the Indexer with metrics registry.
-func IndexerWithTrace[K SQLType, V SQLType](indexer Indexer[K, V], tracer trace.Tracer) Indexer[K, V] {
+// This Indexer will not add any new functionality besides decorating the Indexer with spans.
+func IndexerWithTrace[K SQLType, V SQLType](
+	indexer Indexer[K, V], tracer trace.Tracer, opts ...cfg.Option[Config],
+) Indexer[K, V] {
 	if indexer == nil {
 		return NoOp[K, V]()
 	}
@@ -119,14 +236,21 @@ func IndexerWithTrace[K SQLType, V SQLType](indexer Indexer[K, V], tracer trace.
 		tracer = trace.NewNoopTracerProvider().Tracer("indexer")
 	}
 
-	if withLogs, ok := (indexer).(tracedIndexer[K, V]); ok {
-		withLogs.tracer = tracer
+	config := cfg.New[Config](opts...)
+
+	if withTrace, ok := (indexer).(tracedIndexer[K, V]); ok {
+		withTrace.tracer = tracer
+
+		if len(opts) > 0 {
+			withTrace.shutdown = config.traceShutdown
+		}
 
-		return withLogs
+		return withTrace
 	}
 
 	return tracedIndexer[K, V]{
-		indexer: indexer,
-		tracer:  tracer,
+		indexer:  indexer,
+		tracer:   tracer,
+		shutdown: config.traceShutdown,
 	}
 }
@@ -0,0 +1,104 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// retainingExporter is an in-memory exporter that keeps its spans after being shut down.
+type retainingExporter struct {
+	*tracetest.InMemoryExporter
+}
+
+func (retainingExporter) Shutdown(context.Context) error { return nil }
+
+func TestIndexerWithTrace_ShutdownFlushesSpans(t *testing.T) {
+	ctx := context.Background()
+	exporter := retainingExporter{tracetest.NewInMemoryExporter()}
+
+	// a long batch timeout ensures that spans are only exported when flushed
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(time.Hour)),
+	)
+
+	indexer, err := New(
+		[]Attribute[int, string]{{Key: 1, Value: "some data"}},
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithTrace(provider.Tracer("test")),
+		WithTraceShutdown(provider.Shutdown),
+	)
+	require.NoError(t, err)
+
+	_, err = indexer.Search(ctx, "data")
+	require.NoError(t, err)
+	require.Empty(t, exporter.GetSpans())
+
+	require.NoError(t, indexer.Shutdown(ctx))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	require.Equal(t, "search", spans[0].Name)
+}
+
+func TestIndexerWithTrace_SearchPaginated(t *testing.T) {
+	ctx := context.Background()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	indexer, err := New(
+		[]Attribute[int, string]{{Key: 1, Value: "some data"}, {Key: 2, Value: "more data"}},
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithTrace(provider.Tracer("test")),
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, indexer.Shutdown(ctx))
+	}()
+
+	res, err := indexer.SearchPaginated(ctx, "data", 1, 1)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	require.Equal(t, "search_paginated", spans[0].Name)
+	require.Subset(t, spans[0].Attributes, []attribute.KeyValue{
+		attribute.Int("limit", 1),
+		attribute.Int("offset", 1),
+		attribute.Int("num_results", 1),
+	})
+}
+
+func TestIndexerWithTrace_Count(t *testing.T) {
+	ctx := context.Background()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	indexer, err := New(
+		[]Attribute[int, string]{{Key: 1, Value: "some data"}, {Key: 2, Value: "more data"}},
+		WithURI(filepath.Join(t.TempDir(), "index.db")),
+		WithTrace(provider.Tracer("test")),
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, indexer.Shutdown(ctx))
+	}()
+
+	count, err := indexer.Count(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	require.Equal(t, "count", spans[0].Name)
+	require.Contains(t, spans[0].Attributes, attribute.Int64("count", 2))
+}
@@ -0,0 +1,76 @@
+package fts
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+)
+
+const (
+	insertLinesBatchSize = 1000
+	maxLineSize          = 16 * 1024 * 1024
+)
+
+// InsertLines reads newline-delimited values from the input io.Reader and inserts them in the input Indexer, where
+// each (non-empty) line is a value, and its key is derived from the line number (starting at 1) by the input key
+// function.
+//
+// The lines are inserted in batches of up to 1000 items, each in its own Insert call; this means that if a batch
+// fails, the previously inserted batches are kept in the Indexer. Lines can be up to 16MiB long.
+//
+// This call returns an error if reading from the io.Reader fails or if a line is too long, wrapped with the offending
+// line number (in which case the lines read since the last inserted batch are discarded); or if inserting a batch
+// fails, wrapped with the batch's line range.
+func InsertLines[K SQLType, V Char](
+	ctx context.Context, indexer Indexer[K, V], r io.Reader, keyFn func(line int) K,
+) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+
+	var (
+		line  int
+		first = 1
+		batch = make([]Attribute[K, V], 0, insertLinesBatchSize)
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := indexer.Insert(ctx, batch...); err != nil {
+			return fmt.Errorf("lines %d to %d: %w", first, line, err)
+		}
+
+		batch = batch[:0]
+		first = line + 1
+
+		return nil
+	}
+
+	for scanner.Scan() {
+		line++
+
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		batch = append(batch, Attribute[K, V]{
+			Key:   keyFn(line),
+			Value: V(scanner.Text()),
+		})
+
+		if len(batch) == insertLinesBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("line %d: %w", line+1, err)
+	}
+
+	return flush()
+}
@@ -0,0 +1,71 @@
+package fts
+
+import (
+	"bufio"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertLines(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := NewIndex[int, string](filepath.Join(t.TempDir(), "index.db"))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	input := strings.Join([]string{
+		"some data",
+		"struck gold",
+		"",
+		"some kind of copper",
+		"good ol' gold plate",
+	}, "\n")
+
+	require.NoError(t, InsertLines[int, string](ctx, index, strings.NewReader(input), func(line int) int {
+		return line
+	}))
+
+	res, err := index.Search(ctx, "gold")
+	require.NoError(t, err)
+	require.Equal(t, []Attribute[int, string]{
+		{Key: 2, Value: "struck gold"},
+		{Key: 5, Value: "good ol' gold plate"},
+	}, res)
+}
+
+func TestInsertLines_LongLines(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := NewIndex[int, []byte](filepath.Join(t.TempDir(), "index.db"))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	longLine := strings.Repeat("filler ", bufio.MaxScanTokenSize) + "gold"
+
+	require.NoError(t, InsertLines[int, []byte](ctx, index, strings.NewReader("copper\n"+longLine), func(line int) int {
+		return line
+	}))
+
+	res, err := index.Search(ctx, []byte("gold"))
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, 2, res[0].Key)
+
+	tooLong := "copper\n" + strings.Repeat("x", maxLineSize+1)
+
+	err = InsertLines[int, []byte](ctx, index, strings.NewReader(tooLong), func(line int) int {
+		return line
+	})
+	require.ErrorIs(t, err, bufio.ErrTooLong)
+	require.ErrorContains(t, err, "line 2")
+}
@@ -0,0 +1,148 @@
+package fts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// LanguageIndex routes attributes and searches to a set of sub-indexes keyed by language (such as "en" or "pt"), so
+// that each language is indexed with its own tokenizer (e.g. an English sub-index configured with WithPorterStemmer);
+// as FTS5 can't switch tokenizers per row.
+//
+// The sub-indexes are Indexer, so they can be decorated with logs, metrics or tracing as any other.
+type LanguageIndex[K SQLType, V SQLType] struct {
+	indexes map[string]Indexer[K, V]
+}
+
+// NewLanguageIndex creates a LanguageIndex with the input sub-indexes, keyed by language. Nil sub-indexes are
+// ignored.
+//
+// The LanguageIndex owns the sub-indexes from then on: they are shut down with the LanguageIndex's Shutdown method.
+func NewLanguageIndex[K SQLType, V SQLType](indexes map[string]Indexer[K, V]) *LanguageIndex[K, V] {
+	idx := &LanguageIndex[K, V]{
+		indexes: make(map[string]Indexer[K, V], len(indexes)),
+	}
+
+	for lang, indexer := range indexes {
+		if indexer != nil {
+			idx.indexes[lang] = indexer
+		}
+	}
+
+	return idx
+}
+
+// Languages returns the languages of the LanguageIndex's sub-indexes, sorted.
+func (l *LanguageIndex[K, V]) Languages() []string {
+	langs := make([]string, 0, len(l.indexes))
+
+	for lang := range l.indexes {
+		langs = append(langs, lang)
+	}
+
+	slices.Sort(langs)
+
+	return langs
+}
+
+// indexer returns the sub-index for the input language, or an ErrNotFoundLanguage error if there is none.
+func (l *LanguageIndex[K, V]) indexer(lang string) (Indexer[K, V], error) {
+	indexer, ok := l.indexes[lang]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotFoundLanguage, lang)
+	}
+
+	return indexer, nil
+}
+
+// Insert indexes new attributes in the sub-index for the input language.
+//
+// This call returns an ErrNotFoundLanguage error if there is no sub-index for the language, or the sub-index's error.
+func (l *LanguageIndex[K, V]) Insert(ctx context.Context, lang string, attrs ...Attribute[K, V]) error {
+	indexer, err := l.indexer(lang)
+	if err != nil {
+		return err
+	}
+
+	return indexer.Insert(ctx, attrs...)
+}
+
+// Delete removes attributes matching the input keys in the sub-index for the input language.
+//
+// This call returns an ErrNotFoundLanguage error if there is no sub-index for the language, or the sub-index's error.
+func (l *LanguageIndex[K, V]) Delete(ctx context.Context, lang string, keys ...K) error {
+	indexer, err := l.indexer(lang)
+	if err != nil {
+		return err
+	}
+
+	return indexer.Delete(ctx, keys...)
+}
+
+// Search will look for matches for the input value in the sub-index for the input language, returning a collection of
+// matching Attribute.
+//
+// This call returns an ErrNotFoundLanguage error if there is no sub-index for the language, or the sub-index's results
+// and error (such as an ErrNotFoundKeyword error if there are zero results).
+func (l *LanguageIndex[K, V]) Search(ctx context.Context, lang string, searchTerm V) ([]Attribute[K, V], error) {
+	indexer, err := l.indexer(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	return indexer.Search(ctx, searchTerm)
+}
+
+// SearchAll will look for matches for the input value in all sub-indexes, returning the matching Attribute keyed by the
+// language of the sub-index they were found in. Languages without matches are not set in the results.
+//
+// The search term is tokenized by each sub-index's own tokenizer, so it should be a term that is meaningful across
+// languages (such as a name).
+//
+// This call returns the errors from the sub-indexes joined together, alongside the results of the sub-indexes that
+// succeeded; or an ErrNotFoundKeyword error if there are zero results in all of them.
+func (l *LanguageIndex[K, V]) SearchAll(ctx context.Context, searchTerm V) (map[string][]Attribute[K, V], error) {
+	var (
+		res  = make(map[string][]Attribute[K, V], len(l.indexes))
+		errs = make([]error, 0, len(l.indexes))
+	)
+
+	for _, lang := range l.Languages() {
+		attrs, err := l.indexes[lang].Search(ctx, searchTerm)
+
+		switch {
+		case errors.Is(err, ErrNotFoundKeyword):
+		case err != nil:
+			errs = append(errs, fmt.Errorf("language %q: %w", lang, err))
+		}
+
+		if len(attrs) > 0 {
+			res[lang] = attrs
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return res, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	return res, nil
+}
+
+// Shutdown gracefully closes all sub-indexes, returning their errors joined together.
+func (l *LanguageIndex[K, V]) Shutdown(ctx context.Context) error {
+	errs := make([]error, 0, len(l.indexes))
+
+	for _, lang := range l.Languages() {
+		if err := l.indexes[lang].Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("language %q: %w", lang, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,90 @@
+package fts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguageIndex(t *testing.T) {
+	ctx := context.Background()
+
+	// each sub-index is a separate (named) in-memory database, with its own tokenizer
+	en, err := New[int, string](nil, WithMemoryName("language-en"), WithPorterStemmer())
+	require.NoError(t, err)
+
+	pt, err := New[int, string](nil, WithMemoryName("language-pt"))
+	require.NoError(t, err)
+
+	index := NewLanguageIndex(map[string]Indexer[int, string]{
+		"en": en,
+		"pt": pt,
+		"fr": nil,
+	})
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	require.Equal(t, []string{"en", "pt"}, index.Languages())
+
+	require.NoError(t, index.Insert(ctx, "en",
+		Attribute[int, string]{Key: 1, Value: "running shoes for the Lisbon marathon"},
+		Attribute[int, string]{Key: 2, Value: "a quiet walk"},
+	))
+	require.NoError(t, index.Insert(ctx, "pt",
+		Attribute[int, string]{Key: 1, Value: "sapatos de corrida para a maratona de Lisboa"},
+		Attribute[int, string]{Key: 2, Value: "correr em Lisbon"},
+	))
+
+	t.Run("Search/Stemmed", func(t *testing.T) {
+		res, err := index.Search(ctx, "en", "run")
+		require.NoError(t, err)
+		require.Equal(t, []Attribute[int, string]{{Key: 1, Value: "running shoes for the Lisbon marathon"}}, res)
+	})
+
+	t.Run("Search/OtherLanguage", func(t *testing.T) {
+		_, err := index.Search(ctx, "pt", "run")
+		require.ErrorIs(t, err, ErrNotFoundKeyword)
+
+		res, err := index.Search(ctx, "pt", "corrida")
+		require.NoError(t, err)
+		require.Equal(t, []Attribute[int, string]{{Key: 1, Value: "sapatos de corrida para a maratona de Lisboa"}}, res)
+	})
+
+	t.Run("Search/UnknownLanguage", func(t *testing.T) {
+		_, err := index.Search(ctx, "fr", "course")
+		require.ErrorIs(t, err, ErrNotFoundLanguage)
+
+		require.ErrorIs(t, index.Insert(ctx, "fr", Attribute[int, string]{Key: 1, Value: "course"}), ErrNotFoundLanguage)
+	})
+
+	t.Run("SearchAll", func(t *testing.T) {
+		res, err := index.SearchAll(ctx, "lisbon")
+		require.NoError(t, err)
+		require.Equal(t, map[string][]Attribute[int, string]{
+			"en": {{Key: 1, Value: "running shoes for the Lisbon marathon"}},
+			"pt": {{Key: 2, Value: "correr em Lisbon"}},
+		}, res)
+
+		res, err = index.SearchAll(ctx, "walk")
+		require.NoError(t, err)
+		require.Equal(t, map[string][]Attribute[int, string]{
+			"en": {{Key: 2, Value: "a quiet walk"}},
+		}, res)
+
+		_, err = index.SearchAll(ctx, "bicycle")
+		require.ErrorIs(t, err, ErrNotFoundKeyword)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, index.Delete(ctx, "pt", 2))
+
+		res, err := index.SearchAll(ctx, "lisbon")
+		require.NoError(t, err)
+		require.Equal(t, map[string][]Attribute[int, string]{
+			"en": {{Key: 1, Value: "running shoes for the Lisbon marathon"}},
+		}, res)
+	})
+}
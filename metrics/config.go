@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"net"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zalgonoise/cfg"
+)
+
+const defaultBindBackoff = 100 * time.Millisecond
+
+// Config defines the optional settings of a Metrics instance.
+type Config struct {
+	bindRetries int
+	bindBackoff time.Duration
+	onError     func(error)
+	// listen binds the HTTP server's listener, defaulting to listen; it is only replaced in tests.
+	listen func(addr string, retries int, backoff time.Duration) (net.Listener, error)
+
+	indexName string
+	registry  *prometheus.Registry
+}
+
+// WithBindRetry configures the Metrics HTTP server to retry binding its listener up to the input number of retries,
+// when the port is not available (e.g. while it is briefly occupied during a rolling restart).
+//
+// The wait between attempts starts at the input backoff duration and doubles after each failed attempt. A zero or
+// negative backoff uses the default of 100ms. A zero or negative number of retries is a no-op.
+func WithBindRetry(retries int, backoff time.Duration) cfg.Option[Config] {
+	if retries <= 0 {
+		return cfg.NoOp[Config]{}
+	}
+
+	if backoff <= 0 {
+		backoff = defaultBindBackoff
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.bindRetries = retries
+		config.bindBackoff = backoff
+
+		return config
+	})
+}
+
+// WithServerErrorHandler sets the function called when the Metrics HTTP server stops unexpectedly while serving (after
+// its listener is bound), with the error returned by the server. This allows logging the error, restarting the
+// server, or propagating the failure to the application.
+//
+// By default, the error is logged with the default slog.Logger. A nil function is a no-op.
+func WithServerErrorHandler(fn func(error)) cfg.Option[Config] {
+	if fn == nil {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.onError = fn
+
+		return config
+	})
+}
+
+// WithIndexName sets an "index" constant label with the input name on all of the Metrics' collectors, so that metrics
+// from multiple indexes can be told apart (and registered in the same registry, with WithRegistry).
+//
+// An empty name is a no-op.
+func WithIndexName(name string) cfg.Option[Config] {
+	if name == "" {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.indexName = name
+
+		return config
+	})
+}
+
+// WithRegistry registers the Metrics' collectors in the input registry, instead of a new one. In this case, no HTTP
+// server is started by the Metrics instance, and the caller is responsible for exposing the registry (e.g. with
+// promhttp.HandlerFor).
+//
+// This allows multiple Metrics instances (e.g. one per index, each with a distinct WithIndexName) to share a single
+// registry and endpoint. A nil registry is a no-op.
+func WithRegistry(registry *prometheus.Registry) cfg.Option[Config] {
+	if registry == nil {
+		return cfg.NoOp[Config]{}
+	}
+
+	return cfg.Register[Config](func(config Config) Config {
+		config.registry = registry
+
+		return config
+	})
+}
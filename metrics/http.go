@@ -3,6 +3,8 @@ package metrics
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
 	"time"
 
@@ -10,7 +12,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func newServer(port int, registry *prometheus.Registry) *http.Server {
+func newServer(port int, registry *prometheus.Registry, config Config) (*http.Server, error) {
 	mux := http.NewServeMux()
 
 	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{
@@ -25,11 +27,50 @@ func newServer(port int, registry *prometheus.Registry) *http.Server {
 		WriteTimeout: 15 * time.Second,
 	}
 
-	go func() {
-		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			panic(err)
-		}
-	}()
+	bind := config.listen
+	if bind == nil {
+		bind = listen
+	}
+
+	listener, err := bind(server.Addr, config.bindRetries, config.bindBackoff)
+	if err != nil {
+		return nil, err
+	}
+
+	onError := config.onError
+	if onError == nil {
+		onError = logServerError
+	}
+
+	go serve(server, listener, onError)
+
+	return server, nil
+}
+
+// serve serves HTTP requests on the input listener, calling onError if the server stops with an error other than
+// http.ErrServerClosed (which is returned after a Shutdown).
+func serve(server *http.Server, listener net.Listener, onError func(error)) {
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		onError(err)
+	}
+}
+
+// logServerError is the default server error handler, logging the error with the default slog.Logger.
+func logServerError(err error) {
+	slog.Error("metrics server stopped unexpectedly", slog.String("error", err.Error()))
+}
+
+// listen binds a TCP listener on the input address, retrying up to the input number of retries with an exponential
+// backoff between attempts. The last error is returned if all attempts fail.
+func listen(addr string, retries int, backoff time.Duration) (net.Listener, error) {
+	listener, err := net.Listen("tcp", addr)
+
+	for attempt := 0; err != nil && attempt < retries; attempt++ {
+		time.Sleep(backoff)
+		backoff *= 2
+
+		listener, err = net.Listen("tcp", addr)
+	}
 
-	return server
+	return listener, err
 }
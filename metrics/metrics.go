@@ -4,15 +4,22 @@ import (
 	"net/http"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zalgonoise/cfg"
 )
 
-const traceIDKey = "trace_id" // https://opentelemetry.io/docs/specs/otel/metrics/data-model/#exemplars
+const (
+	traceIDKey = "trace_id" // https://opentelemetry.io/docs/specs/otel/metrics/data-model/#exemplars
+	indexKey   = "index"
+)
 
 type Metrics struct {
 	searchesTotal   prometheus.Counter
 	searchesFailed  prometheus.Counter
 	searchesLatency prometheus.Histogram
 
+	searchPageLimit  prometheus.Histogram
+	searchPageOffset prometheus.Histogram
+
 	insertsTotal   prometheus.Counter
 	insertsFailed  prometheus.Counter
 	insertsLatency prometheus.Histogram
@@ -21,23 +28,52 @@ type Metrics struct {
 	deletesFailed  prometheus.Counter
 	deletesLatency prometheus.Histogram
 
+	updatesTotal   prometheus.Counter
+	updatesFailed  prometheus.Counter
+	updatesLatency prometheus.Histogram
+
+	clearsTotal  prometheus.Counter
+	clearsFailed prometheus.Counter
+
+	cacheHitRatio prometheus.Gauge
+	noOpFallbacks prometheus.Counter
+
 	server *http.Server
 }
 
 // New creates a new Prometheus Metrics instance, with its HTTP server registered on the input port.
-func New(port int) (*Metrics, error) {
+//
+// The server's listener is bound before New returns, so an error is returned if the port is not available. Callers
+// may configure retries for binding the listener with WithBindRetry.
+//
+// If a registry is provided with WithRegistry, the collectors are registered in it and no HTTP server is started (the
+// port is ignored).
+func New(port int, opts ...cfg.Option[Config]) (*Metrics, error) {
 	if port < 0 {
 		port = 0
 	}
 
-	promMetrics := newProm()
+	config := cfg.New[Config](opts...)
+
+	promMetrics := newProm(config.indexName)
+
+	if config.registry != nil {
+		if err := promMetrics.register(config.registry); err != nil {
+			return nil, err
+		}
+
+		return promMetrics, nil
+	}
 
 	reg, err := promMetrics.Registry()
 	if err != nil {
 		return nil, err
 	}
 
-	promMetrics.server = newServer(port, reg)
+	promMetrics.server, err = newServer(port, reg, config)
+	if err != nil {
+		return nil, err
+	}
 
 	return promMetrics, nil
 }
@@ -0,0 +1,157 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/cfg"
+)
+
+func TestNew_BindRetry(t *testing.T) {
+	for _, testcase := range []struct {
+		name     string
+		opts     []cfg.Option[Config]
+		freeWait time.Duration
+		fails    bool
+	}{
+		{
+			name:     "Success/PortFreedDuringRetries",
+			opts:     []cfg.Option[Config]{WithBindRetry(5, 20*time.Millisecond)},
+			freeWait: 50 * time.Millisecond,
+		},
+		{
+			name:     "Fail/NoRetries",
+			freeWait: time.Second,
+			fails:    true,
+		},
+		{
+			name:     "Fail/PortNeverFreed",
+			opts:     []cfg.Option[Config]{WithBindRetry(2, 10*time.Millisecond)},
+			freeWait: time.Second,
+			fails:    true,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			occupied, err := net.Listen("tcp", ":0")
+			require.NoError(t, err)
+
+			port := occupied.Addr().(*net.TCPAddr).Port
+
+			timer := time.AfterFunc(testcase.freeWait, func() {
+				_ = occupied.Close()
+			})
+
+			defer func() {
+				timer.Stop()
+				_ = occupied.Close()
+			}()
+
+			m, err := New(port, testcase.opts...)
+			if testcase.fails {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, m.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestNew_SharedRegistry(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	for _, name := range []string{"users", "products"} {
+		m, err := New(0, WithIndexName(name), WithRegistry(reg))
+		require.NoError(t, err)
+
+		m.IncSearchesTotal()
+	}
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	indexes := make([]string, 0, 2)
+
+	for _, family := range families {
+		if family.GetName() != "searches_received_total" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == indexKey {
+					indexes = append(indexes, label.GetValue())
+				}
+			}
+
+			require.Equal(t, float64(1), metric.GetCounter().GetValue())
+		}
+	}
+
+	require.ElementsMatch(t, []string{"users", "products"}, indexes)
+}
+
+func TestNew_SharedRegistryDuplicateName(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	_, err := New(0, WithIndexName("users"), WithRegistry(reg))
+	require.NoError(t, err)
+
+	_, err = New(0, WithIndexName("users"), WithRegistry(reg))
+	require.Error(t, err)
+}
+
+// failingListener is a net.Listener whose Accept calls fail, stopping any server serving on it.
+type failingListener struct {
+	net.Listener
+	err error
+}
+
+func (l failingListener) Accept() (net.Conn, error) {
+	return nil, l.err
+}
+
+func TestNew_ServerErrorHandler(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	acceptErr := errors.New("accept failed")
+	errCh := make(chan error, 1)
+
+	withFailingListener := cfg.Register[Config](func(config Config) Config {
+		config.listen = func(string, int, time.Duration) (net.Listener, error) {
+			return failingListener{Listener: listener, err: acceptErr}, nil
+		}
+
+		return config
+	})
+
+	_, err = New(0, withFailingListener, WithServerErrorHandler(func(err error) {
+		errCh <- err
+	}))
+	require.NoError(t, err)
+
+	select {
+	case err = <-errCh:
+		require.ErrorIs(t, err, acceptErr)
+	case <-time.After(time.Second):
+		t.Fatal("server error handler was not called")
+	}
+
+	// the default handler logs the error instead of panicking
+	require.NotPanics(t, func() {
+		serve(&http.Server{}, failingListener{Listener: listener, err: acceptErr}, logServerError)
+	})
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -33,6 +34,12 @@ func (m *Metrics) ObserveSearchLatency(ctx context.Context, dur time.Duration) {
 	m.searchesLatency.Observe(dur.Seconds())
 }
 
+// ObserveSearchPage observes the limit and offset of a paginated search request.
+func (m *Metrics) ObserveSearchPage(limit, offset int) {
+	m.searchPageLimit.Observe(float64(limit))
+	m.searchPageOffset.Observe(float64(offset))
+}
+
 // IncInsertsTotal increases the total count of insert requests.
 func (m *Metrics) IncInsertsTotal() {
 	m.insertsTotal.Inc()
@@ -81,6 +88,50 @@ func (m *Metrics) ObserveDeleteLatency(ctx context.Context, dur time.Duration) {
 	m.deletesLatency.Observe(dur.Seconds())
 }
 
+// IncUpdatesTotal increases the total count of update requests.
+func (m *Metrics) IncUpdatesTotal() {
+	m.updatesTotal.Inc()
+}
+
+// IncUpdatesFailed increases the total count of failed update requests.
+func (m *Metrics) IncUpdatesFailed() {
+	m.updatesFailed.Inc()
+}
+
+// ObserveUpdateLatency observes the latency in handling an update request, registering an exemplar with this
+// latency if the input context carries a valid span.
+func (m *Metrics) ObserveUpdateLatency(ctx context.Context, dur time.Duration) {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		m.updatesLatency.(prometheus.ExemplarObserver).ObserveWithExemplar(dur.Seconds(), prometheus.Labels{
+			traceIDKey: sc.TraceID().String(),
+		})
+
+		return
+	}
+
+	m.updatesLatency.Observe(dur.Seconds())
+}
+
+// IncClearsTotal increases the total count of clear requests.
+func (m *Metrics) IncClearsTotal() {
+	m.clearsTotal.Inc()
+}
+
+// IncClearsFailed increases the total count of failed clear requests.
+func (m *Metrics) IncClearsFailed() {
+	m.clearsFailed.Inc()
+}
+
+// SetCacheHitRatio sets the hit ratio of the index's result cache, as the fraction of searches served from it.
+func (m *Metrics) SetCacheHitRatio(ratio float64) {
+	m.cacheHitRatio.Set(ratio)
+}
+
+// IncNoOpFallbackTotal increases the total count of fallbacks to a no-op indexer, which silently ignores all requests.
+func (m *Metrics) IncNoOpFallbackTotal() {
+	m.noOpFallbacks.Inc()
+}
+
 // Registry returns a prometheus.Registry with all set-up collectors for this instance.
 //
 // The default collectors include the Go collector, the process collector, and the different requests collectors
@@ -88,21 +139,44 @@ func (m *Metrics) ObserveDeleteLatency(ctx context.Context, dur time.Duration) {
 func (m *Metrics) Registry() (reg *prometheus.Registry, err error) {
 	reg = prometheus.NewRegistry()
 
+	if err = m.register(reg); err != nil {
+		return nil, err
+	}
+
+	return reg, nil
+}
+
+// register registers the default collectors and the requests collectors in the input registry.
+//
+// The default collectors are shared by all Metrics instances, so they are skipped if they are already registered in a
+// (shared) registry.
+func (m *Metrics) register(reg *prometheus.Registry) error {
 	for _, metric := range []prometheus.Collector{
 		collectors.NewGoCollector(),
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{
 			ReportErrors: false,
 		}),
+	} {
+		if err := reg.Register(metric); err != nil && !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+			return err
+		}
+	}
+
+	for _, metric := range []prometheus.Collector{
 		m.searchesTotal, m.searchesFailed, m.searchesLatency,
+		m.searchPageLimit, m.searchPageOffset,
 		m.insertsTotal, m.insertsFailed, m.insertsLatency,
 		m.deletesTotal, m.deletesFailed, m.deletesLatency,
+		m.updatesTotal, m.updatesFailed, m.updatesLatency,
+		m.clearsTotal, m.clearsFailed,
+		m.cacheHitRatio, m.noOpFallbacks,
 	} {
-		if err = reg.Register(metric); err != nil {
-			return nil, err
+		if err := reg.Register(metric); err != nil {
+			return err
 		}
 	}
 
-	return reg, nil
+	return nil
 }
 
 // Shutdown gracefully shuts down the Metrics HTTP server
@@ -114,48 +188,114 @@ func (m *Metrics) Shutdown(ctx context.Context) error {
 	return m.server.Shutdown(ctx)
 }
 
-func newProm() *Metrics {
+func newProm(indexName string) *Metrics {
+	var labels prometheus.Labels
+	if indexName != "" {
+		labels = prometheus.Labels{indexKey: indexName}
+	}
+
 	return &Metrics{
 		searchesTotal: prometheus.NewCounter(prometheus.CounterOpts{
-			Name: "searches_received_total",
-			Help: "Count of the search requests received by the index",
+			Name:        "searches_received_total",
+			Help:        "Count of the search requests received by the index",
+			ConstLabels: labels,
 		}),
 		searchesFailed: prometheus.NewCounter(prometheus.CounterOpts{
-			Name: "searches_failed_total",
-			Help: "Count of the failed search requests",
+			Name:        "searches_failed_total",
+			Help:        "Count of the failed search requests",
+			ConstLabels: labels,
 		}),
 		searchesLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
-			Name:    "search_handling_latency_seconds",
-			Help:    "Histogram of search request handling latencies",
-			Buckets: []float64{.00001, .00005, .0001, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Name:        "search_handling_latency_seconds",
+			Help:        "Histogram of search request handling latencies",
+			ConstLabels: labels,
+			Buckets:     []float64{.00001, .00005, .0001, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}),
+
+		searchPageLimit: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:        "search_page_limit",
+			Help:        "Histogram of the limits of paginated search requests, where zero or below means no limit",
+			ConstLabels: labels,
+			Buckets:     []float64{0, 10, 25, 50, 100, 250, 500, 1000},
+		}),
+		searchPageOffset: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:        "search_page_offset",
+			Help:        "Histogram of the offsets of paginated search requests",
+			ConstLabels: labels,
+			Buckets:     []float64{0, 10, 50, 100, 500, 1000, 5000, 10000},
 		}),
 
 		insertsTotal: prometheus.NewCounter(prometheus.CounterOpts{
-			Name: "inserts_received_total",
-			Help: "Count of the insert requests received by the index",
+			Name:        "inserts_received_total",
+			Help:        "Count of the insert requests received by the index",
+			ConstLabels: labels,
 		}),
 		insertsFailed: prometheus.NewCounter(prometheus.CounterOpts{
-			Name: "inserts_failed_total",
-			Help: "Count of the failed insert requests",
+			Name:        "inserts_failed_total",
+			Help:        "Count of the failed insert requests",
+			ConstLabels: labels,
 		}),
 		insertsLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
-			Name:    "insert_handling_latency_seconds",
-			Help:    "Histogram of insert request handling latencies",
-			Buckets: []float64{.00001, .00005, .0001, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Name:        "insert_handling_latency_seconds",
+			Help:        "Histogram of insert request handling latencies",
+			ConstLabels: labels,
+			Buckets:     []float64{.00001, .00005, .0001, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
 		}),
 
 		deletesTotal: prometheus.NewCounter(prometheus.CounterOpts{
-			Name: "deletes_received_total",
-			Help: "Count of the delete requests received by the index",
+			Name:        "deletes_received_total",
+			Help:        "Count of the delete requests received by the index",
+			ConstLabels: labels,
 		}),
 		deletesFailed: prometheus.NewCounter(prometheus.CounterOpts{
-			Name: "deletes_failed_total",
-			Help: "Count of the failed delete requests",
+			Name:        "deletes_failed_total",
+			Help:        "Count of the failed delete requests",
+			ConstLabels: labels,
 		}),
 		deletesLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
-			Name:    "delete_handling_latency_seconds",
-			Help:    "Histogram of delete request handling latencies",
-			Buckets: []float64{.00001, .00005, .0001, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Name:        "delete_handling_latency_seconds",
+			Help:        "Histogram of delete request handling latencies",
+			ConstLabels: labels,
+			Buckets:     []float64{.00001, .00005, .0001, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}),
+
+		updatesTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name:        "updates_received_total",
+			Help:        "Count of the update requests received by the index",
+			ConstLabels: labels,
+		}),
+		updatesFailed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name:        "updates_failed_total",
+			Help:        "Count of the failed update requests",
+			ConstLabels: labels,
+		}),
+		updatesLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:        "update_handling_latency_seconds",
+			Help:        "Histogram of update request handling latencies",
+			ConstLabels: labels,
+			Buckets:     []float64{.00001, .00005, .0001, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}),
+
+		clearsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name:        "clears_received_total",
+			Help:        "Count of the clear requests received by the index",
+			ConstLabels: labels,
+		}),
+		clearsFailed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name:        "clears_failed_total",
+			Help:        "Count of the failed clear requests",
+			ConstLabels: labels,
+		}),
+
+		cacheHitRatio: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "search_cache_hit_ratio",
+			Help:        "Fraction of the search requests served from the index's result cache",
+			ConstLabels: labels,
+		}),
+		noOpFallbacks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name:        "noop_fallback_total",
+			Help:        "Count of the fallbacks to a no-op indexer, which silently ignores all requests",
+			ConstLabels: labels,
 		}),
 	}
 }
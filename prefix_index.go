@@ -0,0 +1,257 @@
+package fts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	createPrefixTableQuery = `
+CREATE TABLE IF NOT EXISTS prefix_search (
+	id,
+	val TEXT COLLATE NOCASE
+);
+`
+
+	createPrefixIndexQuery = `
+CREATE INDEX IF NOT EXISTS prefix_search_val 
+	ON prefix_search (val, id);
+`
+
+	insertPrefixQuery = `
+INSERT INTO prefix_search (id, val) 
+	VALUES (?, ?);
+`
+
+	deletePrefixQuery = `
+DELETE FROM prefix_search
+	WHERE id = ?;
+`
+
+	countPrefixQuery = `SELECT count(*) FROM prefix_search;`
+
+	clearPrefixQuery = `DELETE FROM prefix_search;`
+
+	searchPrefixQuery = `
+SELECT id, val FROM prefix_search
+	WHERE val LIKE ? ESCAPE '\'
+	ORDER BY val
+	LIMIT ? OFFSET ?;
+`
+)
+
+// queryOperators matches the FTS5 query syntax in a search term: quotes, prefix and initial token markers, grouping,
+// column filters, and the boolean and NEAR operators. A PrefixIndex rejects them in its search terms, and the Index's
+// LIKE fallback (see WithFallbackToLike) strips them.
+var queryOperators = regexp.MustCompile(`["*^(){}:+]|\b(AND|OR|NOT|NEAR)\b`)
+
+// PrefixIndex is a lightweight Indexer that only supports prefix lookups on its values, such as for autocompletion on
+// short fields. Instead of an FTS5 table, the attributes are stored in a plain table with a covering index on their
+// values; which is much smaller, and matches the values starting with the search term (case-insensitively, for ASCII
+// characters) with a single index range scan.
+//
+// Unlike an Index, a PrefixIndex does not tokenize its values: a search term only matches the start of a value, not
+// the start of any of its words.
+type PrefixIndex[K SQLType, V SQLType] struct {
+	db *sql.DB
+}
+
+// NewPrefixIndex creates a PrefixIndex using the provided URI and set of Attribute.
+//
+// As with NewIndex, if the provided URI is an empty string or ":memory:" the PrefixIndex runs in-memory; otherwise, the
+// URI is treated as a database URI and validated as an OS path.
+//
+// An error is returned if the database fails when being open, initialized, and loaded with the input Attribute.
+func NewPrefixIndex[K SQLType, V SQLType](uri string, attrs ...Attribute[K, V]) (*PrefixIndex[K, V], error) {
+	db, err := open(uri, "", "", "", nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+
+	for _, query := range []string{createPrefixTableQuery, createPrefixIndexQuery} {
+		if _, err = db.ExecContext(ctx, query); err != nil {
+			return nil, errors.Join(err, db.Close())
+		}
+	}
+
+	index := &PrefixIndex[K, V]{db: db}
+
+	if len(attrs) > 0 {
+		if err = index.Insert(ctx, attrs...); err != nil {
+			return nil, errors.Join(err, db.Close())
+		}
+	}
+
+	return index, nil
+}
+
+// Search looks for the attributes whose value starts with the input search term, returning them sorted by value.
+//
+// The search term is matched literally, as a prefix: it must not contain FTS5 query syntax (such as quotes, '*', or
+// the AND, OR, NOT and NEAR operators), which is rejected with an ErrInvalidQuery error. An empty search term is
+// rejected with an ErrQueryTooShort error, instead of matching all attributes.
+//
+// This call returns an error if the search term is invalid, if the underlying SQL query fails, if scanning for the
+// results fails, or an ErrNotFoundKeyword error if there are zero results from the query.
+func (p *PrefixIndex[K, V]) Search(ctx context.Context, searchTerm V) ([]Attribute[K, V], error) {
+	return p.SearchPaginated(ctx, searchTerm, 0, 0)
+}
+
+// SearchPaginated looks for the attributes whose values start with the input search term, like Search, returning at
+// most limit results (sorted by value) after skipping the first offset ones. A limit of zero or below means no limit,
+// and a negative offset is treated as zero.
+//
+// This call returns an error if the search term is invalid, if the underlying SQL query fails, if scanning for the
+// results fails, or an ErrNotFoundKeyword error if there are zero results from the query and the offset is zero. An
+// offset past the last match returns an empty result with no error.
+func (p *PrefixIndex[K, V]) SearchPaginated(
+	ctx context.Context, searchTerm V, limit, offset int,
+) ([]Attribute[K, V], error) {
+	prefix, ok := charString(searchTerm)
+	if !ok {
+		prefix = fmt.Sprint(searchTerm)
+	}
+
+	if strings.TrimSpace(prefix) == "" {
+		return nil, fmt.Errorf("%w: an empty prefix matches all attributes", ErrQueryTooShort)
+	}
+
+	if loc := queryOperators.FindStringIndex(prefix); loc != nil {
+		return nil, fmt.Errorf("%w: %q at position %d: prefix searches do not support query operators",
+			ErrInvalidQuery, prefix[loc[0]:loc[1]], loc[0]+1)
+	}
+
+	if limit <= 0 {
+		limit = -1
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := p.db.QueryContext(ctx, searchPrefixQuery, escapeLike(prefix)+"%", limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scanRows(rows, 0, func(rows *sql.Rows) (attr Attribute[K, V], err error) {
+		return attr, rows.Scan(&attr.Key, &attr.Value)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 && offset == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotFoundKeyword, searchTerm)
+	}
+
+	return res, nil
+}
+
+// Insert adds new attributes in the PrefixIndex, within a single database transaction. If the context is canceled
+// while the transaction is open, it is rolled back and the context's error is returned.
+func (p *PrefixIndex[K, V]) Insert(ctx context.Context, attrs ...Attribute[K, V]) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for idx := range attrs {
+		if err = ctx.Err(); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		if _, err = tx.ExecContext(ctx, insertPrefixQuery, attrs[idx].Key, attrs[idx].Value); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes the attributes in the PrefixIndex with the input keys, within a single database transaction. If the
+// context is canceled while the transaction is open, it is rolled back and the context's error is returned.
+func (p *PrefixIndex[K, V]) Delete(ctx context.Context, keys ...K) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for idx := range keys {
+		if err = ctx.Err(); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		if _, err = tx.ExecContext(ctx, deletePrefixQuery, keys[idx]); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Update replaces the values of the attributes in the PrefixIndex with the input keys, deleting their rows and
+// inserting the new values within a single database transaction; so that keys without a matching attribute are
+// inserted. If the context is canceled while the transaction is open, it is rolled back and the context's error is
+// returned.
+func (p *PrefixIndex[K, V]) Update(ctx context.Context, attrs ...Attribute[K, V]) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for idx := range attrs {
+		if err = ctx.Err(); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		if _, err = tx.ExecContext(ctx, deletePrefixQuery, attrs[idx].Key); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+
+		if _, err = tx.ExecContext(ctx, insertPrefixQuery, attrs[idx].Key, attrs[idx].Value); err != nil {
+			return errors.Join(err, rollback(tx))
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Count returns the number of rows in the PrefixIndex, or zero if it is empty.
+func (p *PrefixIndex[K, V]) Count(ctx context.Context) (count int64, err error) {
+	if err = p.db.QueryRowContext(ctx, countPrefixQuery).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Clear removes all attributes in the PrefixIndex, within a single database transaction, keeping it open and usable.
+func (p *PrefixIndex[K, V]) Clear(ctx context.Context) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, clearPrefixQuery); err != nil {
+		return errors.Join(err, rollback(tx))
+	}
+
+	return tx.Commit()
+}
+
+// Shutdown closes the PrefixIndex's database.
+func (p *PrefixIndex[K, V]) Shutdown(context.Context) error {
+	return p.db.Close()
+}
+
+// escapeLike escapes the LIKE wildcards in the input string, using a backslash as the escape character.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,150 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixIndex(t *testing.T) {
+	attrs := []Attribute[int, string]{
+		{Key: 1, Value: "gold"},
+		{Key: 2, Value: "Golden Gate"},
+		{Key: 3, Value: "silver gold"},
+		{Key: 4, Value: "100% gold"},
+		{Key: 5, Value: "100 grams"},
+		{Key: 6, Value: "go_to"},
+		{Key: 7, Value: "gone"},
+	}
+
+	for _, testcase := range []struct {
+		name   string
+		prefix string
+		wants  []Attribute[int, string]
+		err    error
+	}{
+		{
+			name:   "Success/CaseInsensitive",
+			prefix: "GOLD",
+			wants:  []Attribute[int, string]{attrs[0], attrs[1]},
+		},
+		{
+			name:   "Success/OnlyValuePrefix",
+			prefix: "silver",
+			wants:  []Attribute[int, string]{attrs[2]},
+		},
+		{
+			name:   "Success/LiteralPercent",
+			prefix: "100%",
+			wants:  []Attribute[int, string]{attrs[3]},
+		},
+		{
+			name:   "Success/LiteralUnderscore",
+			prefix: "go_",
+			wants:  []Attribute[int, string]{attrs[5]},
+		},
+		{
+			name:   "Fail/NotFound",
+			prefix: "bronze",
+			err:    ErrNotFoundKeyword,
+		},
+		{
+			name:   "Fail/Empty",
+			prefix: " ",
+			err:    ErrQueryTooShort,
+		},
+		{
+			name:   "Fail/PrefixOperator",
+			prefix: "gol*",
+			err:    ErrInvalidQuery,
+		},
+		{
+			name:   "Fail/BooleanOperator",
+			prefix: "gold OR silver",
+			err:    ErrInvalidQuery,
+		},
+		{
+			name:   "Fail/Phrase",
+			prefix: `"gold"`,
+			err:    ErrInvalidQuery,
+		},
+		{
+			name:   "Fail/ColumnFilter",
+			prefix: "val:gold",
+			err:    ErrInvalidQuery,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			index, err := NewPrefixIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.Search(ctx, testcase.prefix)
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
+
+func TestPrefixIndex_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := NewPrefixIndex[int, string](filepath.Join(t.TempDir(), "index.db"),
+		Attribute[int, string]{Key: 1, Value: "gold"},
+		Attribute[int, string]{Key: 2, Value: "golden"},
+	)
+	require.NoError(t, err)
+
+	// a PrefixIndex is usable as an Indexer
+	var indexer Indexer[int, string] = index
+
+	defer func() {
+		require.NoError(t, indexer.Shutdown(ctx))
+	}()
+
+	require.NoError(t, indexer.Delete(ctx, 1))
+
+	res, err := indexer.Search(ctx, "gold")
+	require.NoError(t, err)
+	require.Equal(t, []Attribute[int, string]{{Key: 2, Value: "golden"}}, res)
+}
+
+func TestPrefixIndex_SearchPaginated(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := NewPrefixIndex[int, string](filepath.Join(t.TempDir(), "index.db"),
+		Attribute[int, string]{Key: 1, Value: "golden"},
+		Attribute[int, string]{Key: 2, Value: "gold"},
+		Attribute[int, string]{Key: 3, Value: "goldfish"},
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	res, err := index.SearchPaginated(ctx, "gold", 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, []Attribute[int, string]{{Key: 1, Value: "golden"}, {Key: 3, Value: "goldfish"}}, res)
+
+	// an offset past the last match is not an error
+	res, err = index.SearchPaginated(ctx, "gold", 2, 3)
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	_, err = index.SearchPaginated(ctx, "silver", 2, 0)
+	require.ErrorIs(t, err, ErrNotFoundKeyword)
+}
@@ -0,0 +1,107 @@
+// Package proto contains the protobuf messages for the attributes indexed in (and returned by) an fts.Index, and the
+// converters between them and fts.Attribute; for services that serialize search results over the wire (e.g. gRPC).
+package proto
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative attribute.proto
+
+import "github.com/zalgonoise/fts"
+
+// Integer is a type constraint that comprises the signed integer types that can be used as keys in an IntAttribute.
+type Integer interface {
+	int | int8 | int16 | int32 | int64
+}
+
+// AttributeToProto converts the input fts.Attribute into an Attribute message.
+func AttributeToProto(attr fts.Attribute[string, string]) *Attribute {
+	return &Attribute{
+		Key:   attr.Key,
+		Value: attr.Value,
+	}
+}
+
+// AttributeFromProto converts the input Attribute message into an fts.Attribute. A nil message is converted into a
+// zero fts.Attribute.
+func AttributeFromProto(msg *Attribute) fts.Attribute[string, string] {
+	return fts.Attribute[string, string]{
+		Key:   msg.GetKey(),
+		Value: msg.GetValue(),
+	}
+}
+
+// ToProto converts the input search results into a Result message.
+func ToProto(attrs []fts.Attribute[string, string]) *Result {
+	res := &Result{
+		Attributes: make([]*Attribute, 0, len(attrs)),
+	}
+
+	for i := range attrs {
+		res.Attributes = append(res.Attributes, AttributeToProto(attrs[i]))
+	}
+
+	return res
+}
+
+// FromProto converts the input Result message into a set of fts.Attribute. A nil message yields no attributes.
+func FromProto(msg *Result) []fts.Attribute[string, string] {
+	attributes := msg.GetAttributes()
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	attrs := make([]fts.Attribute[string, string], 0, len(attributes))
+
+	for i := range attributes {
+		attrs = append(attrs, AttributeFromProto(attributes[i]))
+	}
+
+	return attrs
+}
+
+// IntAttributeToProto converts the input integer-keyed fts.Attribute into an IntAttribute message.
+func IntAttributeToProto[K Integer](attr fts.Attribute[K, string]) *IntAttribute {
+	return &IntAttribute{
+		Key:   int64(attr.Key),
+		Value: attr.Value,
+	}
+}
+
+// IntAttributeFromProto converts the input IntAttribute message into an integer-keyed fts.Attribute. A nil message is
+// converted into a zero fts.Attribute.
+//
+// Keys that do not fit in K are truncated, as in a Go integer conversion.
+func IntAttributeFromProto[K Integer](msg *IntAttribute) fts.Attribute[K, string] {
+	return fts.Attribute[K, string]{
+		Key:   K(msg.GetKey()),
+		Value: msg.GetValue(),
+	}
+}
+
+// IntToProto converts the input integer-keyed search results into an IntResult message.
+func IntToProto[K Integer](attrs []fts.Attribute[K, string]) *IntResult {
+	res := &IntResult{
+		Attributes: make([]*IntAttribute, 0, len(attrs)),
+	}
+
+	for i := range attrs {
+		res.Attributes = append(res.Attributes, IntAttributeToProto(attrs[i]))
+	}
+
+	return res
+}
+
+// IntFromProto converts the input IntResult message into a set of integer-keyed fts.Attribute. A nil message yields
+// no attributes.
+func IntFromProto[K Integer](msg *IntResult) []fts.Attribute[K, string] {
+	attributes := msg.GetAttributes()
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	attrs := make([]fts.Attribute[K, string], 0, len(attributes))
+
+	for i := range attributes {
+		attrs = append(attrs, IntAttributeFromProto[K](attributes[i]))
+	}
+
+	return attrs
+}
@@ -0,0 +1,98 @@
+package proto
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zalgonoise/fts"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		attrs []fts.Attribute[string, string]
+	}{
+		{
+			name: "Success/Empty",
+		},
+		{
+			name: "Success/Attributes",
+			attrs: []fts.Attribute[string, string]{
+				{Key: "docs/install", Value: "install the gold release"},
+				{Key: "", Value: "no key"},
+				{Key: "blog/launch", Value: "we struck gold — ✓"},
+			},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			data, err := proto.Marshal(ToProto(testcase.attrs))
+			require.NoError(t, err)
+
+			msg := &Result{}
+			require.NoError(t, proto.Unmarshal(data, msg))
+
+			require.Equal(t, testcase.attrs, FromProto(msg))
+		})
+	}
+}
+
+func TestIntRoundTrip(t *testing.T) {
+	for _, testcase := range []struct {
+		name  string
+		attrs []fts.Attribute[int, string]
+	}{
+		{
+			name: "Success/Empty",
+		},
+		{
+			name: "Success/Attributes",
+			attrs: []fts.Attribute[int, string]{
+				{Key: 1, Value: "struck gold"},
+				{Key: -7, Value: "negative key"},
+				{Key: 1 << 40, Value: "large key"},
+			},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			data, err := proto.Marshal(IntToProto(testcase.attrs))
+			require.NoError(t, err)
+
+			msg := &IntResult{}
+			require.NoError(t, proto.Unmarshal(data, msg))
+
+			require.Equal(t, testcase.attrs, IntFromProto[int](msg))
+		})
+	}
+}
+
+func TestFromProto_Nil(t *testing.T) {
+	require.Nil(t, FromProto(nil))
+	require.Nil(t, IntFromProto[int64](nil))
+	require.Equal(t, fts.Attribute[string, string]{}, AttributeFromProto(nil))
+	require.Equal(t, fts.Attribute[int32, string]{}, IntAttributeFromProto[int32](nil))
+}
+
+func TestToProto_SearchResults(t *testing.T) {
+	ctx := context.Background()
+
+	index, err := fts.NewIndex(filepath.Join(t.TempDir(), "index.db"),
+		fts.Attribute[string, string]{Key: "a", Value: "struck gold"},
+		fts.Attribute[string, string]{Key: "b", Value: "silver lining"},
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, index.Shutdown(ctx))
+	}()
+
+	res, err := index.Search(ctx, "gold")
+	require.NoError(t, err)
+
+	msg := ToProto(res)
+	require.Len(t, msg.GetAttributes(), 1)
+	require.Equal(t, "a", msg.GetAttributes()[0].GetKey())
+	require.Equal(t, "struck gold", msg.GetAttributes()[0].GetValue())
+}
@@ -0,0 +1,95 @@
+package fts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	opAnd = "AND"
+	opOr  = "OR"
+)
+
+// Query builds FTS5 query expressions from search terms scoped to the Index's columns, which can be combined with
+// And and Or. The compiled expression is returned by Build, and can be used as the search term in any search method
+// of an Index with string values.
+//
+// Combined queries are grouped with parentheses where needed, so that each And and Or call applies to the whole of the
+// queries it joins.
+type Query struct {
+	expr string
+	op   string
+	err  error
+}
+
+// Field creates a Query matching the input term in the input column, which must be one of the Index's columns: "id"
+// (the keys) or "val" (the values). The term is matched as an FTS5 phrase, so FTS5 operators in it are not evaluated.
+//
+// An invalid column results in a Query whose Build call returns an ErrInvalidColumn error.
+func Field(column, term string) Query {
+	if column != keyColumn && column != valueColumn {
+		return Query{err: fmt.Errorf("%w: %q", ErrInvalidColumn, column)}
+	}
+
+	return Query{expr: fmt.Sprintf("{%s}:%s", column, quotePhrase(term))}
+}
+
+// And returns a Query matching the rows matched by this Query and all of the input queries.
+func (q Query) And(queries ...Query) Query {
+	return q.join(opAnd, queries)
+}
+
+// Or returns a Query matching the rows matched by this Query or any of the input queries.
+func (q Query) Or(queries ...Query) Query {
+	return q.join(opOr, queries)
+}
+
+// Build returns the compiled FTS5 query expression, or an error if any of the combined queries is invalid.
+func (q Query) Build() (string, error) {
+	if q.err != nil {
+		return "", q.err
+	}
+
+	return q.expr, nil
+}
+
+// String returns the compiled FTS5 query expression, which is empty if the Query is invalid.
+func (q Query) String() string {
+	if q.err != nil {
+		return ""
+	}
+
+	return q.expr
+}
+
+func (q Query) join(op string, queries []Query) Query {
+	exprs := make([]string, 0, len(queries)+1)
+	errs := make([]error, 0, len(queries)+1)
+
+	for _, query := range append([]Query{q}, queries...) {
+		exprs = append(exprs, query.group(op))
+		errs = append(errs, query.err)
+	}
+
+	return Query{
+		expr: strings.Join(exprs, " "+op+" "),
+		op:   op,
+		err:  errors.Join(errs...),
+	}
+}
+
+// group returns the Query's expression, wrapped in parentheses if it combines queries with a different operator than
+// the input one.
+func (q Query) group(op string) string {
+	if q.op != "" && q.op != op {
+		return "(" + q.expr + ")"
+	}
+
+	return q.expr
+}
+
+// quotePhrase quotes the input term as an FTS5 string, escaping any double quotes in it.
+func quotePhrase(term string) string {
+	return `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+}
@@ -0,0 +1,101 @@
+package fts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	attrs := []Attribute[string, string]{
+		{Key: "gold report", Value: "copper prices"},
+		{Key: "copper report", Value: "struck gold"},
+		{Key: "gold report", Value: "struck gold"},
+		{Key: "bronze notes", Value: "nothing here"},
+	}
+
+	for _, testcase := range []struct {
+		name  string
+		query Query
+		expr  string
+		wants []Attribute[string, string]
+		err   error
+	}{
+		{
+			name:  "Success/Field",
+			query: Field("val", "gold"),
+			expr:  `{val}:"gold"`,
+			wants: []Attribute[string, string]{
+				{Key: "copper report", Value: "struck gold"},
+				{Key: "gold report", Value: "struck gold"},
+			},
+		},
+		{
+			name:  "Success/And",
+			query: Field("id", "gold").And(Field("val", "gold")),
+			expr:  `{id}:"gold" AND {val}:"gold"`,
+			wants: []Attribute[string, string]{
+				{Key: "gold report", Value: "struck gold"},
+			},
+		},
+		{
+			name:  "Success/Or",
+			query: Field("id", "bronze").Or(Field("val", "copper")),
+			expr:  `{id}:"bronze" OR {val}:"copper"`,
+			wants: []Attribute[string, string]{
+				{Key: "gold report", Value: "copper prices"},
+				{Key: "bronze notes", Value: "nothing here"},
+			},
+		},
+		{
+			name:  "Success/Grouped",
+			query: Field("id", "gold").Or(Field("id", "copper")).And(Field("val", "struck gold")),
+			expr:  `({id}:"gold" OR {id}:"copper") AND {val}:"struck gold"`,
+			wants: []Attribute[string, string]{
+				{Key: "copper report", Value: "struck gold"},
+				{Key: "gold report", Value: "struck gold"},
+			},
+		},
+		{
+			name:  "Success/QuotedTerm",
+			query: Field("val", `say "gold"`),
+			expr:  `{val}:"say ""gold"""`,
+		},
+		{
+			name:  "Fail/InvalidColumn",
+			query: Field("title", "gold").And(Field("val", "gold")),
+			err:   ErrInvalidColumn,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			expr, err := testcase.query.Build()
+			if testcase.err != nil {
+				require.ErrorIs(t, err, testcase.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, testcase.expr, expr)
+
+			if testcase.wants == nil {
+				return
+			}
+
+			ctx := context.Background()
+
+			index, err := NewIndex(filepath.Join(t.TempDir(), "index.db"), attrs...)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, index.Shutdown(ctx))
+			}()
+
+			res, err := index.Search(ctx, expr)
+			require.NoError(t, err)
+			require.Equal(t, testcase.wants, res)
+		})
+	}
+}
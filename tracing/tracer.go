@@ -22,8 +22,14 @@ type ShutdownFunc func(err context.Context) error
 
 // Init registers a new tracer for this service, that exports its spans to the input trace.SpanExporter.
 //
+// The spans are exported by a batch span processor, which can be configured with the input
+// sdktrace.BatchSpanProcessorOption (e.g. sdktrace.WithBatchTimeout to export spans sooner, when debugging locally).
+// With no options, the SDK's defaults are used.
+//
 // This call returns the TracerProvider's shutdown function, and an error if raised.
-func Init(ctx context.Context, traceExporter sdktrace.SpanExporter) (ShutdownFunc, error) {
+func Init(
+	ctx context.Context, traceExporter sdktrace.SpanExporter, opts ...sdktrace.BatchSpanProcessorOption,
+) (ShutdownFunc, error) {
 	res, err := resource.New(ctx,
 		resource.WithAttributes(semconv.ServiceName(ServiceName)),
 	)
@@ -31,7 +37,7 @@ func Init(ctx context.Context, traceExporter sdktrace.SpanExporter) (ShutdownFun
 		return nil, err
 	}
 
-	bsp := sdktrace.NewBatchSpanProcessor(traceExporter)
+	bsp := sdktrace.NewBatchSpanProcessor(traceExporter, opts...)
 	tracerProvider := sdktrace.NewTracerProvider(
 		sdktrace.WithSampler(sdktrace.AlwaysSample()),
 		sdktrace.WithResource(res),
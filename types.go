@@ -22,7 +22,8 @@ type SQLNullable interface {
 		sql.NullString
 }
 
-// SQLType is a type constraint that joins the Number, Char and SQLNullable type constraints.
+// SQLType is a type constraint that joins the Number, Char and SQLNullable type constraints, as well as the
+// CompositeKey type.
 type SQLType interface {
-	Number | Char | SQLNullable
+	Number | Char | SQLNullable | CompositeKey
 }